@@ -7,30 +7,74 @@ import (
 	"syscall"
 
 	"src/internal/config"
+	"src/internal/database"
+	projectsSubscribers "src/internal/modules/projects/infrastructure/subscribers"
+	shared "src/internal/modules/shared/domain"
+	tasksSubscribers "src/internal/modules/tasks/infrastructure/subscribers"
+	"src/internal/modules/webhooks/infrastructure/subscribers"
 	"src/internal/pkg/eventbus"
+	"src/internal/pkg/outbox"
+	"src/internal/pkg/taskqueue"
 
 	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
+	"github.com/hibiken/asynq"
 )
 
 func main() {
-	config.Load()
+	cfg := config.Load()
 	logger := watermill.NewStdLogger(false, false)
 	router, err := eventbus.NewRouter(logger)
 	if err != nil {
 		log.Fatalf("failed to create router: %v", err)
 	}
 
-	// TODO: Register event subscribers here
-	// router.AddHandler("handler_name", "topic_name", publisher, "handler_name", handlerFunc)
+	// A panicking handler returns an error instead of crashing the worker
+	router.AddMiddleware(middleware.Recoverer)
 
-	log.Println("Starting event worker...")
+	subscriber, err := eventbus.NewSubscriber(logger, cfg.EventBus.ConsumerGroup)
+	if err != nil {
+		log.Fatalf("failed to create subscriber: %v", err)
+	}
+
+	publisher, err := eventbus.NewPublisher(logger)
+	if err != nil {
+		log.Fatalf("failed to create publisher: %v", err)
+	}
+
+	// Subscribers hand heavy work over to the job worker
+	jobs := taskqueue.NewClient(asynq.RedisClientOpt{
+		Addr:     cfg.RedisURL(),
+		Password: cfg.Redis.Password,
+	})
+	defer jobs.Close()
+
+	registerHandlers(cfg, router, subscriber, publisher, jobs)
+
+	log.Printf("Starting event worker (backend: %s, consumer group: %s)...", cfg.EventBus.Backend, cfg.EventBus.ConsumerGroup)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// Forward committed outbox rows to the event bus
+	relay := outbox.NewRelay(database.GormDB(), publisher, log.Default(), cfg.Outbox.BatchSize, cfg.Outbox.PollInterval, cfg.Outbox.MaxAttempts)
+	go func() {
+		if err := relay.Run(ctx); err != nil {
+			log.Printf("outbox relay error: %v", err)
+		}
+	}()
+
 	if err := router.Run(ctx); err != nil {
 		log.Fatalf("router error: %v", err)
 	}
 
 	log.Println("Event worker stopped.")
 }
+
+// registerHandlers wires event subscribers to the router
+func registerHandlers(cfg *config.Config, router *message.Router, subscriber message.Subscriber, publisher message.Publisher, jobs *asynq.Client) {
+	subscribers.NewWebhookTriage(publisher, log.Default()).Register(router, subscriber)
+	projectsSubscribers.NewInitialSyncScheduler(jobs, log.Default()).Register(router, subscriber)
+	tasksSubscribers.NewPageSyncScheduler(jobs, shared.NewSystemClock(), cfg.Sync.PageDebounce, log.Default()).Register(router, subscriber)
+}
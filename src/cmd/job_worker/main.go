@@ -2,14 +2,30 @@ package main
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"log"
+	"net/http"
 	"os/signal"
 	"syscall"
 
 	"src/internal/config"
+	"src/internal/database"
+	projectsApp "src/internal/modules/projects/application"
+	projectsJobs "src/internal/modules/projects/infrastructure/jobs"
+	projectsMetrics "src/internal/modules/projects/infrastructure/metrics"
+	projectsPostgres "src/internal/modules/projects/infrastructure/postgres"
+	shared "src/internal/modules/shared/domain"
+	tasksApp "src/internal/modules/tasks/application"
+	tasksJobs "src/internal/modules/tasks/infrastructure/jobs"
+	tasksPostgres "src/internal/modules/tasks/infrastructure/postgres"
+	usersPostgres "src/internal/modules/users/infrastructure/postgres"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/outbox"
 	"src/internal/pkg/taskqueue"
 
 	"github.com/hibiken/asynq"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -20,18 +36,39 @@ func main() {
 		Password: cfg.Redis.Password,
 	}
 
-	mux := asynq.NewServeMux()
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisURL(),
+		Password: cfg.Redis.Password,
+	})
+	defer redisClient.Close()
 
-	// TODO: Register task handlers here
-	// mux.HandleFunc("task:type", handlerFunc)
+	mux := asynq.NewServeMux()
+	registerHandlers(mux, redisClient)
 
 	server := taskqueue.NewServer(redisOpt, cfg.Async.Concurrency, cfg.Async.Queues)
 
+	scheduler, err := asynq.NewPeriodicTaskManager(asynq.PeriodicTaskManagerOpts{
+		RedisConnOpt:               redisOpt,
+		PeriodicTaskConfigProvider: projectsJobs.NewReconcileSchedule(projectsPostgres.NewProjectRepository(database.GormDB()), cfg.Sync.ReconcileInterval),
+		SyncInterval:               cfg.Sync.ReconcileScheduleRefresh,
+	})
+	if err != nil {
+		log.Fatalf("scheduler error: %v", err)
+	}
+
 	log.Println("Starting job worker...")
 
 	if err := server.Start(mux); err != nil {
 		log.Fatalf("server error: %v", err)
 	}
+	if err := scheduler.Start(); err != nil {
+		log.Fatalf("scheduler error: %v", err)
+	}
+
+	var metricsServer *http.Server
+	if cfg.Metrics.Addr != "" {
+		metricsServer = startMetricsServer(cfg.Metrics.Addr)
+	}
 
 	// Create context that listens for the interrupt signal from the OS
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -43,7 +80,71 @@ func main() {
 	log.Println("Shutting down job worker...")
 
 	// Graceful shutdown
+	scheduler.Shutdown()
 	server.Shutdown()
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
 
 	log.Println("Job worker stopped.")
 }
+
+// registerHandlers wires task handlers to the mux
+func registerHandlers(mux *asynq.ServeMux, redisClient *redis.Client) {
+	db := database.GormDB()
+	clock := shared.NewSystemClock()
+	txMgr := database.NewTransactionManager(db)
+	notionOpts := notion.ClientOptionsFromConfig(redisClient)
+
+	projectRepo := projectsPostgres.NewProjectRepository(db)
+	userRepo := usersPostgres.NewUserRepository(db)
+	taskRepo := tasksPostgres.NewTaskRepository(db)
+	dependencyMirror := tasksApp.NewDependencyMirror(taskRepo, tasksPostgres.NewDependencyRepository(db), clock, txMgr)
+	events := outbox.New(db)
+
+	syncService := projectsApp.NewProjectSyncService(
+		projectRepo,
+		userRepo,
+		notion.NewDatabases(notionOpts...),
+		taskRepo,
+		dependencyMirror,
+		events,
+		clock,
+		txMgr,
+	)
+	projectsJobs.NewInitialSyncHandler(syncService, log.Default()).Register(mux)
+
+	reconciliationService := projectsApp.NewReconciliationService(
+		projectRepo,
+		projectsPostgres.NewReconciliationRepository(db),
+		userRepo,
+		notion.NewDatabases(notionOpts...),
+		taskRepo,
+		dependencyMirror,
+		projectsMetrics.NewReconciliationMetrics(expvar.NewMap("projects_reconciliation")),
+		events,
+		clock,
+		txMgr,
+	)
+	projectsJobs.NewReconcileHandler(reconciliationService, log.Default()).Register(mux)
+
+	projectDegrader := projectsApp.NewProjectDegrader(projectRepo, events, clock, txMgr)
+	pageSyncService := tasksApp.NewPageSyncService(projectRepo, userRepo, notion.NewPages(notionOpts...), taskRepo, dependencyMirror, projectDegrader, clock)
+	tasksJobs.NewSynchronizePageHandler(pageSyncService, log.Default()).Register(mux)
+}
+
+// startMetricsServer serves the expvar metrics at /debug/vars
+func startMetricsServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	server := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		log.Printf("Serving metrics on %s/debug/vars", addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("metrics server error: %v", err)
+		}
+	}()
+
+	return server
+}
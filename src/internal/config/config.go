@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	_ "github.com/joho/godotenv/autoload"
 )
@@ -41,6 +42,14 @@ type Config struct {
 		WebhookSecret string
 	}
 
+	// Notion API client throttling and retries
+	NotionClient struct {
+		RateLimitBackend  string // memory (per process) or redis (shared across processes)
+		RequestsPerSecond float64
+		Burst             int
+		MaxRetries        int
+	}
+
 	// JWT configuration
 	JWT struct {
 		Secret string
@@ -49,6 +58,48 @@ type Config struct {
 		Concurrency int
 		Queues      map[string]int
 	}
+
+	// Event bus configuration
+	EventBus struct {
+		Backend       string // gochannel, redis or postgres
+		ConsumerGroup string
+	}
+
+	// Webhook delivery configuration
+	Webhooks struct {
+		// ReplayWindow is how old a Notion event may be and how long event IDs are
+		// remembered for deduplication. Zero disables the timestamp check.
+		ReplayWindow time.Duration
+	}
+
+	// Notion to local database synchronization
+	Sync struct {
+		// PageDebounce is the window in which webhook events for the same page are
+		// coalesced into a single fetch. Zero fetches the page for every event.
+		PageDebounce time.Duration
+
+		// ReconcileInterval is how often projects without their own interval are
+		// swept for changes missed by webhooks. Zero disables their sweeps.
+		ReconcileInterval time.Duration
+
+		// ReconcileScheduleRefresh is how often the job worker reloads the projects
+		// and their intervals into the reconciliation schedule
+		ReconcileScheduleRefresh time.Duration
+	}
+
+	// Metrics configuration
+	Metrics struct {
+		// Addr is where the job worker serves expvar metrics at /debug/vars.
+		// Empty disables the metrics server.
+		Addr string
+	}
+
+	// Transactional outbox relay configuration
+	Outbox struct {
+		PollInterval time.Duration
+		BatchSize    int
+		MaxAttempts  int
+	}
 }
 
 var cfg *Config
@@ -88,6 +139,26 @@ func Load() *Config {
 	cfg.Notion.APIVersion = getEnv("NOTION_API_VERSION", "2022-06-28")
 	cfg.Notion.WebhookSecret = getEnv("NOTION_WEBHOOK_SECRET", "")
 
+	// Notion client
+	cfg.NotionClient.RateLimitBackend = getEnv("NOTION_RATE_LIMIT_BACKEND", "memory")
+	notionRPS, err := strconv.ParseFloat(getEnv("NOTION_RATE_LIMIT_RPS", "3"), 64)
+	if err != nil {
+		log.Fatalf("Invalid NOTION_RATE_LIMIT_RPS value: %v", err)
+	}
+	cfg.NotionClient.RequestsPerSecond = notionRPS
+
+	notionBurst, err := strconv.Atoi(getEnv("NOTION_RATE_LIMIT_BURST", "3"))
+	if err != nil {
+		log.Fatalf("Invalid NOTION_RATE_LIMIT_BURST value: %v", err)
+	}
+	cfg.NotionClient.Burst = notionBurst
+
+	notionMaxRetries, err := strconv.Atoi(getEnv("NOTION_MAX_RETRIES", "5"))
+	if err != nil {
+		log.Fatalf("Invalid NOTION_MAX_RETRIES value: %v", err)
+	}
+	cfg.NotionClient.MaxRetries = notionMaxRetries
+
 	// JWT
 	cfg.JWT.Secret = getEnv("JWT_SECRET", "your-secret-key")
 
@@ -110,6 +181,58 @@ func Load() *Config {
 		log.Fatalf("Invalid ASYNC_QUEUES value: %v", err)
 	}
 
+	// Event bus
+	cfg.EventBus.Backend = getEnv("EVENTBUS_BACKEND", "redis")
+	cfg.EventBus.ConsumerGroup = getEnv("EVENTBUS_CONSUMER_GROUP", "event_worker")
+
+	// Webhooks
+	webhookReplayWindow, err := time.ParseDuration(getEnv("WEBHOOK_REPLAY_WINDOW", "24h"))
+	if err != nil {
+		log.Fatalf("Invalid WEBHOOK_REPLAY_WINDOW value: %v", err)
+	}
+	cfg.Webhooks.ReplayWindow = webhookReplayWindow
+
+	// Sync
+	syncPageDebounce, err := time.ParseDuration(getEnv("SYNC_PAGE_DEBOUNCE", "5s"))
+	if err != nil {
+		log.Fatalf("Invalid SYNC_PAGE_DEBOUNCE value: %v", err)
+	}
+	cfg.Sync.PageDebounce = syncPageDebounce
+
+	syncReconcileInterval, err := time.ParseDuration(getEnv("SYNC_RECONCILE_INTERVAL", "1h"))
+	if err != nil {
+		log.Fatalf("Invalid SYNC_RECONCILE_INTERVAL value: %v", err)
+	}
+	cfg.Sync.ReconcileInterval = syncReconcileInterval
+
+	syncReconcileScheduleRefresh, err := time.ParseDuration(getEnv("SYNC_RECONCILE_SCHEDULE_REFRESH", "1m"))
+	if err != nil {
+		log.Fatalf("Invalid SYNC_RECONCILE_SCHEDULE_REFRESH value: %v", err)
+	}
+	cfg.Sync.ReconcileScheduleRefresh = syncReconcileScheduleRefresh
+
+	// Metrics
+	cfg.Metrics.Addr = getEnv("METRICS_ADDR", "")
+
+	// Outbox
+	outboxPollInterval, err := time.ParseDuration(getEnv("OUTBOX_POLL_INTERVAL", "1s"))
+	if err != nil {
+		log.Fatalf("Invalid OUTBOX_POLL_INTERVAL value: %v", err)
+	}
+	cfg.Outbox.PollInterval = outboxPollInterval
+
+	outboxBatchSize, err := strconv.Atoi(getEnv("OUTBOX_BATCH_SIZE", "100"))
+	if err != nil {
+		log.Fatalf("Invalid OUTBOX_BATCH_SIZE value: %v", err)
+	}
+	cfg.Outbox.BatchSize = outboxBatchSize
+
+	outboxMaxAttempts, err := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "10"))
+	if err != nil {
+		log.Fatalf("Invalid OUTBOX_MAX_ATTEMPTS value: %v", err)
+	}
+	cfg.Outbox.MaxAttempts = outboxMaxAttempts
+
 	return cfg
 }
 
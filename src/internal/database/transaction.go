@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	shared "src/internal/modules/shared/domain"
+)
+
+type txContextKey struct{}
+
+// WithTx returns a copy of ctx that carries the given GORM transaction
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// TxFromContext returns the GORM transaction stored in ctx, if any
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// Conn returns the ambient transaction from ctx, or db when no transaction is running.
+// Repositories use it so that their queries join the caller's transaction automatically.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// TransactionManager implements shared.TransactionManager using GORM transactions
+type TransactionManager struct {
+	db *gorm.DB
+}
+
+// NewTransactionManager creates a new GORM-backed transaction manager
+func NewTransactionManager(db *gorm.DB) shared.TransactionManager {
+	return &TransactionManager{db: db}
+}
+
+// WithinTransaction runs fn inside a database transaction stored in the context.
+// The transaction is committed when fn returns nil and rolled back otherwise.
+// Calls made while a transaction is already running open a savepoint instead:
+// an error rolls back to the savepoint and is returned to the caller, which
+// decides whether the outer transaction fails too.
+func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx).Transaction(func(nested *gorm.DB) error {
+			return fn(WithTx(ctx, nested))
+		})
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"src/internal/modules/projects/domain"
 	shared "src/internal/modules/shared/domain"
@@ -14,6 +15,7 @@ type CreateProjectRequest struct {
 	UserID              uuid.UUID
 	NotionDatabaseID    string
 	NotionWebhookSecret string
+	ReconcileInterval   time.Duration // Zero uses the worker default
 }
 
 // CreateProjectResponse contains the created project data
@@ -23,10 +25,11 @@ type CreateProjectResponse struct {
 
 // CreateProjectUseCase handles project creation business logic
 type CreateProjectUseCase struct {
-	repo  domain.Repository
-	idGen shared.IDGenerator
-	clock shared.Clock
-	txMgr shared.TransactionManager
+	repo   domain.Repository
+	idGen  shared.IDGenerator
+	clock  shared.Clock
+	txMgr  shared.TransactionManager
+	events shared.EventRecorder
 }
 
 // NewCreateProjectUseCase creates a new CreateProjectUseCase
@@ -35,12 +38,14 @@ func NewCreateProjectUseCase(
 	idGen shared.IDGenerator,
 	clock shared.Clock,
 	txMgr shared.TransactionManager,
+	events shared.EventRecorder,
 ) *CreateProjectUseCase {
 	return &CreateProjectUseCase{
-		repo:  repo,
-		idGen: idGen,
-		clock: clock,
-		txMgr: txMgr,
+		repo:   repo,
+		idGen:  idGen,
+		clock:  clock,
+		txMgr:  txMgr,
+		events: events,
 	}
 }
 
@@ -63,6 +68,9 @@ func (uc *CreateProjectUseCase) Execute(ctx context.Context, req CreateProjectRe
 		if err != nil {
 			return err
 		}
+		if err := project.SetReconcileInterval(req.ReconcileInterval, uc.clock); err != nil {
+			return err
+		}
 
 		// Save to repository
 		err = uc.repo.Save(ctx, &project)
@@ -70,6 +78,11 @@ func (uc *CreateProjectUseCase) Execute(ctx context.Context, req CreateProjectRe
 			return err
 		}
 
+		// Record the event in the same transaction as the project
+		if err := uc.events.Record(ctx, domain.NewProjectCreated(project)); err != nil {
+			return err
+		}
+
 		response = CreateProjectResponse{Project: project}
 		return nil
 	})
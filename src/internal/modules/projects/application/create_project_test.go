@@ -39,6 +39,15 @@ func (m *mockProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*do
 	return project, nil
 }
 
+func (m *mockProjectRepository) FindByPublicID(ctx context.Context, publicID string) (*domain.Project, error) {
+	for _, p := range m.projects {
+		if p.PublicID == publicID {
+			return p, nil
+		}
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
 func (m *mockProjectRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Project, error) {
 	var projects []*domain.Project
 	for _, p := range m.projects {
@@ -49,6 +58,14 @@ func (m *mockProjectRepository) FindByUserID(ctx context.Context, userID uuid.UU
 	return projects, nil
 }
 
+func (m *mockProjectRepository) FindAll(ctx context.Context) ([]*domain.Project, error) {
+	var projects []*domain.Project
+	for _, p := range m.projects {
+		projects = append(projects, p)
+	}
+	return projects, nil
+}
+
 func (m *mockProjectRepository) FindByNotionDatabaseID(ctx context.Context, notionDatabaseID string) (*domain.Project, error) {
 	for _, p := range m.projects {
 		if p.NotionDatabaseID == notionDatabaseID {
@@ -58,8 +75,25 @@ func (m *mockProjectRepository) FindByNotionDatabaseID(ctx context.Context, noti
 	return nil, domain.ErrProjectNotFound
 }
 
+// Update keeps the stored sync state, which only UpdateSync writes
 func (m *mockProjectRepository) Update(ctx context.Context, project *domain.Project) error {
-	m.projects[project.ID] = project
+	existing, exists := m.projects[project.ID]
+	if !exists {
+		return domain.ErrProjectNotFound
+	}
+	stored := *project
+	stored.Sync = existing.Sync
+	m.projects[project.ID] = &stored
+	return nil
+}
+
+// UpdateSync stores a copy, so only saved sync states are visible to later lookups
+func (m *mockProjectRepository) UpdateSync(ctx context.Context, project *domain.Project) error {
+	if _, exists := m.projects[project.ID]; !exists {
+		return domain.ErrProjectNotFound
+	}
+	stored := *project
+	m.projects[project.ID] = &stored
 	return nil
 }
 
@@ -96,14 +130,28 @@ func (m *mockTransactionManager) WithinTransaction(ctx context.Context, fn func(
 	return fn(ctx)
 }
 
+type mockEventRecorder struct {
+	events     []shared.Event
+	shouldFail bool
+}
+
+func (m *mockEventRecorder) Record(ctx context.Context, events ...shared.Event) error {
+	if m.shouldFail {
+		return errors.New("outbox unavailable")
+	}
+	m.events = append(m.events, events...)
+	return nil
+}
+
 var _ = Describe("CreateProjectUseCase", func() {
 	var (
-		repo  domain.Repository
-		idGen shared.IDGenerator
-		clock shared.Clock
-		txMgr shared.TransactionManager
-		uc    *application.CreateProjectUseCase
-		ctx   context.Context
+		repo   domain.Repository
+		idGen  shared.IDGenerator
+		clock  shared.Clock
+		txMgr  shared.TransactionManager
+		events *mockEventRecorder
+		uc     *application.CreateProjectUseCase
+		ctx    context.Context
 	)
 
 	BeforeEach(func() {
@@ -111,7 +159,8 @@ var _ = Describe("CreateProjectUseCase", func() {
 		idGen = &mockIDGenerator{}
 		clock = &mockClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
 		txMgr = &mockTransactionManager{}
-		uc = application.NewCreateProjectUseCase(repo, idGen, clock, txMgr)
+		events = &mockEventRecorder{}
+		uc = application.NewCreateProjectUseCase(repo, idGen, clock, txMgr, events)
 		ctx = context.Background()
 	})
 
@@ -132,6 +181,69 @@ var _ = Describe("CreateProjectUseCase", func() {
 			Expect(resp.Project.NotionWebhookSecret).To(Equal(req.NotionWebhookSecret))
 		})
 
+		It("should create a project with its own reconcile interval", func() {
+			req := application.CreateProjectRequest{
+				UserID:              uuid.New(),
+				NotionDatabaseID:    "database_123",
+				NotionWebhookSecret: "secret_123",
+				ReconcileInterval:   15 * time.Minute,
+			}
+
+			resp, err := uc.Execute(ctx, req)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Project.ReconcileInterval).To(Equal(15 * time.Minute))
+		})
+
+		It("should reject reconcile intervals shorter than the minimum", func() {
+			req := application.CreateProjectRequest{
+				UserID:              uuid.New(),
+				NotionDatabaseID:    "database_123",
+				NotionWebhookSecret: "secret_123",
+				ReconcileInterval:   time.Minute,
+			}
+
+			_, err := uc.Execute(ctx, req)
+
+			Expect(err).To(MatchError(domain.ErrInvalidReconcileInterval))
+		})
+
+		It("should record a ProjectCreated event", func() {
+			req := application.CreateProjectRequest{
+				UserID:              uuid.New(),
+				NotionDatabaseID:    "database_123",
+				NotionWebhookSecret: "secret_123",
+			}
+
+			resp, err := uc.Execute(ctx, req)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events.events).To(HaveLen(1))
+			event, ok := events.events[0].(domain.ProjectCreated)
+			Expect(ok).To(BeTrue())
+			Expect(event.EventTopic()).To(Equal(domain.ProjectCreatedTopic))
+			Expect(event.ProjectID).To(Equal(resp.Project.ID))
+			Expect(event.PublicID).To(Equal(resp.Project.PublicID))
+			Expect(event.UserID).To(Equal(req.UserID))
+			Expect(event.NotionDatabaseID).To(Equal(req.NotionDatabaseID))
+		})
+
+		It("should return error when the event cannot be recorded", func() {
+			failingEvents := &mockEventRecorder{shouldFail: true}
+			uc := application.NewCreateProjectUseCase(repo, idGen, clock, txMgr, failingEvents)
+
+			req := application.CreateProjectRequest{
+				UserID:              uuid.New(),
+				NotionDatabaseID:    "database_123",
+				NotionWebhookSecret: "secret_123",
+			}
+
+			_, err := uc.Execute(ctx, req)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("outbox unavailable"))
+		})
+
 		It("should return error when project already exists for the database", func() {
 			// Create first project
 			req1 := application.CreateProjectRequest{
@@ -156,7 +268,7 @@ var _ = Describe("CreateProjectUseCase", func() {
 
 		It("should return error when transaction fails", func() {
 			txMgr := &mockTransactionManager{shouldFail: true}
-			uc := application.NewCreateProjectUseCase(repo, idGen, clock, txMgr)
+			uc := application.NewCreateProjectUseCase(repo, idGen, clock, txMgr, events)
 
 			req := application.CreateProjectRequest{
 				UserID:              uuid.New(),
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+)
+
+// ProjectDegrader marks projects degraded when work outside the sync services finds
+// that their tasks can no longer follow Notion, e.g. a page sync that hits a broken
+// property mapping
+type ProjectDegrader struct {
+	projects domain.Repository
+	events   shared.EventRecorder
+	clock    shared.Clock
+	txMgr    shared.TransactionManager
+}
+
+// NewProjectDegrader creates a new ProjectDegrader
+func NewProjectDegrader(
+	projects domain.Repository,
+	events shared.EventRecorder,
+	clock shared.Clock,
+	txMgr shared.TransactionManager,
+) *ProjectDegrader {
+	return &ProjectDegrader{
+		projects: projects,
+		events:   events,
+		clock:    clock,
+		txMgr:    txMgr,
+	}
+}
+
+// Degrade records cause on a synced project and marks it degraded, publishing the
+// status change. Projects that cannot become degraded, such as those still running
+// their initial sync, are left alone; their own sync reports the problem.
+func (d *ProjectDegrader) Degrade(ctx context.Context, projectID uuid.UUID, cause error) error {
+	project, err := d.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if !project.Sync.Status.CanTransitionTo(domain.SyncStatusDegraded) {
+		return nil
+	}
+
+	previous := project.Sync.Status
+	if err := project.Degrade(cause, d.clock); err != nil {
+		return err
+	}
+	return d.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		return saveSyncState(ctx, d.projects, d.events, project, previous)
+	})
+}
@@ -0,0 +1,63 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/domain"
+)
+
+var _ = Describe("ProjectDegrader", func() {
+	var (
+		ctx      context.Context
+		projects *mockProjectRepository
+		events   *mockEventRecorder
+		clock    *mockClock
+		degrader *application.ProjectDegrader
+		project  domain.Project
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		projects = newMockProjectRepository()
+		events = &mockEventRecorder{}
+		clock = &mockClock{now: time.Date(2025, 10, 27, 9, 0, 0, 0, time.UTC)}
+		degrader = application.NewProjectDegrader(projects, events, clock, &mockTransactionManager{})
+
+		project = domain.Project{ID: uuid.New(), PublicID: "project_1", UserID: uuid.New()}
+		project.Sync.Status = domain.SyncStatusSynced
+		Expect(projects.Save(ctx, &project)).To(Succeed())
+	})
+
+	It("degrades a synced project and records the status change", func() {
+		Expect(degrader.Degrade(ctx, project.ID, errors.New("mapping broken"))).To(Succeed())
+
+		degraded := projects.projects[project.ID].Sync
+		Expect(degraded.Status).To(Equal(domain.SyncStatusDegraded))
+		Expect(degraded.LastError).To(Equal("mapping broken"))
+		Expect(degraded.StatusChangedAt).To(Equal(clock.now))
+		Expect(events.events).To(HaveLen(1))
+	})
+
+	It("leaves projects running their initial sync alone", func() {
+		project.Sync.Status = domain.SyncStatusInitialSyncing
+		Expect(projects.Save(ctx, &project)).To(Succeed())
+
+		Expect(degrader.Degrade(ctx, project.ID, errors.New("mapping broken"))).To(Succeed())
+
+		Expect(projects.projects[project.ID].Sync.Status).To(Equal(domain.SyncStatusInitialSyncing))
+		Expect(events.events).To(BeEmpty())
+	})
+
+	It("returns ErrProjectNotFound for unknown projects", func() {
+		err := degrader.Degrade(ctx, uuid.New(), errors.New("mapping broken"))
+
+		Expect(err).To(MatchError(domain.ErrProjectNotFound))
+	})
+})
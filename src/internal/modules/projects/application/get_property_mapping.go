@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	tasksApp "src/internal/modules/tasks/application"
+	usersDomain "src/internal/modules/users/domain"
+)
+
+// GetPropertyMappingRequest identifies the project whose mapping is requested
+type GetPropertyMappingRequest struct {
+	UserID   uuid.UUID
+	PublicID string
+}
+
+// GetPropertyMappingResponse describes a project's property mapping against the live
+// schema of its Notion database
+type GetPropertyMappingResponse struct {
+	Project   domain.Project
+	Mapping   domain.PropertyMapping
+	Suggested domain.PropertyMapping
+	Problems  map[string]string // Why the mapping no longer fits the schema, by field
+	Schema    domain.Schema
+}
+
+// GetPropertyMappingUseCase handles requests for a project's property mapping
+type GetPropertyMappingUseCase struct {
+	repo      domain.Repository
+	users     UserLookup
+	databases DatabaseRetriever
+	clock     shared.Clock
+}
+
+// NewGetPropertyMappingUseCase creates a new GetPropertyMappingUseCase
+func NewGetPropertyMappingUseCase(
+	repo domain.Repository,
+	users UserLookup,
+	databases DatabaseRetriever,
+	clock shared.Clock,
+) *GetPropertyMappingUseCase {
+	return &GetPropertyMappingUseCase{
+		repo:      repo,
+		users:     users,
+		databases: databases,
+		clock:     clock,
+	}
+}
+
+// Execute returns the property mapping of a project of the user, checked against the
+// schema of its Notion database, along with the mapping the schema suggests.
+// Projects of other users are reported as domain.ErrProjectNotFound.
+func (uc *GetPropertyMappingUseCase) Execute(ctx context.Context, req GetPropertyMappingRequest) (GetPropertyMappingResponse, error) {
+	project, err := uc.repo.FindByPublicID(ctx, req.PublicID)
+	if err != nil {
+		return GetPropertyMappingResponse{}, err
+	}
+	if project.UserID != req.UserID {
+		return GetPropertyMappingResponse{}, domain.ErrProjectNotFound
+	}
+
+	schema, err := liveSchema(ctx, uc.users, uc.databases, uc.clock, project)
+	if err != nil {
+		return GetPropertyMappingResponse{}, err
+	}
+
+	resp := GetPropertyMappingResponse{
+		Project:   *project,
+		Mapping:   project.PropertyMapping,
+		Suggested: domain.SuggestPropertyMapping(schema, project.NotionDatabaseID),
+		Schema:    schema,
+	}
+
+	var mappingErr *domain.PropertyMappingError
+	if err := project.PropertyMapping.Validate(schema, project.NotionDatabaseID); errors.As(err, &mappingErr) {
+		resp.Problems = mappingErr.Problems
+	}
+	return resp, nil
+}
+
+// liveSchema fetches the schema of the project's Notion database with its owner's token
+func liveSchema(ctx context.Context, users UserLookup, databases DatabaseRetriever, clock shared.Clock, project *domain.Project) (domain.Schema, error) {
+	owner, err := users.GetByUUID(ctx, project.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !owner.HasValidNotionToken(clock) {
+		return nil, usersDomain.ErrNotionTokenMissing
+	}
+
+	database, err := databases.Retrieve(ctx, owner.NotionAccessToken, project.NotionDatabaseID)
+	if err != nil {
+		return nil, err
+	}
+	return tasksApp.DatabaseSchema(*database), nil
+}
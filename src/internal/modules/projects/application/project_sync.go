@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	tasksApp "src/internal/modules/tasks/application"
+	tasksDomain "src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+)
+
+// queryPageSize is the largest page size Notion accepts for database queries
+const queryPageSize = 100
+
+// UserLookup is the subset of the users repository used to find a project owner's Notion token
+type UserLookup interface {
+	GetByUUID(ctx context.Context, id uuid.UUID) (usersDomain.User, error)
+}
+
+// DatabaseQuerier reads the schema of a Notion database and queries its pages, one
+// batch at a time or all of them (see notion.Databases)
+type DatabaseQuerier interface {
+	DatabaseRetriever
+	Query(ctx context.Context, accessToken, databaseID string, request *notion.DatabaseQueryRequest) (*notion.DatabaseQueryResponse, error)
+	QueryAll(ctx context.Context, accessToken, databaseID string, request *notion.DatabaseQueryRequest) iter.Seq2[notion.Page, error]
+}
+
+// TaskStore is the subset of the tasks repository used to store imported pages
+type TaskStore interface {
+	Upsert(ctx context.Context, task *tasksDomain.Task) error
+}
+
+// DependencyPuller mirrors the dependency relation of a project's stored tasks
+// into task dependencies (see tasks application.DependencyMirror)
+type DependencyPuller interface {
+	Pull(ctx context.Context, project *domain.Project) error
+}
+
+// ProjectSyncService imports projects' Notion databases into local tasks
+type ProjectSyncService struct {
+	projects  domain.Repository
+	users     UserLookup
+	databases DatabaseQuerier
+	tasks     TaskStore
+	deps      DependencyPuller
+	events    shared.EventRecorder
+	clock     shared.Clock
+	txMgr     shared.TransactionManager
+}
+
+// NewProjectSyncService creates a new ProjectSyncService
+func NewProjectSyncService(
+	projects domain.Repository,
+	users UserLookup,
+	databases DatabaseQuerier,
+	tasks TaskStore,
+	deps DependencyPuller,
+	events shared.EventRecorder,
+	clock shared.Clock,
+	txMgr shared.TransactionManager,
+) *ProjectSyncService {
+	return &ProjectSyncService{
+		projects:  projects,
+		users:     users,
+		databases: databases,
+		tasks:     tasks,
+		deps:      deps,
+		events:    events,
+		clock:     clock,
+		txMgr:     txMgr,
+	}
+}
+
+// PerformInitialSync pages through the project's Notion database and stores every
+// page as a task, reading task fields through the project's property mapping; a
+// project without one gets the mapping suggested by its database schema. Each
+// batch of tasks is committed together with the cursor of the next batch, so a
+// sync interrupted by a worker restart resumes where it stopped. Once every page
+// is stored, task dependencies are pulled from the project's dependency relation.
+// Every status change is recorded as a ProjectSyncStatusChanged event. A property
+// mapping that does not fit the database fails the initial sync; once a project
+// is synced, a broken mapping degrades it instead (see ReconciliationService and
+// ProjectDegrader). Syncing a project whose initial sync has completed does nothing.
+func (s *ProjectSyncService) PerformInitialSync(ctx context.Context, projectID uuid.UUID) (*domain.Project, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Sync.InitialSyncCompleted() {
+		return project, nil
+	}
+
+	previous := project.Sync.Status
+	if err := project.StartInitialSync(s.clock); err != nil {
+		return nil, err
+	}
+	err = s.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		return saveSyncState(ctx, s.projects, s.events, project, previous)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.importPages(ctx, project)
+	if err == nil {
+		err = s.deps.Pull(ctx, project)
+	}
+	if err != nil {
+		// A cancelled context means the worker is stopping, not that the sync failed
+		if ctx.Err() == nil {
+			if failErr := s.fail(ctx, project, err); failErr != nil {
+				return project, errors.Join(err, failErr)
+			}
+		}
+		return project, err
+	}
+
+	previous = project.Sync.Status
+	if err := project.CompleteInitialSync(s.clock); err != nil {
+		return project, err
+	}
+	err = s.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		return saveSyncState(ctx, s.projects, s.events, project, previous)
+	})
+	if err != nil {
+		return project, err
+	}
+
+	return project, nil
+}
+
+// fail records why the initial sync stopped
+func (s *ProjectSyncService) fail(ctx context.Context, project *domain.Project, cause error) error {
+	previous := project.Sync.Status
+	var err error
+	if notionAccessRevoked(cause) {
+		err = project.RevokeToken(cause, s.clock)
+	} else {
+		err = project.FailInitialSync(cause, s.clock)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		return saveSyncState(ctx, s.projects, s.events, project, previous)
+	})
+}
+
+// importPages stores the project's pages from its sync cursor onwards
+func (s *ProjectSyncService) importPages(ctx context.Context, project *domain.Project) error {
+	owner, err := s.users.GetByUUID(ctx, project.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load project owner: %w", err)
+	}
+	if !owner.HasValidNotionToken(s.clock) {
+		return usersDomain.ErrNotionTokenMissing
+	}
+
+	err = resolvePropertyMapping(ctx, s.projects, s.databases, s.clock, owner.NotionAccessToken, project)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := s.databases.Query(ctx, owner.NotionAccessToken, project.NotionDatabaseID, &notion.DatabaseQueryRequest{
+			StartCursor: project.Sync.Cursor,
+			PageSize:    queryPageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		nextCursor := resp.NextCursor
+		if !resp.HasMore {
+			nextCursor = ""
+		}
+
+		// Work on a copy so a failed batch leaves the project at the last committed cursor
+		batch := *project
+		err = s.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+			for _, page := range resp.Results {
+				if err := s.storePage(ctx, project, page); err != nil {
+					return err
+				}
+			}
+
+			batch.AdvanceInitialSync(nextCursor, len(resp.Results))
+			return s.projects.UpdateSync(ctx, &batch)
+		})
+		if err != nil {
+			return err
+		}
+		*project = batch
+
+		if nextCursor == "" {
+			return nil
+		}
+	}
+}
+
+// storePage creates or updates the task mirroring a Notion page. A page synced from
+// a webhook while the initial sync was running may already be stored in a newer
+// version, which is kept.
+func (s *ProjectSyncService) storePage(ctx context.Context, project *domain.Project, page notion.Page) error {
+	snapshot, err := tasksApp.NotionSnapshot(page, project.PropertyMapping)
+	if err != nil {
+		return err
+	}
+
+	task, err := tasksDomain.NewTask(project.ID, snapshot, s.clock)
+	if err != nil {
+		return err
+	}
+
+	err = s.tasks.Upsert(ctx, &task)
+	if errors.Is(err, tasksDomain.ErrStaleTask) {
+		return nil
+	}
+	return err
+}
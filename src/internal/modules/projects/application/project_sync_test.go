@@ -0,0 +1,327 @@
+package application_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/domain"
+	tasksDomain "src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/notion/notiontest"
+)
+
+type mockUserLookup struct {
+	users map[uuid.UUID]usersDomain.User
+}
+
+func (m *mockUserLookup) GetByUUID(ctx context.Context, id uuid.UUID) (usersDomain.User, error) {
+	user, exists := m.users[id]
+	if !exists {
+		return usersDomain.User{}, usersDomain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+type mockTaskStore struct {
+	tasks   map[string]tasksDomain.Task
+	upserts int
+}
+
+func (m *mockTaskStore) Upsert(ctx context.Context, task *tasksDomain.Task) error {
+	if existing, exists := m.tasks[task.NotionPageID]; exists {
+		if existing.NotionLastEditedAt.After(task.NotionLastEditedAt) {
+			return tasksDomain.ErrStaleTask
+		}
+		task.ID = existing.ID
+		task.CreatedAt = existing.CreatedAt
+	}
+	m.tasks[task.NotionPageID] = *task
+	m.upserts++
+	return nil
+}
+
+func (m *mockTaskStore) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*tasksDomain.Task, error) {
+	var tasks []*tasksDomain.Task
+	for _, task := range m.tasks {
+		if task.ProjectID == projectID {
+			tasks = append(tasks, &task)
+		}
+	}
+	return tasks, nil
+}
+
+type mockDependencyPuller struct {
+	pulled []domain.PropertyMapping
+	err    error
+}
+
+func (m *mockDependencyPuller) Pull(ctx context.Context, project *domain.Project) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.pulled = append(m.pulled, project.PropertyMapping)
+	return nil
+}
+
+// failingQuerier fails the query with the given (1-based) index
+type failingQuerier struct {
+	application.DatabaseQuerier
+	failAt int
+	calls  int
+}
+
+func (q *failingQuerier) Query(ctx context.Context, accessToken, databaseID string, request *notion.DatabaseQueryRequest) (*notion.DatabaseQueryResponse, error) {
+	q.calls++
+	if q.calls == q.failAt {
+		return nil, errors.New("notion unavailable")
+	}
+	return q.DatabaseQuerier.Query(ctx, accessToken, databaseID, request)
+}
+
+// QueryAll follows the cursors through Query, so iterating fails at the same query
+func (q *failingQuerier) QueryAll(ctx context.Context, accessToken, databaseID string, request *notion.DatabaseQueryRequest) iter.Seq2[notion.Page, error] {
+	return func(yield func(notion.Page, error) bool) {
+		next := *request
+		for {
+			resp, err := q.Query(ctx, accessToken, databaseID, &next)
+			if err != nil {
+				yield(notion.Page{}, err)
+				return
+			}
+			for _, page := range resp.Results {
+				if !yield(page, nil) {
+					return
+				}
+			}
+			if !resp.HasMore {
+				return
+			}
+			next.StartCursor = resp.NextCursor
+		}
+	}
+}
+
+var _ = Describe("ProjectSyncService", func() {
+	var (
+		ctx       context.Context
+		server    *notiontest.Server
+		databases *notion.Databases
+		projects  *mockProjectRepository
+		events    *mockEventRecorder
+		users     *mockUserLookup
+		tasks     *mockTaskStore
+		deps      *mockDependencyPuller
+		clock     *mockClock
+		project   domain.Project
+	)
+
+	newService := func(querier application.DatabaseQuerier) *application.ProjectSyncService {
+		return application.NewProjectSyncService(projects, users, querier, tasks, deps, events, clock, &mockTransactionManager{})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = notiontest.NewServer()
+		DeferCleanup(server.Close)
+		server.PageSize = 2
+		databases = notion.NewDatabases(server.ClientOptions()...)
+
+		database := server.AddDatabase(notion.Database{Properties: map[string]notion.Property{
+			"Name":   {Type: "title", Title: &struct{}{}},
+			"Status": {Type: "status"},
+		}})
+		for i := 1; i <= 5; i++ {
+			server.AddPage(notion.Page{
+				Parent: notion.Parent{Type: "database_id", DatabaseID: database.ID},
+				Properties: map[string]notion.PropertyValue{
+					"Name":   {Title: []notion.RichText{notion.NewText(fmt.Sprintf("Task %d", i))}},
+					"Status": {Status: &notion.SelectOption{Name: "Not started"}},
+				},
+			})
+		}
+
+		owner := usersDomain.User{ID: uuid.New(), NotionAccessToken: notiontest.DefaultToken}
+		users = &mockUserLookup{users: map[uuid.UUID]usersDomain.User{owner.ID: owner}}
+
+		clock = &mockClock{now: time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)}
+		projects = newMockProjectRepository()
+		project = domain.Project{
+			ID:               uuid.New(),
+			PublicID:         "project_1",
+			UserID:           owner.ID,
+			NotionDatabaseID: database.ID,
+			Sync:             domain.SyncState{Status: domain.SyncStatusPending},
+		}
+		Expect(projects.Save(ctx, &project)).To(Succeed())
+
+		events = &mockEventRecorder{}
+		tasks = &mockTaskStore{tasks: make(map[string]tasksDomain.Task)}
+		deps = &mockDependencyPuller{}
+	})
+
+	// statusChanges returns the sync status transitions recorded as events
+	statusChanges := func() []string {
+		var changes []string
+		for _, event := range events.events {
+			changed, ok := event.(domain.ProjectSyncStatusChanged)
+			Expect(ok).To(BeTrue())
+			Expect(changed.ProjectID).To(Equal(project.ID))
+			changes = append(changes, string(changed.From)+" -> "+string(changed.To))
+		}
+		return changes
+	}
+
+	It("imports every page of the database as a task", func() {
+		synced, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(synced.Sync.Status).To(Equal(domain.SyncStatusSynced))
+		Expect(synced.Sync.PagesSynced).To(Equal(5))
+		Expect(synced.Sync.Cursor).To(BeEmpty())
+		Expect(*synced.Sync.StartedAt).To(Equal(clock.now))
+		Expect(*synced.Sync.SyncedAt).To(Equal(clock.now))
+		Expect(projects.projects[project.ID].Sync).To(Equal(synced.Sync))
+		Expect(statusChanges()).To(Equal([]string{"pending -> initial_syncing", "initial_syncing -> synced"}))
+
+		Expect(tasks.tasks).To(HaveLen(5))
+		for _, task := range tasks.tasks {
+			Expect(task.ProjectID).To(Equal(project.ID))
+			Expect(task.Title).To(HavePrefix("Task "))
+			Expect(task.SyncedAt).To(Equal(clock.now))
+
+			var properties map[string]notion.PropertyValue
+			Expect(json.Unmarshal(task.Properties, &properties)).To(Succeed())
+			Expect(properties["Status"].Status.Name).To(Equal("Not started"))
+		}
+	})
+
+	It("suggests a property mapping for the database and reads task fields through it", func() {
+		synced, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(synced.PropertyMapping).To(Equal(domain.PropertyMapping{Status: "Status"}))
+		Expect(projects.projects[project.ID].PropertyMapping).To(Equal(synced.PropertyMapping))
+		for _, task := range tasks.tasks {
+			Expect(task.Status).To(Equal("Not started"))
+		}
+	})
+
+	It("fails when the stored property mapping no longer fits the database", func() {
+		project.PropertyMapping = domain.PropertyMapping{Status: "Stage"}
+		Expect(projects.Save(ctx, &project)).To(Succeed())
+
+		_, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).To(MatchError(domain.ErrInvalidPropertyMapping))
+		failed := projects.projects[project.ID]
+		Expect(failed.Sync.Status).To(Equal(domain.SyncStatusFailed))
+		Expect(failed.Sync.LastError).To(ContainSubstring(`status: property "Stage" not found`))
+		Expect(failed.PropertyMapping).To(Equal(domain.PropertyMapping{Status: "Stage"}))
+		Expect(tasks.tasks).To(BeEmpty())
+	})
+
+	It("pulls task dependencies through the resolved mapping once every page is stored", func() {
+		synced, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deps.pulled).To(Equal([]domain.PropertyMapping{synced.PropertyMapping}))
+	})
+
+	It("fails when task dependencies cannot be pulled", func() {
+		deps.err = errors.New("database unavailable")
+
+		_, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).To(MatchError("database unavailable"))
+		failed := projects.projects[project.ID].Sync
+		Expect(failed.Status).To(Equal(domain.SyncStatusFailed))
+		Expect(failed.LastError).To(Equal("database unavailable"))
+	})
+
+	It("resumes from the last stored cursor after a failure", func() {
+		startedAt := clock.now
+		_, err := newService(&failingQuerier{DatabaseQuerier: databases, failAt: 2}).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).To(MatchError("notion unavailable"))
+		failed := projects.projects[project.ID].Sync
+		Expect(failed.Status).To(Equal(domain.SyncStatusFailed))
+		Expect(failed.LastError).To(Equal("notion unavailable"))
+		Expect(failed.PagesSynced).To(Equal(2))
+		Expect(failed.Cursor).ToNot(BeEmpty())
+		Expect(tasks.upserts).To(Equal(2))
+
+		clock.now = clock.now.Add(time.Minute)
+		synced, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(synced.Sync.Status).To(Equal(domain.SyncStatusSynced))
+		Expect(synced.Sync.PagesSynced).To(Equal(5))
+		Expect(synced.Sync.LastError).To(BeEmpty())
+		Expect(*synced.Sync.StartedAt).To(Equal(startedAt))
+		// Pages stored before the failure are not fetched again
+		Expect(tasks.upserts).To(Equal(5))
+		Expect(tasks.tasks).To(HaveLen(5))
+		Expect(statusChanges()).To(Equal([]string{
+			"pending -> initial_syncing",
+			"initial_syncing -> failed",
+			"failed -> initial_syncing",
+			"initial_syncing -> synced",
+		}))
+	})
+
+	It("does nothing once the initial sync has completed", func() {
+		_, err := newService(databases).PerformInitialSync(ctx, project.ID)
+		Expect(err).ToNot(HaveOccurred())
+		requests := len(server.Requests())
+		recorded := len(events.events)
+
+		synced, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(synced.Sync.Status).To(Equal(domain.SyncStatusSynced))
+		Expect(server.Requests()).To(HaveLen(requests))
+		Expect(events.events).To(HaveLen(recorded))
+	})
+
+	It("marks the token revoked when the owner has not connected Notion", func() {
+		owner := users.users[project.UserID]
+		owner.NotionAccessToken = ""
+		users.users[project.UserID] = owner
+
+		_, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		Expect(err).To(MatchError(usersDomain.ErrNotionTokenMissing))
+		Expect(projects.projects[project.ID].Sync.Status).To(Equal(domain.SyncStatusTokenRevoked))
+		Expect(statusChanges()).To(Equal([]string{"pending -> initial_syncing", "initial_syncing -> token_revoked"}))
+		Expect(server.Requests()).To(BeEmpty())
+	})
+
+	It("marks the token revoked when Notion rejects it", func() {
+		owner := users.users[project.UserID]
+		owner.NotionAccessToken = "secret_revoked"
+		users.users[project.UserID] = owner
+
+		_, err := newService(databases).PerformInitialSync(ctx, project.ID)
+
+		var apiErr *notion.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(projects.projects[project.ID].Sync.Status).To(Equal(domain.SyncStatusTokenRevoked))
+		Expect(projects.projects[project.ID].Sync.LastError).ToNot(BeEmpty())
+	})
+
+	It("returns ErrProjectNotFound for unknown projects", func() {
+		_, err := newService(databases).PerformInitialSync(ctx, uuid.New())
+
+		Expect(err).To(MatchError(domain.ErrProjectNotFound))
+	})
+})
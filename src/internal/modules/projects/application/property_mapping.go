@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	tasksApp "src/internal/modules/tasks/application"
+	"src/internal/pkg/notion"
+)
+
+// DatabaseRetriever fetches the schema of a Notion database (see notion.Databases)
+type DatabaseRetriever interface {
+	Retrieve(ctx context.Context, accessToken, databaseID string) (*notion.Database, error)
+}
+
+// resolvePropertyMapping checks the project's property mapping against the live
+// schema of its database. A project without a mapping gets the suggested one,
+// which is stored with the project. It returns a *domain.PropertyMappingError when
+// the schema changed under the mapping.
+func resolvePropertyMapping(
+	ctx context.Context,
+	projects domain.Repository,
+	databases DatabaseRetriever,
+	clock shared.Clock,
+	accessToken string,
+	project *domain.Project,
+) error {
+	database, err := databases.Retrieve(ctx, accessToken, project.NotionDatabaseID)
+	if err != nil {
+		return err
+	}
+	schema := tasksApp.DatabaseSchema(*database)
+
+	if !project.PropertyMapping.IsZero() {
+		return project.PropertyMapping.Validate(schema, project.NotionDatabaseID)
+	}
+
+	suggested := domain.SuggestPropertyMapping(schema, project.NotionDatabaseID)
+	if suggested.IsZero() {
+		return nil
+	}
+	if err := project.SetPropertyMapping(suggested, schema, clock); err != nil {
+		return err
+	}
+	return projects.Update(ctx, project)
+}
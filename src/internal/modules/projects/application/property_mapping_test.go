@@ -0,0 +1,150 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/notion/notiontest"
+)
+
+var _ = Describe("Property mapping use cases", func() {
+	var (
+		ctx       context.Context
+		server    *notiontest.Server
+		databases *notion.Databases
+		repo      *mockProjectRepository
+		users     *mockUserLookup
+		clock     *mockClock
+		project   domain.Project
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = notiontest.NewServer()
+		DeferCleanup(server.Close)
+		databases = notion.NewDatabases(server.ClientOptions()...)
+
+		databaseID := uuid.NewString()
+		server.AddDatabase(notion.Database{ID: databaseID, Properties: map[string]notion.Property{
+			"Name":     {Type: "title", Title: &struct{}{}},
+			"Status":   {Type: "status"},
+			"Deadline": {Type: "date", Date: &struct{}{}},
+			"Owner":    {Type: "people", People: &struct{}{}},
+			"Parent item": {Type: "relation", Relation: &struct {
+				DatabaseID         string `json:"database_id"`
+				SyncedPropertyName string `json:"synced_property_name,omitempty"`
+				SyncedPropertyID   string `json:"synced_property_id,omitempty"`
+			}{DatabaseID: databaseID}},
+		}})
+
+		owner := usersDomain.User{ID: uuid.New(), NotionAccessToken: notiontest.DefaultToken}
+		users = &mockUserLookup{users: map[uuid.UUID]usersDomain.User{owner.ID: owner}}
+
+		clock = &mockClock{now: time.Date(2025, 10, 25, 9, 0, 0, 0, time.UTC)}
+		repo = newMockProjectRepository()
+		project = domain.Project{ID: uuid.New(), PublicID: "project_1", UserID: owner.ID, NotionDatabaseID: databaseID}
+		Expect(repo.Save(ctx, &project)).To(Succeed())
+	})
+
+	Describe("GetPropertyMappingUseCase", func() {
+		var uc *application.GetPropertyMappingUseCase
+
+		BeforeEach(func() {
+			uc = application.NewGetPropertyMappingUseCase(repo, users, databases, clock)
+		})
+
+		It("should describe the mapping against the live schema", func() {
+			repo.projects[project.ID].PropertyMapping = domain.PropertyMapping{Status: "Status", Assignee: "Assignee"}
+
+			resp, err := uc.Execute(ctx, application.GetPropertyMappingRequest{UserID: project.UserID, PublicID: project.PublicID})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Mapping).To(Equal(domain.PropertyMapping{Status: "Status", Assignee: "Assignee"}))
+			Expect(resp.Suggested).To(Equal(domain.PropertyMapping{
+				Start:    "Deadline",
+				End:      "Deadline",
+				Status:   "Status",
+				Assignee: "Owner",
+				Parent:   "Parent item",
+			}))
+			Expect(resp.Problems).To(Equal(map[string]string{"assignee": `property "Assignee" not found`}))
+			Expect(resp.Schema).To(HaveKeyWithValue("Parent item", domain.SchemaProperty{Type: "relation", RelatedDatabaseID: project.NotionDatabaseID}))
+		})
+
+		It("should not reveal projects of other users", func() {
+			_, err := uc.Execute(ctx, application.GetPropertyMappingRequest{UserID: uuid.New(), PublicID: project.PublicID})
+
+			Expect(err).To(MatchError(domain.ErrProjectNotFound))
+		})
+
+		It("should require the owner's Notion token", func() {
+			owner := users.users[project.UserID]
+			owner.NotionAccessToken = ""
+			users.users[project.UserID] = owner
+
+			_, err := uc.Execute(ctx, application.GetPropertyMappingRequest{UserID: project.UserID, PublicID: project.PublicID})
+
+			Expect(err).To(MatchError(usersDomain.ErrNotionTokenMissing))
+		})
+	})
+
+	Describe("UpdatePropertyMappingUseCase", func() {
+		var uc *application.UpdatePropertyMappingUseCase
+
+		BeforeEach(func() {
+			uc = application.NewUpdatePropertyMappingUseCase(repo, users, databases, clock, &mockTransactionManager{})
+		})
+
+		It("should store a mapping that fits the schema", func() {
+			mapping := domain.PropertyMapping{Start: "Deadline", End: "Deadline", Status: "Status", Parent: "Parent item"}
+
+			updated, err := uc.Execute(ctx, application.UpdatePropertyMappingRequest{
+				UserID:   project.UserID,
+				PublicID: project.PublicID,
+				Mapping:  mapping,
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated.PropertyMapping).To(Equal(mapping))
+			Expect(updated.UpdatedAt).To(Equal(clock.now))
+			Expect(repo.projects[project.ID].PropertyMapping).To(Equal(mapping))
+		})
+
+		It("should reject a mapping that does not fit the schema", func() {
+			_, err := uc.Execute(ctx, application.UpdatePropertyMappingRequest{
+				UserID:   project.UserID,
+				PublicID: project.PublicID,
+				Mapping:  domain.PropertyMapping{Start: "Status", Assignee: "Owner", Dependency: "Blocked by"},
+			})
+
+			Expect(err).To(MatchError(domain.ErrInvalidPropertyMapping))
+			var mappingErr *domain.PropertyMappingError
+			Expect(errors.As(err, &mappingErr)).To(BeTrue())
+			Expect(mappingErr.Problems).To(Equal(map[string]string{
+				"start":      `property "Status" is of type status, expected date`,
+				"dependency": `property "Blocked by" not found`,
+			}))
+			Expect(repo.projects[project.ID].PropertyMapping.IsZero()).To(BeTrue())
+		})
+
+		It("should not change projects of other users", func() {
+			_, err := uc.Execute(ctx, application.UpdatePropertyMappingRequest{
+				UserID:   uuid.New(),
+				PublicID: project.PublicID,
+				Mapping:  domain.PropertyMapping{Status: "Status"},
+			})
+
+			Expect(err).To(MatchError(domain.ErrProjectNotFound))
+			Expect(repo.projects[project.ID].PropertyMapping.IsZero()).To(BeTrue())
+		})
+	})
+})
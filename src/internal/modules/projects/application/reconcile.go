@@ -0,0 +1,306 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	tasksApp "src/internal/modules/tasks/application"
+	tasksDomain "src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+)
+
+// reconcileEditMargin widens the edit window of a sweep. Notion rounds
+// last_edited_time down to the minute, so a page edited just after the previous
+// sweep started can carry an earlier timestamp.
+const reconcileEditMargin = time.Minute
+
+// TaskReconciler is the subset of the tasks repository used to compare and repair tasks
+type TaskReconciler interface {
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*tasksDomain.Task, error)
+	Upsert(ctx context.Context, task *tasksDomain.Task) error
+}
+
+// ReconciliationMetrics records the outcome of reconciliation sweeps
+type ReconciliationMetrics interface {
+	ObserveSweep(report ReconciliationReport, err error)
+}
+
+// ReconciliationReport summarizes what a sweep found
+type ReconciliationReport struct {
+	ProjectID uuid.UUID
+	Since     time.Time // Pages edited from then on were compared with their tasks
+	Checked   int       // Pages edited since the previous sweep
+	Created   int       // Pages without a task
+	Updated   int       // Tasks that differed from their page
+	Archived  int       // Tasks whose page was deleted or moved out of the database
+	Skipped   bool      // The initial sync has not completed yet
+}
+
+// Divergences returns the number of tasks the sweep repaired
+func (r ReconciliationReport) Divergences() int {
+	return r.Created + r.Updated + r.Archived
+}
+
+// ReconciliationService repairs tasks that missed webhook events
+type ReconciliationService struct {
+	projects        domain.Repository
+	reconciliations domain.ReconciliationRepository
+	users           UserLookup
+	databases       DatabaseQuerier
+	tasks           TaskReconciler
+	deps            DependencyPuller
+	metrics         ReconciliationMetrics
+	events          shared.EventRecorder
+	clock           shared.Clock
+	txMgr           shared.TransactionManager
+}
+
+// NewReconciliationService creates a new ReconciliationService
+func NewReconciliationService(
+	projects domain.Repository,
+	reconciliations domain.ReconciliationRepository,
+	users UserLookup,
+	databases DatabaseQuerier,
+	tasks TaskReconciler,
+	deps DependencyPuller,
+	metrics ReconciliationMetrics,
+	events shared.EventRecorder,
+	clock shared.Clock,
+	txMgr shared.TransactionManager,
+) *ReconciliationService {
+	return &ReconciliationService{
+		projects:        projects,
+		reconciliations: reconciliations,
+		users:           users,
+		databases:       databases,
+		tasks:           tasks,
+		deps:            deps,
+		metrics:         metrics,
+		events:          events,
+		clock:           clock,
+		txMgr:           txMgr,
+	}
+}
+
+// Reconcile compares a project's tasks with its Notion database. Pages edited since
+// the last successful sweep are stored when they differ from their task, and tasks
+// whose page no longer appears in the database are archived. All repairs are
+// committed together with the sweep time and the task dependencies pulled from the
+// project's dependency relation, so a failed sweep is repeated in full.
+// A successful sweep marks the project synced; a failed one degrades it, or marks
+// its token revoked when Notion rejects it. A property mapping that no longer fits
+// the database schema fails the sweep, so the project stays degraded until the
+// mapping or the schema is fixed. Projects whose initial sync has not
+// completed are skipped.
+func (s *ReconciliationService) Reconcile(ctx context.Context, projectID uuid.UUID) (ReconciliationReport, error) {
+	report := ReconciliationReport{ProjectID: projectID}
+
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return report, err
+	}
+
+	if !project.Sync.InitialSyncCompleted() {
+		report.Skipped = true
+		s.metrics.ObserveSweep(report, nil)
+		return report, nil
+	}
+
+	reconciliation, err := s.reconciliations.FindByProjectID(ctx, projectID)
+	if errors.Is(err, domain.ErrReconciliationNotFound) {
+		created := domain.NewReconciliation(projectID, s.clock)
+		reconciliation, err = &created, nil
+	}
+	if err != nil {
+		return report, err
+	}
+
+	// The first sweep covers the pages edited while the initial sync was running
+	report.Since = *project.Sync.SyncedAt
+	if project.Sync.StartedAt != nil {
+		report.Since = *project.Sync.StartedAt
+	}
+	if reconciliation.LastSweepAt != nil {
+		report.Since = *reconciliation.LastSweepAt
+	}
+
+	startedAt := s.clock.Now()
+	changed, err := s.sweep(ctx, project, &report)
+	if err == nil {
+		err = s.commit(ctx, project, reconciliation, startedAt, changed, report)
+	}
+	if err != nil {
+		// A cancelled context means the worker is stopping, not that the sweep failed
+		if ctx.Err() == nil {
+			if failErr := s.fail(ctx, project, reconciliation, startedAt, err); failErr != nil {
+				err = errors.Join(err, failErr)
+			}
+		}
+		s.metrics.ObserveSweep(report, err)
+		return report, err
+	}
+
+	s.metrics.ObserveSweep(report, nil)
+	return report, nil
+}
+
+// sweep finds the tasks that diverge from the project's Notion database and
+// returns them repaired, counting them in report
+func (s *ReconciliationService) sweep(ctx context.Context, project *domain.Project, report *ReconciliationReport) ([]*tasksDomain.Task, error) {
+	owner, err := s.users.GetByUUID(ctx, project.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project owner: %w", err)
+	}
+	if !owner.HasValidNotionToken(s.clock) {
+		return nil, usersDomain.ErrNotionTokenMissing
+	}
+
+	// A schema change in Notion that breaks the mapping fails the sweep
+	err = resolvePropertyMapping(ctx, s.projects, s.databases, s.clock, owner.NotionAccessToken, project)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.tasks.FindByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[string]*tasksDomain.Task, len(existing))
+	for _, task := range existing {
+		tasks[task.NotionPageID] = task
+	}
+	changed := make(map[string]*tasksDomain.Task)
+
+	// Deleted pages are left out of query results, so every page of the database is
+	// listed to find the tasks whose page is gone. Pages edited since the previous
+	// sweep are compared with their task on the way.
+	since := report.Since.Add(-reconcileEditMargin)
+	listed := make(map[string]bool, len(tasks))
+	pages := s.databases.QueryAll(ctx, owner.NotionAccessToken, project.NotionDatabaseID, &notion.DatabaseQueryRequest{PageSize: queryPageSize})
+	for page, err := range pages {
+		if err != nil {
+			return nil, err
+		}
+		listed[page.ID] = true
+		if page.LastEditedTime.Before(since) {
+			continue
+		}
+		report.Checked++
+
+		snapshot, err := tasksApp.NotionSnapshot(page, project.PropertyMapping)
+		if err != nil {
+			return nil, err
+		}
+
+		task, exists := tasks[page.ID]
+		switch {
+		case !exists:
+			created, err := tasksDomain.NewTask(project.ID, snapshot, s.clock)
+			if err != nil {
+				return nil, err
+			}
+			tasks[page.ID] = &created
+			changed[page.ID] = &created
+			report.Created++
+		case task.Mirrors(snapshot), snapshot.LastEditedAt.Before(task.NotionLastEditedAt):
+			// Up to date, or a webhook already stored a newer version
+		default:
+			task.Apply(snapshot, s.clock)
+			changed[page.ID] = task
+			report.Updated++
+		}
+	}
+	for _, task := range existing {
+		if !task.Archived && !listed[task.NotionPageID] {
+			task.Archive(s.clock)
+			changed[task.NotionPageID] = task
+			report.Archived++
+		}
+	}
+
+	repaired := make([]*tasksDomain.Task, 0, len(changed))
+	for _, task := range changed {
+		repaired = append(repaired, task)
+	}
+	return repaired, nil
+}
+
+// commit stores the repaired tasks and records the successful sweep. Tasks are
+// loaded before the database is listed, so a webhook may have stored a newer
+// version of a page meanwhile, possibly bringing back a task the sweep archives;
+// the newer version is kept (see tasksDomain.ErrStaleTask).
+func (s *ReconciliationService) commit(
+	ctx context.Context,
+	project *domain.Project,
+	reconciliation *domain.Reconciliation,
+	startedAt time.Time,
+	repaired []*tasksDomain.Task,
+	report ReconciliationReport,
+) error {
+	// Work on copies so a failed commit does not advance the sweep time
+	done := *reconciliation
+	synced := *project
+	if err := synced.MarkSynced(startedAt, s.clock); err != nil {
+		return err
+	}
+
+	err := s.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, task := range repaired {
+			err := s.tasks.Upsert(ctx, task)
+			if err != nil && !errors.Is(err, tasksDomain.ErrStaleTask) {
+				return err
+			}
+		}
+		if err := s.deps.Pull(ctx, &synced); err != nil {
+			return err
+		}
+
+		done.Succeed(startedAt, report.Divergences(), s.clock)
+		if err := s.reconciliations.Save(ctx, &done); err != nil {
+			return err
+		}
+		return saveSyncState(ctx, s.projects, s.events, &synced, project.Sync.Status)
+	})
+	if err != nil {
+		return err
+	}
+
+	*reconciliation = done
+	*project = synced
+	return nil
+}
+
+// fail records the failed sweep and degrades the project
+func (s *ReconciliationService) fail(
+	ctx context.Context,
+	project *domain.Project,
+	reconciliation *domain.Reconciliation,
+	startedAt time.Time,
+	cause error,
+) error {
+	previous := project.Sync.Status
+	var err error
+	if notionAccessRevoked(cause) {
+		err = project.RevokeToken(cause, s.clock)
+	} else {
+		err = project.Degrade(cause, s.clock)
+	}
+	if err != nil {
+		return err
+	}
+
+	reconciliation.Fail(startedAt, cause, s.clock)
+	return s.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.reconciliations.Save(ctx, reconciliation); err != nil {
+			return err
+		}
+		return saveSyncState(ctx, s.projects, s.events, project, previous)
+	})
+}
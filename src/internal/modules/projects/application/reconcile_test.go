@@ -0,0 +1,437 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	tasksApp "src/internal/modules/tasks/application"
+	tasksDomain "src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/notion/notiontest"
+)
+
+type mockReconciliationRepository struct {
+	reconciliations map[uuid.UUID]domain.Reconciliation
+}
+
+func (m *mockReconciliationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) (*domain.Reconciliation, error) {
+	reconciliation, exists := m.reconciliations[projectID]
+	if !exists {
+		return nil, domain.ErrReconciliationNotFound
+	}
+	return &reconciliation, nil
+}
+
+func (m *mockReconciliationRepository) Save(ctx context.Context, reconciliation *domain.Reconciliation) error {
+	m.reconciliations[reconciliation.ProjectID] = *reconciliation
+	return nil
+}
+
+type observedSweep struct {
+	report application.ReconciliationReport
+	err    error
+}
+
+type mockReconciliationMetrics struct {
+	sweeps []observedSweep
+}
+
+func (m *mockReconciliationMetrics) ObserveSweep(report application.ReconciliationReport, err error) {
+	m.sweeps = append(m.sweeps, observedSweep{report: report, err: err})
+}
+
+// webhookQuerier runs webhook once the database has been listed, as a webhook
+// handled while a sweep runs would
+type webhookQuerier struct {
+	application.DatabaseQuerier
+	webhook func()
+}
+
+func (q *webhookQuerier) QueryAll(ctx context.Context, accessToken, databaseID string, request *notion.DatabaseQueryRequest) iter.Seq2[notion.Page, error] {
+	return func(yield func(notion.Page, error) bool) {
+		for page, err := range q.DatabaseQuerier.QueryAll(ctx, accessToken, databaseID, request) {
+			if !yield(page, err) {
+				return
+			}
+		}
+		q.webhook()
+	}
+}
+
+var _ = Describe("ReconciliationService", func() {
+	var (
+		ctx             context.Context
+		server          *notiontest.Server
+		databases       *notion.Databases
+		database        notion.Database
+		projects        *mockProjectRepository
+		events          *mockEventRecorder
+		reconciliations *mockReconciliationRepository
+		users           *mockUserLookup
+		tasks           *mockTaskStore
+		deps            *mockDependencyPuller
+		metrics         *mockReconciliationMetrics
+		clock           *mockClock
+		project         domain.Project
+		pages           []notion.Page
+		syncStartedAt   time.Time
+	)
+
+	newService := func(querier application.DatabaseQuerier) *application.ReconciliationService {
+		return application.NewReconciliationService(projects, reconciliations, users, querier, tasks, deps, metrics, events, clock, &mockTransactionManager{})
+	}
+
+	addPage := func(title string) notion.Page {
+		return server.AddPage(notion.Page{
+			Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+			Properties: map[string]notion.PropertyValue{"Name": {Title: []notion.RichText{notion.NewText(title)}}},
+		})
+	}
+
+	// storeTask stores the task of a page as the initial sync or a webhook would
+	storeTask := func(page notion.Page) tasksDomain.Task {
+		fetched, err := notion.NewPages(server.ClientOptions()...).Retrieve(ctx, notiontest.DefaultToken, page.ID)
+		Expect(err).ToNot(HaveOccurred())
+		snapshot, err := tasksApp.NotionSnapshot(*fetched, project.PropertyMapping)
+		Expect(err).ToNot(HaveOccurred())
+		task, err := tasksDomain.NewTask(project.ID, snapshot, clock)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.Upsert(ctx, &task)).To(Succeed())
+		return task
+	}
+
+	queries := func() []string {
+		var bodies []string
+		for _, request := range server.Requests() {
+			if strings.HasSuffix(request.Path, "/query") {
+				bodies = append(bodies, string(request.Body))
+			}
+		}
+		return bodies
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = notiontest.NewServer()
+		DeferCleanup(server.Close)
+		server.PageSize = 2
+		databases = notion.NewDatabases(server.ClientOptions()...)
+
+		database = server.AddDatabase(notion.Database{Properties: map[string]notion.Property{
+			"Name": {Type: "title", Title: &struct{}{}},
+		}})
+		pages = []notion.Page{addPage("Task 1"), addPage("Task 2"), addPage("Task 3")}
+
+		owner := usersDomain.User{ID: uuid.New(), NotionAccessToken: notiontest.DefaultToken}
+		users = &mockUserLookup{users: map[uuid.UUID]usersDomain.User{owner.ID: owner}}
+
+		clock = &mockClock{now: time.Date(2025, 10, 22, 9, 0, 0, 0, time.UTC)}
+		projects = newMockProjectRepository()
+		syncStartedAt = clock.now.Add(-time.Hour)
+		project = domain.Project{ID: uuid.New(), PublicID: "project_1", UserID: owner.ID, NotionDatabaseID: database.ID}
+		project.Sync.Status = domain.SyncStatusPending
+		Expect(project.StartInitialSync(&mockClock{now: syncStartedAt})).To(Succeed())
+		Expect(project.CompleteInitialSync(&mockClock{now: syncStartedAt.Add(time.Minute)})).To(Succeed())
+		Expect(projects.Save(ctx, &project)).To(Succeed())
+
+		events = &mockEventRecorder{}
+
+		reconciliations = &mockReconciliationRepository{reconciliations: make(map[uuid.UUID]domain.Reconciliation)}
+		tasks = &mockTaskStore{tasks: make(map[string]tasksDomain.Task)}
+		deps = &mockDependencyPuller{}
+		metrics = &mockReconciliationMetrics{}
+		for _, page := range pages {
+			storeTask(page)
+		}
+		tasks.upserts = 0
+	})
+
+	It("repairs nothing when every task mirrors its page", func() {
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Checked).To(Equal(3))
+		Expect(report.Divergences()).To(BeZero())
+		Expect(tasks.upserts).To(BeZero())
+
+		reconciliation := reconciliations.reconciliations[project.ID]
+		Expect(*reconciliation.LastSweepAt).To(Equal(clock.now))
+		Expect(reconciliation.LastError).To(BeEmpty())
+		Expect(metrics.sweeps).To(Equal([]observedSweep{{report: report}}))
+
+		synced := projects.projects[project.ID].Sync
+		Expect(synced.Status).To(Equal(domain.SyncStatusSynced))
+		Expect(*synced.SyncedAt).To(Equal(clock.now))
+		// The project was already synced, so its status did not change
+		Expect(events.events).To(BeEmpty())
+	})
+
+	It("stores pages that missed their webhooks", func() {
+		stale := tasks.tasks[pages[0].ID]
+		stale.Title = "Old title"
+		stale.NotionLastEditedAt = stale.NotionLastEditedAt.Add(-time.Hour)
+		tasks.tasks[pages[0].ID] = stale
+		created := addPage("Task 4")
+
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Updated).To(Equal(1))
+		Expect(report.Created).To(Equal(1))
+		Expect(report.Divergences()).To(Equal(2))
+		Expect(tasks.tasks[pages[0].ID].Title).To(Equal("Task 1"))
+		Expect(tasks.tasks[created.ID].Title).To(Equal("Task 4"))
+		Expect(reconciliations.reconciliations[project.ID].Divergences).To(Equal(2))
+	})
+
+	It("keeps tasks that a webhook updated after the page was fetched", func() {
+		newer := tasks.tasks[pages[0].ID]
+		newer.Title = "Newer title"
+		newer.NotionLastEditedAt = newer.NotionLastEditedAt.Add(time.Hour)
+		tasks.tasks[pages[0].ID] = newer
+
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Divergences()).To(BeZero())
+		Expect(tasks.tasks[pages[0].ID].Title).To(Equal("Newer title"))
+	})
+
+	It("keeps tasks that a webhook updated while the sweep ran", func() {
+		stale := tasks.tasks[pages[0].ID]
+		stale.Title = "Old title"
+		stale.NotionLastEditedAt = stale.NotionLastEditedAt.Add(-time.Hour)
+		tasks.tasks[pages[0].ID] = stale
+
+		querier := &webhookQuerier{DatabaseQuerier: databases, webhook: func() {
+			newer := tasks.tasks[pages[0].ID]
+			newer.Title = "Newer title"
+			newer.NotionLastEditedAt = newer.NotionLastEditedAt.Add(2 * time.Hour)
+			tasks.tasks[pages[0].ID] = newer
+		}}
+		_, err := newService(querier).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.tasks[pages[0].ID].Title).To(Equal("Newer title"))
+		Expect(reconciliations.reconciliations[project.ID].LastError).To(BeEmpty())
+	})
+
+	It("keeps tasks that a webhook restored while the sweep ran", func() {
+		deleted := pages[1]
+		deleted.Archived = true
+		server.AddPage(deleted)
+
+		querier := &webhookQuerier{DatabaseQuerier: databases, webhook: func() {
+			restored := tasks.tasks[deleted.ID]
+			restored.NotionLastEditedAt = restored.NotionLastEditedAt.Add(time.Hour)
+			tasks.tasks[deleted.ID] = restored
+		}}
+		_, err := newService(querier).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.tasks[deleted.ID].Archived).To(BeFalse())
+	})
+
+	It("archives tasks whose page was deleted in Notion", func() {
+		deleted := pages[1]
+		deleted.Archived = true
+		server.AddPage(deleted)
+
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Archived).To(Equal(1))
+		Expect(tasks.tasks[deleted.ID].Archived).To(BeTrue())
+
+		// Archived tasks are not counted again
+		report, err = newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Archived).To(BeZero())
+	})
+
+	It("checks the pages edited since the last successful sweep", func() {
+		_, err := newService(databases).Reconcile(ctx, project.ID)
+		Expect(err).ToNot(HaveOccurred())
+		firstSweep := clock.now
+		clock.now = clock.now.Add(time.Hour)
+
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Since).To(Equal(firstSweep))
+	})
+
+	It("lists the database once and compares only pages edited since the last sweep", func() {
+		stale := tasks.tasks[pages[0].ID]
+		stale.Title = "Old title"
+		tasks.tasks[pages[0].ID] = stale
+		deleted := pages[1]
+		deleted.Archived = true
+		server.AddPage(deleted)
+
+		lastSweepAt := time.Now().Add(time.Hour)
+		reconciliation := domain.NewReconciliation(project.ID, clock)
+		reconciliation.LastSweepAt = &lastSweepAt
+		Expect(reconciliations.Save(ctx, &reconciliation)).To(Succeed())
+
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Checked).To(BeZero())
+		Expect(report.Archived).To(Equal(1))
+		Expect(tasks.tasks[pages[0].ID].Title).To(Equal("Old title"))
+		Expect(queries()).To(Equal([]string{`{"page_size":100}`}))
+	})
+
+	It("compares pages edited within a margin before the last sweep", func() {
+		lastSweepAt := pages[2].LastEditedTime.Add(30 * time.Second)
+		reconciliation := domain.NewReconciliation(project.ID, clock)
+		reconciliation.LastSweepAt = &lastSweepAt
+		Expect(reconciliations.Save(ctx, &reconciliation)).To(Succeed())
+
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Checked).To(Equal(3))
+	})
+
+	It("repeats a failed sweep in full", func() {
+		stale := tasks.tasks[pages[0].ID]
+		stale.Title = "Old title"
+		tasks.tasks[pages[0].ID] = stale
+
+		// The second query lists the last pages of the database
+		report, err := newService(&failingQuerier{DatabaseQuerier: databases, failAt: 2}).Reconcile(ctx, project.ID)
+
+		Expect(err).To(MatchError("notion unavailable"))
+		Expect(tasks.upserts).To(BeZero())
+		failed := reconciliations.reconciliations[project.ID]
+		Expect(failed.LastSweepAt).To(BeNil())
+		Expect(failed.LastError).To(Equal("notion unavailable"))
+		Expect(metrics.sweeps).To(Equal([]observedSweep{{report: report, err: err}}))
+
+		report, err = newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Since).To(Equal(syncStartedAt))
+		Expect(report.Updated).To(Equal(1))
+		Expect(reconciliations.reconciliations[project.ID].LastError).To(BeEmpty())
+	})
+
+	It("pulls task dependencies with the repairs", func() {
+		_, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deps.pulled).To(HaveLen(1))
+	})
+
+	It("fails the sweep when task dependencies cannot be pulled", func() {
+		deps.err = errors.New("database unavailable")
+
+		_, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).To(MatchError("database unavailable"))
+		Expect(reconciliations.reconciliations[project.ID].LastSweepAt).To(BeNil())
+		Expect(projects.projects[project.ID].Sync.Status).To(Equal(domain.SyncStatusDegraded))
+	})
+
+	It("degrades the project until a sweep succeeds", func() {
+		_, err := newService(&failingQuerier{DatabaseQuerier: databases, failAt: 1}).Reconcile(ctx, project.ID)
+
+		Expect(err).To(MatchError("notion unavailable"))
+		degraded := projects.projects[project.ID].Sync
+		Expect(degraded.Status).To(Equal(domain.SyncStatusDegraded))
+		Expect(degraded.LastError).To(Equal("notion unavailable"))
+		Expect(degraded.StatusChangedAt).To(Equal(clock.now))
+
+		clock.now = clock.now.Add(time.Hour)
+		_, err = newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		synced := projects.projects[project.ID].Sync
+		Expect(synced.Status).To(Equal(domain.SyncStatusSynced))
+		Expect(synced.LastError).To(BeEmpty())
+		Expect(events.events).To(Equal([]shared.Event{
+			domain.ProjectSyncStatusChanged{
+				ProjectID: project.ID,
+				PublicID:  project.PublicID,
+				UserID:    project.UserID,
+				From:      domain.SyncStatusSynced,
+				To:        domain.SyncStatusDegraded,
+				LastError: "notion unavailable",
+				ChangedAt: clock.now.Add(-time.Hour),
+			},
+			domain.ProjectSyncStatusChanged{
+				ProjectID: project.ID,
+				PublicID:  project.PublicID,
+				UserID:    project.UserID,
+				From:      domain.SyncStatusDegraded,
+				To:        domain.SyncStatusSynced,
+				ChangedAt: clock.now,
+			},
+		}))
+	})
+
+	It("degrades the project while its property mapping does not fit the database", func() {
+		project.PropertyMapping = domain.PropertyMapping{Status: "Status"}
+		Expect(projects.Save(ctx, &project)).To(Succeed())
+
+		_, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).To(MatchError(domain.ErrInvalidPropertyMapping))
+		degraded := projects.projects[project.ID].Sync
+		Expect(degraded.Status).To(Equal(domain.SyncStatusDegraded))
+		Expect(degraded.LastError).To(ContainSubstring(`status: property "Status" not found`))
+		Expect(queries()).To(BeEmpty())
+		Expect(tasks.upserts).To(BeZero())
+
+		// The owner drops the mapped status
+		projects.projects[project.ID].PropertyMapping = domain.PropertyMapping{}
+		clock.now = clock.now.Add(time.Hour)
+		_, err = newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(projects.projects[project.ID].Sync.Status).To(Equal(domain.SyncStatusSynced))
+	})
+
+	It("skips projects whose initial sync has not completed", func() {
+		Expect(project.StartInitialSync(clock)).To(Succeed())
+
+		report, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Skipped).To(BeTrue())
+		Expect(queries()).To(BeEmpty())
+		Expect(reconciliations.reconciliations).To(BeEmpty())
+	})
+
+	It("marks the token revoked when the owner has not connected Notion", func() {
+		owner := users.users[project.UserID]
+		owner.NotionAccessToken = ""
+		users.users[project.UserID] = owner
+
+		_, err := newService(databases).Reconcile(ctx, project.ID)
+
+		Expect(err).To(MatchError(usersDomain.ErrNotionTokenMissing))
+		Expect(reconciliations.reconciliations[project.ID].LastError).ToNot(BeEmpty())
+		Expect(projects.projects[project.ID].Sync.Status).To(Equal(domain.SyncStatusTokenRevoked))
+	})
+
+	It("returns ErrProjectNotFound for unknown projects", func() {
+		_, err := newService(databases).Reconcile(ctx, uuid.New())
+
+		Expect(err).To(MatchError(domain.ErrProjectNotFound))
+	})
+})
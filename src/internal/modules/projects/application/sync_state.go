@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+)
+
+// saveSyncState stores the project's sync state and, when its status changed from
+// previous, records a ProjectSyncStatusChanged event in the same transaction
+func saveSyncState(ctx context.Context, projects domain.Repository, events shared.EventRecorder, project *domain.Project, previous domain.SyncStatus) error {
+	if err := projects.UpdateSync(ctx, project); err != nil {
+		return err
+	}
+
+	if project.Sync.Status == previous {
+		return nil
+	}
+	return events.Record(ctx, domain.NewProjectSyncStatusChanged(*project, previous))
+}
+
+// notionAccessRevoked reports whether err means the owner's Notion token is missing,
+// expired or was revoked, which retrying cannot fix
+func notionAccessRevoked(err error) bool {
+	if errors.Is(err, usersDomain.ErrNotionTokenMissing) {
+		return true
+	}
+
+	var apiErr *notion.APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+// TypeInitialSync imports every page of a project's Notion database
+const TypeInitialSync = "projects:initial_sync"
+
+// initialSyncMaxRetry gives the import room to outlast Notion outages; each retry
+// resumes from the stored cursor
+const initialSyncMaxRetry = 10
+
+// InitialSyncPayload is the payload of a TypeInitialSync task
+type InitialSyncPayload struct {
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// NewInitialSyncTask creates the initial sync task of a project. The task ID is
+// derived from the project, so enqueueing it twice while it is pending is rejected
+// with asynq.ErrTaskIDConflict.
+func NewInitialSyncTask(projectID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(InitialSyncPayload{ProjectID: projectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal initial sync payload: %w", err)
+	}
+
+	return asynq.NewTask(TypeInitialSync, payload,
+		asynq.TaskID(InitialSyncTaskID(projectID)),
+		asynq.MaxRetry(initialSyncMaxRetry),
+	), nil
+}
+
+// InitialSyncTaskID returns the asynq task ID of a project's initial sync
+func InitialSyncTaskID(projectID uuid.UUID) string {
+	return TypeInitialSync + ":" + projectID.String()
+}
+
+// ParseInitialSyncPayload decodes the payload of a TypeInitialSync task
+func ParseInitialSyncPayload(task *asynq.Task) (InitialSyncPayload, error) {
+	var payload InitialSyncPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return InitialSyncPayload{}, fmt.Errorf("invalid initial sync payload: %w", err)
+	}
+	if payload.ProjectID == uuid.Nil {
+		return InitialSyncPayload{}, fmt.Errorf("invalid initial sync payload: missing project_id")
+	}
+	return payload, nil
+}
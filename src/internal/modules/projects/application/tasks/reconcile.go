@@ -0,0 +1,43 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+// TypeReconcile repairs the tasks of a project that missed webhook events
+const TypeReconcile = "projects:reconcile"
+
+// reconcileMaxRetry only covers transient errors; a failed sweep is repeated by
+// the next scheduled one anyway
+const reconcileMaxRetry = 2
+
+// ReconcilePayload is the payload of a TypeReconcile task
+type ReconcilePayload struct {
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// NewReconcileTask creates the reconciliation task of a project
+func NewReconcileTask(projectID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(ReconcilePayload{ProjectID: projectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reconcile payload: %w", err)
+	}
+
+	return asynq.NewTask(TypeReconcile, payload, asynq.MaxRetry(reconcileMaxRetry)), nil
+}
+
+// ParseReconcilePayload decodes the payload of a TypeReconcile task
+func ParseReconcilePayload(task *asynq.Task) (ReconcilePayload, error) {
+	var payload ReconcilePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return ReconcilePayload{}, fmt.Errorf("invalid reconcile payload: %w", err)
+	}
+	if payload.ProjectID == uuid.Nil {
+		return ReconcilePayload{}, fmt.Errorf("invalid reconcile payload: missing project_id")
+	}
+	return payload, nil
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+
+	"github.com/google/uuid"
+)
+
+// UpdateProjectRequest contains the project settings to change. Nil fields are left unchanged.
+type UpdateProjectRequest struct {
+	UserID            uuid.UUID
+	PublicID          string
+	ReconcileInterval *time.Duration // Zero resets to the worker default
+}
+
+// UpdateProjectUseCase handles changes to a project's settings
+type UpdateProjectUseCase struct {
+	repo  domain.Repository
+	clock shared.Clock
+	txMgr shared.TransactionManager
+}
+
+// NewUpdateProjectUseCase creates a new UpdateProjectUseCase
+func NewUpdateProjectUseCase(
+	repo domain.Repository,
+	clock shared.Clock,
+	txMgr shared.TransactionManager,
+) *UpdateProjectUseCase {
+	return &UpdateProjectUseCase{
+		repo:  repo,
+		clock: clock,
+		txMgr: txMgr,
+	}
+}
+
+// Execute updates a project of the user. Projects of other users are reported as
+// domain.ErrProjectNotFound.
+func (uc *UpdateProjectUseCase) Execute(ctx context.Context, req UpdateProjectRequest) (domain.Project, error) {
+	var updated domain.Project
+
+	err := uc.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		project, err := uc.repo.FindByPublicID(ctx, req.PublicID)
+		if err != nil {
+			return err
+		}
+		if project.UserID != req.UserID {
+			return domain.ErrProjectNotFound
+		}
+
+		if req.ReconcileInterval != nil {
+			if err := project.SetReconcileInterval(*req.ReconcileInterval, uc.clock); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.repo.Update(ctx, project); err != nil {
+			return err
+		}
+
+		updated = *project
+		return nil
+	})
+
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,83 @@
+package application_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/domain"
+)
+
+var _ = Describe("UpdateProjectUseCase", func() {
+	var (
+		repo    *mockProjectRepository
+		clock   *mockClock
+		uc      *application.UpdateProjectUseCase
+		ctx     context.Context
+		project domain.Project
+	)
+
+	BeforeEach(func() {
+		repo = newMockProjectRepository()
+		clock = &mockClock{now: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)}
+		uc = application.NewUpdateProjectUseCase(repo, clock, &mockTransactionManager{})
+		ctx = context.Background()
+
+		project = domain.Project{ID: uuid.New(), PublicID: "project_1", UserID: uuid.New(), NotionDatabaseID: "database_123"}
+		Expect(repo.Save(ctx, &project)).To(Succeed())
+	})
+
+	interval := func(d time.Duration) *time.Duration {
+		return &d
+	}
+
+	It("should change the reconcile interval", func() {
+		updated, err := uc.Execute(ctx, application.UpdateProjectRequest{
+			UserID:            project.UserID,
+			PublicID:          project.PublicID,
+			ReconcileInterval: interval(30 * time.Minute),
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.ReconcileInterval).To(Equal(30 * time.Minute))
+		Expect(updated.UpdatedAt).To(Equal(clock.now))
+		Expect(repo.projects[project.ID].ReconcileInterval).To(Equal(30 * time.Minute))
+	})
+
+	It("should leave omitted settings unchanged", func() {
+		repo.projects[project.ID].ReconcileInterval = 30 * time.Minute
+
+		updated, err := uc.Execute(ctx, application.UpdateProjectRequest{
+			UserID:   project.UserID,
+			PublicID: project.PublicID,
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.ReconcileInterval).To(Equal(30 * time.Minute))
+	})
+
+	It("should reject reconcile intervals shorter than the minimum", func() {
+		_, err := uc.Execute(ctx, application.UpdateProjectRequest{
+			UserID:            project.UserID,
+			PublicID:          project.PublicID,
+			ReconcileInterval: interval(time.Minute),
+		})
+
+		Expect(err).To(MatchError(domain.ErrInvalidReconcileInterval))
+	})
+
+	It("should not update projects of other users", func() {
+		_, err := uc.Execute(ctx, application.UpdateProjectRequest{
+			UserID:            uuid.New(),
+			PublicID:          project.PublicID,
+			ReconcileInterval: interval(30 * time.Minute),
+		})
+
+		Expect(err).To(MatchError(domain.ErrProjectNotFound))
+		Expect(repo.projects[project.ID].ReconcileInterval).To(BeZero())
+	})
+})
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+)
+
+// UpdatePropertyMappingRequest contains the new property mapping of a project
+type UpdatePropertyMappingRequest struct {
+	UserID   uuid.UUID
+	PublicID string
+	Mapping  domain.PropertyMapping
+}
+
+// UpdatePropertyMappingUseCase handles changes to a project's property mapping
+type UpdatePropertyMappingUseCase struct {
+	repo      domain.Repository
+	users     UserLookup
+	databases DatabaseRetriever
+	clock     shared.Clock
+	txMgr     shared.TransactionManager
+}
+
+// NewUpdatePropertyMappingUseCase creates a new UpdatePropertyMappingUseCase
+func NewUpdatePropertyMappingUseCase(
+	repo domain.Repository,
+	users UserLookup,
+	databases DatabaseRetriever,
+	clock shared.Clock,
+	txMgr shared.TransactionManager,
+) *UpdatePropertyMappingUseCase {
+	return &UpdatePropertyMappingUseCase{
+		repo:      repo,
+		users:     users,
+		databases: databases,
+		clock:     clock,
+		txMgr:     txMgr,
+	}
+}
+
+// Execute replaces the property mapping of a project of the user after checking it
+// against the live schema of its Notion database; a mapping that does not fit is
+// reported as a *domain.PropertyMappingError. Tasks pick up the new mapping as
+// their pages are synchronized. Projects of other users are reported as
+// domain.ErrProjectNotFound.
+func (uc *UpdatePropertyMappingUseCase) Execute(ctx context.Context, req UpdatePropertyMappingRequest) (domain.Project, error) {
+	project, err := uc.repo.FindByPublicID(ctx, req.PublicID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if project.UserID != req.UserID {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+
+	// Notion is asked outside the transaction
+	schema, err := liveSchema(ctx, uc.users, uc.databases, uc.clock, project)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	var updated domain.Project
+	err = uc.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := project.SetPropertyMapping(req.Mapping, schema, uc.clock); err != nil {
+			return err
+		}
+		if err := uc.repo.Update(ctx, project); err != nil {
+			return err
+		}
+
+		updated = *project
+		return nil
+	})
+
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProjectCreatedTopic           = "projects.project_created"
+	ProjectSyncStatusChangedTopic = "projects.sync_status_changed"
+)
+
+// ProjectCreated is emitted when a new project is stored
+type ProjectCreated struct {
+	ProjectID        uuid.UUID `json:"project_id"`
+	PublicID         string    `json:"public_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	NotionDatabaseID string    `json:"notion_database_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// EventTopic implements shared.Event
+func (ProjectCreated) EventTopic() string {
+	return ProjectCreatedTopic
+}
+
+// NewProjectCreated builds the ProjectCreated event for the given project
+func NewProjectCreated(project Project) ProjectCreated {
+	return ProjectCreated{
+		ProjectID:        project.ID,
+		PublicID:         project.PublicID,
+		UserID:           project.UserID,
+		NotionDatabaseID: project.NotionDatabaseID,
+		CreatedAt:        project.CreatedAt,
+	}
+}
+
+// ProjectSyncStatusChanged is emitted on every sync status transition, so clients
+// can show whether a project's tasks are being imported, up to date or stale
+type ProjectSyncStatusChanged struct {
+	ProjectID   uuid.UUID  `json:"project_id"`
+	PublicID    string     `json:"public_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	From        SyncStatus `json:"from"`
+	To          SyncStatus `json:"to"`
+	LastError   string     `json:"last_error,omitempty"`
+	PagesSynced int        `json:"pages_synced"`
+	ChangedAt   time.Time  `json:"changed_at"`
+}
+
+// EventTopic implements shared.Event
+func (ProjectSyncStatusChanged) EventTopic() string {
+	return ProjectSyncStatusChangedTopic
+}
+
+// NewProjectSyncStatusChanged builds the event for a project whose sync status changed from the given status
+func NewProjectSyncStatusChanged(project Project, from SyncStatus) ProjectSyncStatusChanged {
+	return ProjectSyncStatusChanged{
+		ProjectID:   project.ID,
+		PublicID:    project.PublicID,
+		UserID:      project.UserID,
+		From:        from,
+		To:          project.Sync.Status,
+		LastError:   project.Sync.LastError,
+		PagesSynced: project.Sync.PagesSynced,
+		ChangedAt:   project.Sync.StatusChangedAt,
+	}
+}
@@ -8,9 +8,14 @@ import (
 )
 
 var (
-	ErrProjectNotFound = errors.New("project not found")
+	ErrProjectNotFound          = errors.New("project not found")
+	ErrInvalidReconcileInterval = errors.New("reconcile interval must be zero or at least 5 minutes")
 )
 
+// MinReconcileInterval is the shortest reconciliation interval a project may use,
+// so that sweeps of large databases stay within Notion's rate limits
+const MinReconcileInterval = 5 * time.Minute
+
 // Project represents a Notion database that is being synchronized
 type Project struct {
 	ID                  uuid.UUID // Internal UUID for DB relations and ordering
@@ -18,6 +23,9 @@ type Project struct {
 	UserID              uuid.UUID
 	NotionDatabaseID    string
 	NotionWebhookSecret string
+	ReconcileInterval   time.Duration // Time between reconciliation sweeps; zero uses the worker default
+	PropertyMapping     PropertyMapping
+	Sync                SyncState
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
@@ -42,11 +50,33 @@ func NewProject(userID uuid.UUID, notionDatabaseID, notionWebhookSecret string,
 		UserID:              userID,
 		NotionDatabaseID:    notionDatabaseID,
 		NotionWebhookSecret: notionWebhookSecret,
+		Sync:                SyncState{Status: SyncStatusPending, StatusChangedAt: now},
 		CreatedAt:           now,
 		UpdatedAt:           now,
 	}, nil
 }
 
+// SetReconcileInterval changes how often the project is reconciled with Notion.
+// Zero resets it to the worker default.
+func (p *Project) SetReconcileInterval(interval time.Duration, clock Clock) error {
+	if interval != 0 && interval < MinReconcileInterval {
+		return ErrInvalidReconcileInterval
+	}
+
+	p.ReconcileInterval = interval
+	p.UpdatedAt = clock.Now()
+	return nil
+}
+
+// ReconcileEvery returns the project's reconciliation interval, or defaultInterval
+// when the project does not set one
+func (p Project) ReconcileEvery(defaultInterval time.Duration) time.Duration {
+	if p.ReconcileInterval == 0 {
+		return defaultInterval
+	}
+	return p.ReconcileInterval
+}
+
 // Clock interface for dependency injection
 type Clock interface {
 	Now() time.Time
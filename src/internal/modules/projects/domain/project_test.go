@@ -55,6 +55,7 @@ var _ = Describe("Project", func() {
 			Expect(project.UserID).To(Equal(userID))
 			Expect(project.NotionDatabaseID).To(Equal(notionDatabaseID))
 			Expect(project.NotionWebhookSecret).To(Equal(notionWebhookSecret))
+			Expect(project.Sync.Status).To(Equal(domain.SyncStatusPending))
 			Expect(project.CreatedAt).To(Equal(clock.Now()))
 			Expect(project.UpdatedAt).To(Equal(clock.Now()))
 		})
@@ -80,6 +81,39 @@ var _ = Describe("Project", func() {
 			Expect(err.Error()).To(ContainSubstring("notion webhook secret cannot be empty"))
 		})
 	})
+
+	Describe("SetReconcileInterval", func() {
+		var (
+			project domain.Project
+			clock   *mockClock
+		)
+
+		BeforeEach(func() {
+			clock = &mockClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+			project = domain.Project{ID: uuid.New()}
+		})
+
+		It("should override the default interval", func() {
+			Expect(project.SetReconcileInterval(30*time.Minute, clock)).To(Succeed())
+
+			Expect(project.ReconcileEvery(time.Hour)).To(Equal(30 * time.Minute))
+			Expect(project.UpdatedAt).To(Equal(clock.Now()))
+		})
+
+		It("should fall back to the default interval when reset to zero", func() {
+			Expect(project.SetReconcileInterval(30*time.Minute, clock)).To(Succeed())
+			Expect(project.SetReconcileInterval(0, clock)).To(Succeed())
+
+			Expect(project.ReconcileEvery(time.Hour)).To(Equal(time.Hour))
+		})
+
+		It("should reject intervals shorter than the minimum", func() {
+			err := project.SetReconcileInterval(time.Minute, clock)
+
+			Expect(err).To(MatchError(domain.ErrInvalidReconcileInterval))
+			Expect(project.ReconcileInterval).To(BeZero())
+		})
+	})
 })
 
 func TestProject(t *testing.T) {
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidPropertyMapping = errors.New("invalid property mapping")
+	ErrUnmappedField          = errors.New("task field is not mapped to a Notion property")
+)
+
+// PropertyMapping names the Notion properties that feed a project's task fields.
+// Empty names leave the field unmapped. Start and End may name the same date
+// property, in which case its range is the task's schedule.
+type PropertyMapping struct {
+	Start      string // date
+	End        string // date
+	Status     string // status or select
+	Priority   string // select
+	Assignee   string // people
+	Parent     string // relation to the project's database
+	Dependency string // relation to the project's database, listing the task's predecessors
+}
+
+// IsZero reports whether no field is mapped
+func (m PropertyMapping) IsZero() bool {
+	return m == PropertyMapping{}
+}
+
+// SchemaProperty describes a property of a Notion database
+type SchemaProperty struct {
+	Type              string
+	RelatedDatabaseID string // Database a relation points to
+}
+
+// Schema lists the properties of a Notion database by name
+type Schema map[string]SchemaProperty
+
+// PropertyMappingError lists why a mapping does not fit a database schema, by field
+type PropertyMappingError struct {
+	Problems map[string]string
+}
+
+func (e *PropertyMappingError) Error() string {
+	fields := make([]string, 0, len(e.Problems))
+	for field := range e.Problems {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	problems := make([]string, len(fields))
+	for i, field := range fields {
+		problems[i] = field + ": " + e.Problems[field]
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidPropertyMapping, strings.Join(problems, "; "))
+}
+
+func (e *PropertyMappingError) Unwrap() error {
+	return ErrInvalidPropertyMapping
+}
+
+// mappedField describes a field of PropertyMapping
+type mappedField struct {
+	name         string
+	types        []string
+	selfRelation bool     // The relation must point to the project's own database
+	hints        []string // Lower-case property names suggested for the field, best first
+	nameOnly     bool     // Only suggest properties named like a hint
+	property     func(*PropertyMapping) *string
+}
+
+var mappedFields = []mappedField{
+	{
+		name:     "start",
+		types:    []string{"date"},
+		hints:    []string{"timeline", "dates", "schedule", "start", "start date", "begin"},
+		property: func(m *PropertyMapping) *string { return &m.Start },
+	},
+	{
+		name:     "end",
+		types:    []string{"date"},
+		hints:    []string{"timeline", "dates", "schedule", "due", "due date", "deadline", "end", "end date"},
+		property: func(m *PropertyMapping) *string { return &m.End },
+	},
+	{
+		name:     "status",
+		types:    []string{"status", "select"},
+		hints:    []string{"status", "state", "stage"},
+		property: func(m *PropertyMapping) *string { return &m.Status },
+	},
+	{
+		name:     "priority",
+		types:    []string{"select"},
+		hints:    []string{"priority"},
+		nameOnly: true,
+		property: func(m *PropertyMapping) *string { return &m.Priority },
+	},
+	{
+		name:     "assignee",
+		types:    []string{"people"},
+		hints:    []string{"assignee", "assignees", "assigned to", "owner", "owners"},
+		property: func(m *PropertyMapping) *string { return &m.Assignee },
+	},
+	{
+		name:         "parent",
+		types:        []string{"relation"},
+		selfRelation: true,
+		hints:        []string{"parent item", "parent", "parent task"},
+		nameOnly:     true,
+		property:     func(m *PropertyMapping) *string { return &m.Parent },
+	},
+	{
+		name:         "dependency",
+		types:        []string{"relation"},
+		selfRelation: true,
+		hints:        []string{"blocked by", "depends on", "dependencies", "predecessors"},
+		nameOnly:     true,
+		property:     func(m *PropertyMapping) *string { return &m.Dependency },
+	},
+}
+
+// Validate checks that every mapped property exists in the schema of the given
+// database with a type its field accepts. It returns a *PropertyMappingError.
+func (m PropertyMapping) Validate(schema Schema, databaseID string) error {
+	problems := make(map[string]string)
+	for _, field := range mappedFields {
+		name := *field.property(&m)
+		if name == "" {
+			continue
+		}
+
+		property, exists := schema[name]
+		switch {
+		case !exists:
+			problems[field.name] = fmt.Sprintf("property %q not found", name)
+		case !slices.Contains(field.types, property.Type):
+			problems[field.name] = fmt.Sprintf("property %q is of type %s, expected %s", name, property.Type, strings.Join(field.types, " or "))
+		case field.selfRelation && !sameDatabaseID(property.RelatedDatabaseID, databaseID):
+			problems[field.name] = fmt.Sprintf("property %q must relate to the project's database", name)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &PropertyMappingError{Problems: problems}
+	}
+	return nil
+}
+
+// SuggestPropertyMapping maps each field to a property of the schema with a
+// conventional name, or to the schema's only property of a suitable type
+func SuggestPropertyMapping(schema Schema, databaseID string) PropertyMapping {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var mapping PropertyMapping
+	for _, field := range mappedFields {
+		var candidates []string
+		for _, name := range names {
+			property := schema[name]
+			if slices.Contains(field.types, property.Type) &&
+				(!field.selfRelation || sameDatabaseID(property.RelatedDatabaseID, databaseID)) {
+				candidates = append(candidates, name)
+			}
+		}
+
+		*field.property(&mapping) = suggestProperty(candidates, field)
+	}
+
+	// A lone date property is the whole schedule
+	if mapping.Start == "" && mapping.End != "" {
+		mapping.Start = mapping.End
+	}
+	if mapping.End == "" && mapping.Start != "" {
+		mapping.End = mapping.Start
+	}
+	return mapping
+}
+
+// suggestProperty picks the candidate named like the field's best hint, or the only
+// candidate when no name matches. A lone select or self relation could mean
+// anything, so those fields are only suggested by name.
+func suggestProperty(candidates []string, field mappedField) string {
+	for _, hint := range field.hints {
+		for _, candidate := range candidates {
+			if strings.EqualFold(strings.TrimSpace(candidate), hint) {
+				return candidate
+			}
+		}
+	}
+
+	if len(candidates) == 1 && !field.nameOnly {
+		return candidates[0]
+	}
+	return ""
+}
+
+// SetPropertyMapping replaces the project's property mapping after checking it
+// against the schema of the project's database
+func (p *Project) SetPropertyMapping(mapping PropertyMapping, schema Schema, clock Clock) error {
+	if err := mapping.Validate(schema, p.NotionDatabaseID); err != nil {
+		return err
+	}
+
+	p.PropertyMapping = mapping
+	p.UpdatedAt = clock.Now()
+	return nil
+}
+
+// sameDatabaseID reports whether two Notion database IDs are equal, ignoring dashes
+func sameDatabaseID(a, b string) bool {
+	return strings.EqualFold(strings.ReplaceAll(a, "-", ""), strings.ReplaceAll(b, "-", ""))
+}
@@ -0,0 +1,134 @@
+package domain_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/domain"
+)
+
+var _ = Describe("PropertyMapping", func() {
+	const databaseID = "2f1a3b4c-5d6e-4f70-8192-a3b4c5d6e7f8"
+
+	var schema domain.Schema
+
+	BeforeEach(func() {
+		schema = domain.Schema{
+			"Name":        {Type: "title"},
+			"Stage":       {Type: "status"},
+			"Priority":    {Type: "select"},
+			"Category":    {Type: "select"},
+			"Start date":  {Type: "date"},
+			"Deadline":    {Type: "date"},
+			"Owner":       {Type: "people"},
+			"Parent item": {Type: "relation", RelatedDatabaseID: "2f1a3b4c5d6e4f708192a3b4c5d6e7f8"},
+			"Blocked by":  {Type: "relation", RelatedDatabaseID: databaseID},
+			"Client":      {Type: "relation", RelatedDatabaseID: "other_database"},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("should accept properties of the expected types", func() {
+			mapping := domain.PropertyMapping{
+				Start:      "Start date",
+				End:        "Deadline",
+				Status:     "Category",
+				Priority:   "Priority",
+				Assignee:   "Owner",
+				Parent:     "Parent item",
+				Dependency: "Blocked by",
+			}
+
+			Expect(mapping.Validate(schema, databaseID)).To(Succeed())
+		})
+
+		It("should list every problem by field", func() {
+			mapping := domain.PropertyMapping{Start: "Start", Status: "Owner", Dependency: "Client"}
+
+			err := mapping.Validate(schema, databaseID)
+
+			Expect(err).To(MatchError(domain.ErrInvalidPropertyMapping))
+			Expect(err).To(MatchError(`invalid property mapping: dependency: property "Client" must relate to the project's database; ` +
+				`start: property "Start" not found; status: property "Owner" is of type people, expected status or select`))
+		})
+	})
+
+	Describe("SuggestPropertyMapping", func() {
+		It("should prefer conventional names", func() {
+			Expect(domain.SuggestPropertyMapping(schema, databaseID)).To(Equal(domain.PropertyMapping{
+				Start:      "Start date",
+				End:        "Deadline",
+				Status:     "Stage",
+				Priority:   "Priority",
+				Assignee:   "Owner",
+				Parent:     "Parent item",
+				Dependency: "Blocked by",
+			}))
+		})
+
+		It("should use a lone date property as the whole schedule", func() {
+			delete(schema, "Start date")
+
+			mapping := domain.SuggestPropertyMapping(schema, databaseID)
+
+			Expect(mapping.Start).To(Equal("Deadline"))
+			Expect(mapping.End).To(Equal("Deadline"))
+		})
+
+		It("should not guess selects or relations by type alone", func() {
+			schema = domain.Schema{
+				"Name":     {Type: "title"},
+				"Category": {Type: "select"},
+				"Related":  {Type: "relation", RelatedDatabaseID: databaseID},
+			}
+
+			mapping := domain.SuggestPropertyMapping(schema, databaseID)
+
+			Expect(mapping.Priority).To(BeEmpty())
+			Expect(mapping.Parent).To(BeEmpty())
+			Expect(mapping.Dependency).To(BeEmpty())
+			Expect(mapping.Status).To(Equal("Category"))
+		})
+
+		It("should leave fields unmapped when several properties could feed them", func() {
+			schema["Lead"] = schema["Owner"]
+			schema["Reviewer"] = schema["Owner"]
+			delete(schema, "Owner")
+
+			Expect(domain.SuggestPropertyMapping(schema, databaseID).Assignee).To(BeEmpty())
+		})
+	})
+
+	Describe("SetPropertyMapping", func() {
+		var (
+			project domain.Project
+			clock   *mockClock
+		)
+
+		BeforeEach(func() {
+			clock = &mockClock{now: time.Date(2025, 10, 25, 9, 0, 0, 0, time.UTC)}
+			project = domain.Project{NotionDatabaseID: databaseID}
+		})
+
+		It("should replace the mapping", func() {
+			mapping := domain.PropertyMapping{Status: "Stage"}
+
+			Expect(project.SetPropertyMapping(mapping, schema, clock)).To(Succeed())
+
+			Expect(project.PropertyMapping).To(Equal(mapping))
+			Expect(project.UpdatedAt).To(Equal(clock.now))
+		})
+
+		It("should keep the mapping when the new one does not fit", func() {
+			project.PropertyMapping = domain.PropertyMapping{Status: "Stage"}
+
+			err := project.SetPropertyMapping(domain.PropertyMapping{Status: "Missing"}, schema, clock)
+
+			Expect(err).To(MatchError(domain.ErrInvalidPropertyMapping))
+			Expect(project.PropertyMapping).To(Equal(domain.PropertyMapping{Status: "Stage"}))
+			Expect(project.UpdatedAt).To(BeZero())
+		})
+	})
+})
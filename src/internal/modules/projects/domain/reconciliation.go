@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReconciliationNotFound = errors.New("project reconciliation not found")
+)
+
+// Reconciliation tracks the periodic sweeps that repair tasks missed by webhooks.
+// LastSweepAt is when the last successful sweep started: pages edited after it
+// are checked by the next sweep.
+type Reconciliation struct {
+	ProjectID     uuid.UUID
+	LastSweepAt   *time.Time
+	LastAttemptAt *time.Time
+	LastError     string
+	Divergences   int // Tasks repaired by the last successful sweep
+	UpdatedAt     time.Time
+}
+
+// NewReconciliation creates the reconciliation state of a project that was never swept
+func NewReconciliation(projectID uuid.UUID, clock Clock) Reconciliation {
+	return Reconciliation{
+		ProjectID: projectID,
+		UpdatedAt: clock.Now(),
+	}
+}
+
+// Succeed records a sweep that started at startedAt and repaired divergences tasks
+func (r *Reconciliation) Succeed(startedAt time.Time, divergences int, clock Clock) {
+	r.LastSweepAt = &startedAt
+	r.LastAttemptAt = &startedAt
+	r.LastError = ""
+	r.Divergences = divergences
+	r.UpdatedAt = clock.Now()
+}
+
+// Fail records a sweep that started at startedAt and did not finish. The next
+// sweep checks pages from the last successful one again.
+func (r *Reconciliation) Fail(startedAt time.Time, err error, clock Clock) {
+	r.LastAttemptAt = &startedAt
+	r.LastError = err.Error()
+	r.UpdatedAt = clock.Now()
+}
@@ -14,15 +14,33 @@ type Repository interface {
 	// FindByID retrieves a project by its ID
 	FindByID(ctx context.Context, id uuid.UUID) (*Project, error)
 
+	// FindByPublicID retrieves a project by its public ID
+	FindByPublicID(ctx context.Context, publicID string) (*Project, error)
+
 	// FindByUserID retrieves all projects for a user
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*Project, error)
 
+	// FindAll retrieves every project
+	FindAll(ctx context.Context) ([]*Project, error)
+
 	// FindByNotionDatabaseID retrieves a project by Notion database ID
 	FindByNotionDatabaseID(ctx context.Context, notionDatabaseID string) (*Project, error)
 
-	// Update updates an existing project
+	// Update updates an existing project, except for its sync state
 	Update(ctx context.Context, project *Project) error
 
+	// UpdateSync stores the sync state of a project, leaving its other fields untouched
+	UpdateSync(ctx context.Context, project *Project) error
+
 	// Delete removes a project
 	Delete(ctx context.Context, id uuid.UUID) error
 }
+
+// ReconciliationRepository defines the interface for reconciliation sweep state
+type ReconciliationRepository interface {
+	// FindByProjectID retrieves the reconciliation state of a project
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) (*Reconciliation, error)
+
+	// Save creates or replaces the reconciliation state of a project
+	Save(ctx context.Context, reconciliation *Reconciliation) error
+}
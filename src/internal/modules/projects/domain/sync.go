@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrInvalidSyncTransition = errors.New("invalid sync status transition")
+)
+
+// SyncStatus is the state of a project's synchronization with its Notion database
+type SyncStatus string
+
+const (
+	// SyncStatusPending projects wait for their initial sync to start
+	SyncStatusPending SyncStatus = "pending"
+	// SyncStatusInitialSyncing projects are importing their Notion database
+	SyncStatusInitialSyncing SyncStatus = "initial_syncing"
+	// SyncStatusSynced projects are kept up to date by webhooks and reconciliation
+	SyncStatusSynced SyncStatus = "synced"
+	// SyncStatusDegraded projects were synced but their last reconciliation failed,
+	// so their tasks may lag behind Notion
+	SyncStatusDegraded SyncStatus = "degraded"
+	// SyncStatusFailed projects stopped their initial sync on an error; retrying
+	// resumes from the stored cursor
+	SyncStatusFailed SyncStatus = "failed"
+	// SyncStatusTokenRevoked projects cannot reach Notion until their owner
+	// reconnects their Notion account
+	SyncStatusTokenRevoked SyncStatus = "token_revoked"
+)
+
+// syncTransitions lists the statuses each status may change to
+var syncTransitions = map[SyncStatus][]SyncStatus{
+	SyncStatusPending:        {SyncStatusInitialSyncing},
+	SyncStatusInitialSyncing: {SyncStatusSynced, SyncStatusFailed, SyncStatusTokenRevoked},
+	SyncStatusSynced:         {SyncStatusInitialSyncing, SyncStatusDegraded, SyncStatusTokenRevoked},
+	SyncStatusDegraded:       {SyncStatusInitialSyncing, SyncStatusSynced, SyncStatusTokenRevoked},
+	SyncStatusFailed:         {SyncStatusInitialSyncing, SyncStatusTokenRevoked},
+	SyncStatusTokenRevoked:   {SyncStatusInitialSyncing, SyncStatusSynced, SyncStatusDegraded},
+}
+
+// CanTransitionTo reports whether a project may change from status s to next.
+// Staying in the same status is always allowed.
+func (s SyncStatus) CanTransitionTo(next SyncStatus) bool {
+	return s == next || slices.Contains(syncTransitions[s], next)
+}
+
+// SyncState is the synchronization state of a project. Cursor is the Notion start
+// cursor of the next initial sync batch, so an interrupted import resumes where
+// it stopped instead of starting over.
+type SyncState struct {
+	Status          SyncStatus
+	StatusChangedAt time.Time
+	LastError       string
+	Cursor          string
+	PagesSynced     int        // Pages imported by the initial sync
+	StartedAt       *time.Time // Start of the initial sync
+	SyncedAt        *time.Time // Last time the tasks were known to match Notion
+}
+
+// InitialSyncCompleted reports whether the project's Notion database has been imported
+func (s SyncState) InitialSyncCompleted() bool {
+	return s.SyncedAt != nil
+}
+
+// StartInitialSync marks the initial sync as running. A sync that failed or was
+// interrupted keeps its cursor; starting over a completed sync imports every page again.
+func (p *Project) StartInitialSync(clock Clock) error {
+	if err := p.transition(SyncStatusInitialSyncing, clock); err != nil {
+		return err
+	}
+
+	now := clock.Now()
+	if p.Sync.InitialSyncCompleted() {
+		p.Sync.Cursor = ""
+		p.Sync.PagesSynced = 0
+		p.Sync.StartedAt = nil
+		p.Sync.SyncedAt = nil
+	}
+	if p.Sync.StartedAt == nil {
+		p.Sync.StartedAt = &now
+	}
+	p.Sync.LastError = ""
+	return nil
+}
+
+// AdvanceInitialSync records a stored batch of pages and the cursor of the next one
+func (p *Project) AdvanceInitialSync(nextCursor string, pages int) {
+	p.Sync.Cursor = nextCursor
+	p.Sync.PagesSynced += pages
+}
+
+// CompleteInitialSync marks the Notion database as imported
+func (p *Project) CompleteInitialSync(clock Clock) error {
+	if err := p.transition(SyncStatusSynced, clock); err != nil {
+		return err
+	}
+
+	now := clock.Now()
+	p.Sync.Cursor = ""
+	p.Sync.SyncedAt = &now
+	return nil
+}
+
+// FailInitialSync records why the initial sync stopped; the cursor is kept for the next attempt
+func (p *Project) FailInitialSync(cause error, clock Clock) error {
+	if err := p.transition(SyncStatusFailed, clock); err != nil {
+		return err
+	}
+
+	p.Sync.LastError = cause.Error()
+	return nil
+}
+
+// MarkSynced records that the tasks matched Notion at the given time, e.g. after a
+// successful reconciliation sweep
+func (p *Project) MarkSynced(at time.Time, clock Clock) error {
+	if err := p.transition(SyncStatusSynced, clock); err != nil {
+		return err
+	}
+
+	p.Sync.SyncedAt = &at
+	p.Sync.LastError = ""
+	return nil
+}
+
+// Degrade records why the tasks of a synced project may lag behind Notion
+func (p *Project) Degrade(cause error, clock Clock) error {
+	if err := p.transition(SyncStatusDegraded, clock); err != nil {
+		return err
+	}
+
+	p.Sync.LastError = cause.Error()
+	return nil
+}
+
+// RevokeToken records that Notion rejected the owner's access token
+func (p *Project) RevokeToken(cause error, clock Clock) error {
+	if err := p.transition(SyncStatusTokenRevoked, clock); err != nil {
+		return err
+	}
+
+	p.Sync.LastError = cause.Error()
+	return nil
+}
+
+// transition changes the sync status, enforcing the allowed transitions
+func (p *Project) transition(next SyncStatus, clock Clock) error {
+	if !p.Sync.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidSyncTransition, p.Sync.Status, next)
+	}
+
+	if p.Sync.Status != next {
+		p.Sync.Status = next
+		p.Sync.StatusChangedAt = clock.Now()
+	}
+	return nil
+}
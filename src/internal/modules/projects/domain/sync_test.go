@@ -0,0 +1,118 @@
+package domain_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/domain"
+)
+
+var _ = Describe("SyncState", func() {
+	var (
+		project domain.Project
+		clock   *mockClock
+	)
+
+	BeforeEach(func() {
+		clock = &mockClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+		project = domain.Project{ID: uuid.New(), Sync: domain.SyncState{Status: domain.SyncStatusPending}}
+	})
+
+	Describe("initial sync", func() {
+		It("should record progress until the import completes", func() {
+			Expect(project.StartInitialSync(clock)).To(Succeed())
+			Expect(project.Sync.Status).To(Equal(domain.SyncStatusInitialSyncing))
+			Expect(*project.Sync.StartedAt).To(Equal(clock.Now()))
+
+			project.AdvanceInitialSync("cursor_2", 100)
+			clock.now = clock.now.Add(time.Minute)
+			Expect(project.CompleteInitialSync(clock)).To(Succeed())
+
+			Expect(project.Sync.Status).To(Equal(domain.SyncStatusSynced))
+			Expect(project.Sync.StatusChangedAt).To(Equal(clock.Now()))
+			Expect(project.Sync.PagesSynced).To(Equal(100))
+			Expect(project.Sync.Cursor).To(BeEmpty())
+			Expect(project.Sync.InitialSyncCompleted()).To(BeTrue())
+		})
+
+		It("should keep the cursor of a failed sync for the next attempt", func() {
+			Expect(project.StartInitialSync(clock)).To(Succeed())
+			project.AdvanceInitialSync("cursor_2", 100)
+			Expect(project.FailInitialSync(errors.New("notion unavailable"), clock)).To(Succeed())
+
+			Expect(project.Sync.Status).To(Equal(domain.SyncStatusFailed))
+			Expect(project.Sync.LastError).To(Equal("notion unavailable"))
+
+			Expect(project.StartInitialSync(clock)).To(Succeed())
+			Expect(project.Sync.Cursor).To(Equal("cursor_2"))
+			Expect(project.Sync.PagesSynced).To(Equal(100))
+			Expect(project.Sync.LastError).To(BeEmpty())
+		})
+
+		It("should start over when a completed sync is restarted", func() {
+			Expect(project.StartInitialSync(clock)).To(Succeed())
+			project.AdvanceInitialSync("", 100)
+			Expect(project.CompleteInitialSync(clock)).To(Succeed())
+
+			clock.now = clock.now.Add(time.Hour)
+			Expect(project.StartInitialSync(clock)).To(Succeed())
+
+			Expect(project.Sync.PagesSynced).To(BeZero())
+			Expect(*project.Sync.StartedAt).To(Equal(clock.Now()))
+			Expect(project.Sync.InitialSyncCompleted()).To(BeFalse())
+		})
+	})
+
+	Describe("transitions", func() {
+		It("should degrade a synced project and recover it", func() {
+			Expect(project.StartInitialSync(clock)).To(Succeed())
+			Expect(project.CompleteInitialSync(clock)).To(Succeed())
+
+			Expect(project.Degrade(errors.New("sweep failed"), clock)).To(Succeed())
+			Expect(project.Sync.Status).To(Equal(domain.SyncStatusDegraded))
+			Expect(project.Sync.LastError).To(Equal("sweep failed"))
+
+			clock.now = clock.now.Add(time.Hour)
+			Expect(project.MarkSynced(clock.Now(), clock)).To(Succeed())
+			Expect(project.Sync.Status).To(Equal(domain.SyncStatusSynced))
+			Expect(*project.Sync.SyncedAt).To(Equal(clock.Now()))
+			Expect(project.Sync.LastError).To(BeEmpty())
+		})
+
+		It("should keep the status change time when the status stays the same", func() {
+			Expect(project.StartInitialSync(clock)).To(Succeed())
+			Expect(project.CompleteInitialSync(clock)).To(Succeed())
+			changedAt := project.Sync.StatusChangedAt
+
+			clock.now = clock.now.Add(time.Hour)
+			Expect(project.MarkSynced(clock.Now(), clock)).To(Succeed())
+
+			Expect(project.Sync.StatusChangedAt).To(Equal(changedAt))
+		})
+
+		It("should reject transitions the state machine does not allow", func() {
+			err := project.Degrade(errors.New("sweep failed"), clock)
+
+			Expect(err).To(MatchError(domain.ErrInvalidSyncTransition))
+			Expect(project.Sync.Status).To(Equal(domain.SyncStatusPending))
+			Expect(project.Sync.LastError).To(BeEmpty())
+		})
+
+		DescribeTable("CanTransitionTo",
+			func(from, to domain.SyncStatus, allowed bool) {
+				Expect(from.CanTransitionTo(to)).To(Equal(allowed))
+			},
+			Entry("pending to initial_syncing", domain.SyncStatusPending, domain.SyncStatusInitialSyncing, true),
+			Entry("pending to synced", domain.SyncStatusPending, domain.SyncStatusSynced, false),
+			Entry("initial_syncing to degraded", domain.SyncStatusInitialSyncing, domain.SyncStatusDegraded, false),
+			Entry("failed to synced", domain.SyncStatusFailed, domain.SyncStatusSynced, false),
+			Entry("token_revoked to initial_syncing", domain.SyncStatusTokenRevoked, domain.SyncStatusInitialSyncing, true),
+			Entry("degraded to synced", domain.SyncStatusDegraded, domain.SyncStatusSynced, true),
+			Entry("synced to pending", domain.SyncStatusSynced, domain.SyncStatusPending, false),
+		)
+	})
+})
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+
+	"src/internal/modules/projects/application/tasks"
+	"src/internal/modules/projects/domain"
+	usersDomain "src/internal/modules/users/domain"
+)
+
+// InitialSyncer performs a project's initial sync (see application.ProjectSyncService)
+type InitialSyncer interface {
+	PerformInitialSync(ctx context.Context, projectID uuid.UUID) (*domain.Project, error)
+}
+
+// InitialSyncHandler processes tasks.TypeInitialSync tasks
+type InitialSyncHandler struct {
+	syncer InitialSyncer
+	logger *log.Logger
+}
+
+// NewInitialSyncHandler creates a new initial sync task handler
+func NewInitialSyncHandler(syncer InitialSyncer, logger *log.Logger) *InitialSyncHandler {
+	return &InitialSyncHandler{
+		syncer: syncer,
+		logger: logger,
+	}
+}
+
+// Register adds the handler to the job worker's mux
+func (h *InitialSyncHandler) Register(mux *asynq.ServeMux) {
+	mux.Handle(tasks.TypeInitialSync, h)
+}
+
+// ProcessTask implements asynq.Handler. Tasks that cannot succeed until something
+// changes (malformed payloads, deleted projects, disconnected owners) are not
+// retried; other errors are retried by asynq and resume from the stored cursor.
+func (h *InitialSyncHandler) ProcessTask(ctx context.Context, task *asynq.Task) error {
+	payload, err := tasks.ParseInitialSyncPayload(task)
+	if err != nil {
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+
+	project, err := h.syncer.PerformInitialSync(ctx, payload.ProjectID)
+	if errors.Is(err, domain.ErrProjectNotFound) {
+		h.logger.Printf("Skipping initial sync of deleted project %s", payload.ProjectID)
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+	if errors.Is(err, usersDomain.ErrNotionTokenMissing) {
+		h.logger.Printf("Stopping initial sync of project %s until its owner reconnects Notion", payload.ProjectID)
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+	if err != nil {
+		return fmt.Errorf("initial sync of project %s failed: %w", payload.ProjectID, err)
+	}
+
+	h.logger.Printf("Initial sync of project %s is %s with %d pages", payload.ProjectID, project.Sync.Status, project.Sync.PagesSynced)
+	return nil
+}
@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/application/tasks"
+	"src/internal/modules/projects/domain"
+	usersDomain "src/internal/modules/users/domain"
+)
+
+// Reconciler sweeps a project for missed webhook events (see application.ReconciliationService)
+type Reconciler interface {
+	Reconcile(ctx context.Context, projectID uuid.UUID) (application.ReconciliationReport, error)
+}
+
+// ReconcileHandler processes tasks.TypeReconcile tasks
+type ReconcileHandler struct {
+	reconciler Reconciler
+	logger     *log.Logger
+}
+
+// NewReconcileHandler creates a new reconcile task handler
+func NewReconcileHandler(reconciler Reconciler, logger *log.Logger) *ReconcileHandler {
+	return &ReconcileHandler{
+		reconciler: reconciler,
+		logger:     logger,
+	}
+}
+
+// Register adds the handler to the job worker's mux
+func (h *ReconcileHandler) Register(mux *asynq.ServeMux) {
+	mux.Handle(tasks.TypeReconcile, h)
+}
+
+// ProcessTask implements asynq.Handler. Sweeps that cannot succeed until something
+// changes (malformed payloads, deleted projects, disconnected owners, property
+// mappings broken by a schema change) are not retried; the next scheduled sweep
+// tries again.
+func (h *ReconcileHandler) ProcessTask(ctx context.Context, task *asynq.Task) error {
+	payload, err := tasks.ParseReconcilePayload(task)
+	if err != nil {
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+
+	report, err := h.reconciler.Reconcile(ctx, payload.ProjectID)
+	if errors.Is(err, domain.ErrProjectNotFound) || errors.Is(err, usersDomain.ErrNotionTokenMissing) ||
+		errors.Is(err, domain.ErrInvalidPropertyMapping) {
+		h.logger.Printf("Skipping reconciliation of project %s: %v", payload.ProjectID, err)
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+	if err != nil {
+		return fmt.Errorf("reconciliation of project %s failed: %w", payload.ProjectID, err)
+	}
+
+	if report.Skipped {
+		h.logger.Printf("Skipping reconciliation of project %s until its initial sync completes", payload.ProjectID)
+		return nil
+	}
+	h.logger.Printf("Reconciled project %s: %d pages edited since %s, %d created, %d updated, %d archived",
+		payload.ProjectID, report.Checked, report.Since.Format(time.RFC3339), report.Created, report.Updated, report.Archived)
+	return nil
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"src/internal/modules/projects/application/tasks"
+	"src/internal/modules/projects/domain"
+)
+
+// reconcileScheduleTimeout bounds the project listing of a schedule refresh
+const reconcileScheduleTimeout = 30 * time.Second
+
+// ProjectLister lists the projects to reconcile (see domain.Repository)
+type ProjectLister interface {
+	FindAll(ctx context.Context) ([]*domain.Project, error)
+}
+
+// ReconcileSchedule implements asynq.PeriodicTaskConfigProvider with one periodic
+// tasks.TypeReconcile task per project, run at the project's reconcile interval.
+// The asynq.PeriodicTaskManager polls it, so new projects and interval changes
+// are picked up without restarting the job worker.
+type ReconcileSchedule struct {
+	projects        ProjectLister
+	defaultInterval time.Duration
+}
+
+// NewReconcileSchedule creates the reconciliation schedule. Projects without their
+// own interval are reconciled every defaultInterval, or never if it is zero.
+func NewReconcileSchedule(projects ProjectLister, defaultInterval time.Duration) *ReconcileSchedule {
+	return &ReconcileSchedule{
+		projects:        projects,
+		defaultInterval: defaultInterval,
+	}
+}
+
+// GetConfigs implements asynq.PeriodicTaskConfigProvider
+func (s *ReconcileSchedule) GetConfigs() ([]*asynq.PeriodicTaskConfig, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileScheduleTimeout)
+	defer cancel()
+
+	projects, err := s.projects.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects to reconcile: %w", err)
+	}
+
+	configs := make([]*asynq.PeriodicTaskConfig, 0, len(projects))
+	for _, project := range projects {
+		interval := project.ReconcileEvery(s.defaultInterval)
+		if interval <= 0 {
+			continue
+		}
+
+		task, err := tasks.NewReconcileTask(project.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, &asynq.PeriodicTaskConfig{
+			Cronspec: "@every " + interval.String(),
+			Task:     task,
+			// Keeps sweeps of a project from overlapping when one outlasts the
+			// interval or several job workers run the scheduler
+			Opts: []asynq.Option{asynq.Unique(interval)},
+		})
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,70 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application/tasks"
+	"src/internal/modules/projects/domain"
+	"src/internal/modules/projects/infrastructure/jobs"
+)
+
+type mockProjectLister struct {
+	projects []*domain.Project
+	err      error
+}
+
+func (m *mockProjectLister) FindAll(ctx context.Context) ([]*domain.Project, error) {
+	return m.projects, m.err
+}
+
+var _ = Describe("ReconcileSchedule", func() {
+	var (
+		defaultProject *domain.Project
+		customProject  *domain.Project
+		lister         *mockProjectLister
+	)
+
+	BeforeEach(func() {
+		defaultProject = &domain.Project{ID: uuid.New()}
+		customProject = &domain.Project{ID: uuid.New(), ReconcileInterval: 15 * time.Minute}
+		lister = &mockProjectLister{projects: []*domain.Project{defaultProject, customProject}}
+	})
+
+	It("schedules every project at its own interval", func() {
+		configs, err := jobs.NewReconcileSchedule(lister, time.Hour).GetConfigs()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configs).To(HaveLen(2))
+		Expect(configs[0].Cronspec).To(Equal("@every 1h0m0s"))
+		Expect(configs[1].Cronspec).To(Equal("@every 15m0s"))
+
+		Expect(configs[1].Task.Type()).To(Equal(tasks.TypeReconcile))
+		payload, err := tasks.ParseReconcilePayload(configs[1].Task)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.ProjectID).To(Equal(customProject.ID))
+		Expect(configs[1].Opts).To(ContainElement(asynq.Unique(15 * time.Minute)))
+	})
+
+	It("leaves out projects without an interval when the default is disabled", func() {
+		configs, err := jobs.NewReconcileSchedule(lister, 0).GetConfigs()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configs).To(HaveLen(1))
+		Expect(configs[0].Cronspec).To(Equal("@every 15m0s"))
+	})
+
+	It("returns listing errors so the previous schedule is kept", func() {
+		lister.err = errors.New("database unavailable")
+
+		_, err := jobs.NewReconcileSchedule(lister, time.Hour).GetConfigs()
+
+		Expect(err).To(MatchError(ContainSubstring("database unavailable")))
+	})
+})
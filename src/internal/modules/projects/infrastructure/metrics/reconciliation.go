@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"expvar"
+
+	"src/internal/modules/projects/application"
+)
+
+// ReconciliationMetrics publishes reconciliation sweep counters in an expvar map:
+//
+//	sweeps, skipped, failures       sweeps by outcome
+//	pages_checked                   pages edited since the previous sweeps
+//	divergences                     tasks repaired, also split into
+//	tasks_created, tasks_updated,   pages without a task, outdated tasks and
+//	tasks_archived                  tasks whose page is gone
+//	last_divergences                tasks repaired by the last sweep, by project ID
+type ReconciliationMetrics struct {
+	vars     *expvar.Map
+	projects *expvar.Map
+}
+
+// NewReconciliationMetrics creates reconciliation metrics stored in vars, usually
+// a map published with expvar.NewMap
+func NewReconciliationMetrics(vars *expvar.Map) *ReconciliationMetrics {
+	projects := new(expvar.Map).Init()
+	vars.Set("last_divergences", projects)
+
+	return &ReconciliationMetrics{
+		vars:     vars,
+		projects: projects,
+	}
+}
+
+// ObserveSweep implements application.ReconciliationMetrics
+func (m *ReconciliationMetrics) ObserveSweep(report application.ReconciliationReport, err error) {
+	switch {
+	case err != nil:
+		m.vars.Add("failures", 1)
+	case report.Skipped:
+		m.vars.Add("skipped", 1)
+	default:
+		m.vars.Add("sweeps", 1)
+		m.vars.Add("pages_checked", int64(report.Checked))
+		m.vars.Add("divergences", int64(report.Divergences()))
+		m.vars.Add("tasks_created", int64(report.Created))
+		m.vars.Add("tasks_updated", int64(report.Updated))
+		m.vars.Add("tasks_archived", int64(report.Archived))
+
+		last := new(expvar.Int)
+		last.Set(int64(report.Divergences()))
+		m.projects.Set(report.ProjectID.String(), last)
+	}
+}
@@ -0,0 +1,58 @@
+package metrics_test
+
+import (
+	"errors"
+	"expvar"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/infrastructure/metrics"
+)
+
+var _ = Describe("ReconciliationMetrics", func() {
+	var (
+		vars    *expvar.Map
+		metric  *metrics.ReconciliationMetrics
+		project uuid.UUID
+	)
+
+	value := func(key string) string {
+		v := vars.Get(key)
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	}
+
+	BeforeEach(func() {
+		vars = new(expvar.Map).Init()
+		metric = metrics.NewReconciliationMetrics(vars)
+		project = uuid.New()
+	})
+
+	It("counts the divergences found by sweeps", func() {
+		metric.ObserveSweep(application.ReconciliationReport{ProjectID: project, Checked: 10, Created: 1, Updated: 2}, nil)
+		metric.ObserveSweep(application.ReconciliationReport{ProjectID: project, Checked: 4, Archived: 1}, nil)
+
+		Expect(value("sweeps")).To(Equal("2"))
+		Expect(value("pages_checked")).To(Equal("14"))
+		Expect(value("divergences")).To(Equal("4"))
+		Expect(value("tasks_created")).To(Equal("1"))
+		Expect(value("tasks_updated")).To(Equal("2"))
+		Expect(value("tasks_archived")).To(Equal("1"))
+		Expect(value("last_divergences")).To(MatchJSON(`{"` + project.String() + `": 1}`))
+	})
+
+	It("counts failed and skipped sweeps separately", func() {
+		metric.ObserveSweep(application.ReconciliationReport{ProjectID: project, Updated: 3}, errors.New("notion unavailable"))
+		metric.ObserveSweep(application.ReconciliationReport{ProjectID: project, Skipped: true}, nil)
+
+		Expect(value("failures")).To(Equal("1"))
+		Expect(value("skipped")).To(Equal("1"))
+		Expect(value("sweeps")).To(BeEmpty())
+		Expect(value("divergences")).To(BeEmpty())
+	})
+})
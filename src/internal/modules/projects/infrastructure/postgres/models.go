@@ -16,6 +16,15 @@ type ProjectRecord struct {
 	UserID              uuid.UUID      `gorm:"not null;type:uuid;index"`
 	NotionDatabaseID    string         `gorm:"not null;type:varchar(255);uniqueIndex"`
 	NotionWebhookSecret string         `gorm:"not null;type:varchar(255)"`
+	ReconcileInterval   int64          `gorm:"not null;default:0"` // Seconds; zero uses the worker default
+	PropertyMapping     MappingRecord  `gorm:"type:jsonb;serializer:json"`
+	SyncStatus          string         `gorm:"not null;type:varchar(32);default:'pending';index"`
+	SyncStatusChangedAt time.Time      `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	SyncLastError       string         `gorm:"type:text"`
+	SyncCursor          string         `gorm:"type:varchar(255)"`
+	SyncPagesSynced     int            `gorm:"not null;default:0"`
+	SyncStartedAt       *time.Time     `gorm:""`
+	SyncedAt            *time.Time     `gorm:""`
 	CreatedAt           time.Time      `gorm:"not null;index"`
 	UpdatedAt           time.Time      `gorm:"not null"`
 	DeletedAt           gorm.DeletedAt `gorm:"index"`
@@ -26,6 +35,17 @@ func (ProjectRecord) TableName() string {
 	return "projects"
 }
 
+// MappingRecord is the JSON shape of a project's property mapping
+type MappingRecord struct {
+	Start      string `json:"start,omitempty"`
+	End        string `json:"end,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+	Assignee   string `json:"assignee,omitempty"`
+	Parent     string `json:"parent,omitempty"`
+	Dependency string `json:"dependency,omitempty"`
+}
+
 // toDomainProject converts a ProjectRecord to a domain Project
 func toDomainProject(record ProjectRecord) domain.Project {
 	return domain.Project{
@@ -34,8 +54,19 @@ func toDomainProject(record ProjectRecord) domain.Project {
 		UserID:              record.UserID,
 		NotionDatabaseID:    record.NotionDatabaseID,
 		NotionWebhookSecret: record.NotionWebhookSecret,
+		ReconcileInterval:   time.Duration(record.ReconcileInterval) * time.Second,
+		PropertyMapping:     domain.PropertyMapping(record.PropertyMapping),
 		CreatedAt:           record.CreatedAt,
 		UpdatedAt:           record.UpdatedAt,
+		Sync: domain.SyncState{
+			Status:          domain.SyncStatus(record.SyncStatus),
+			StatusChangedAt: record.SyncStatusChangedAt,
+			LastError:       record.SyncLastError,
+			Cursor:          record.SyncCursor,
+			PagesSynced:     record.SyncPagesSynced,
+			StartedAt:       record.SyncStartedAt,
+			SyncedAt:        record.SyncedAt,
+		},
 	}
 }
 
@@ -47,7 +78,55 @@ func toProjectRecord(project domain.Project) ProjectRecord {
 		UserID:              project.UserID,
 		NotionDatabaseID:    project.NotionDatabaseID,
 		NotionWebhookSecret: project.NotionWebhookSecret,
+		ReconcileInterval:   int64(project.ReconcileInterval / time.Second),
+		PropertyMapping:     MappingRecord(project.PropertyMapping),
+		SyncStatus:          string(project.Sync.Status),
+		SyncStatusChangedAt: project.Sync.StatusChangedAt,
+		SyncLastError:       project.Sync.LastError,
+		SyncCursor:          project.Sync.Cursor,
+		SyncPagesSynced:     project.Sync.PagesSynced,
+		SyncStartedAt:       project.Sync.StartedAt,
+		SyncedAt:            project.Sync.SyncedAt,
 		CreatedAt:           project.CreatedAt,
 		UpdatedAt:           project.UpdatedAt,
 	}
 }
+
+// ReconciliationRecord represents the project_reconciliations table structure in PostgreSQL
+type ReconciliationRecord struct {
+	ProjectID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	LastSweepAt   *time.Time
+	LastAttemptAt *time.Time
+	LastError     string    `gorm:"type:text"`
+	Divergences   int       `gorm:"not null;default:0"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (ReconciliationRecord) TableName() string {
+	return "project_reconciliations"
+}
+
+// toDomainReconciliation converts a ReconciliationRecord to a domain Reconciliation
+func toDomainReconciliation(record ReconciliationRecord) domain.Reconciliation {
+	return domain.Reconciliation{
+		ProjectID:     record.ProjectID,
+		LastSweepAt:   record.LastSweepAt,
+		LastAttemptAt: record.LastAttemptAt,
+		LastError:     record.LastError,
+		Divergences:   record.Divergences,
+		UpdatedAt:     record.UpdatedAt,
+	}
+}
+
+// toReconciliationRecord converts a domain Reconciliation to a ReconciliationRecord
+func toReconciliationRecord(reconciliation domain.Reconciliation) ReconciliationRecord {
+	return ReconciliationRecord{
+		ProjectID:     reconciliation.ProjectID,
+		LastSweepAt:   reconciliation.LastSweepAt,
+		LastAttemptAt: reconciliation.LastAttemptAt,
+		LastError:     reconciliation.LastError,
+		Divergences:   reconciliation.Divergences,
+		UpdatedAt:     reconciliation.UpdatedAt,
+	}
+}
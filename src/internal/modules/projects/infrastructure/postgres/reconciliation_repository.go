@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"src/internal/database"
+	"src/internal/modules/projects/domain"
+)
+
+// ReconciliationRepository implements domain.ReconciliationRepository using PostgreSQL/GORM
+type ReconciliationRepository struct {
+	db *gorm.DB
+}
+
+// NewReconciliationRepository creates a new PostgreSQL project reconciliation repository
+func NewReconciliationRepository(db *gorm.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+// FindByProjectID retrieves the reconciliation state of a project
+func (r *ReconciliationRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) (*domain.Reconciliation, error) {
+	var record ReconciliationRecord
+
+	err := database.Conn(ctx, r.db).Where("project_id = ?", projectID).First(&record).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrReconciliationNotFound
+		}
+		return nil, err
+	}
+
+	reconciliation := toDomainReconciliation(record)
+	return &reconciliation, nil
+}
+
+// Save creates or replaces the reconciliation state of a project
+func (r *ReconciliationRepository) Save(ctx context.Context, reconciliation *domain.Reconciliation) error {
+	record := toReconciliationRecord(*reconciliation)
+	return database.Conn(ctx, r.db).Save(&record).Error
+}
@@ -5,6 +5,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"src/internal/database"
 	"src/internal/modules/projects/domain"
 
 	"github.com/google/uuid"
@@ -24,7 +25,7 @@ func NewProjectRepository(db *gorm.DB) *ProjectRepository {
 func (r *ProjectRepository) Save(ctx context.Context, project *domain.Project) error {
 	record := toProjectRecord(*project)
 
-	if err := r.db.WithContext(ctx).Create(&record).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(&record).Error; err != nil {
 		return err
 	}
 
@@ -39,7 +40,7 @@ func (r *ProjectRepository) Save(ctx context.Context, project *domain.Project) e
 func (r *ProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Project, error) {
 	var record ProjectRecord
 
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&record).Error
+	err := database.Conn(ctx, r.db).Where("id = ?", id).First(&record).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrProjectNotFound
@@ -55,7 +56,7 @@ func (r *ProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain
 func (r *ProjectRepository) FindByPublicID(ctx context.Context, publicID string) (*domain.Project, error) {
 	var record ProjectRecord
 
-	err := r.db.WithContext(ctx).Where("public_id = ?", publicID).First(&record).Error
+	err := database.Conn(ctx, r.db).Where("public_id = ?", publicID).First(&record).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrProjectNotFound
@@ -71,7 +72,7 @@ func (r *ProjectRepository) FindByPublicID(ctx context.Context, publicID string)
 func (r *ProjectRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Project, error) {
 	var records []ProjectRecord
 
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&records).Error
@@ -89,11 +90,29 @@ func (r *ProjectRepository) FindByUserID(ctx context.Context, userID uuid.UUID)
 	return projects, nil
 }
 
+// FindAll retrieves every project
+func (r *ProjectRepository) FindAll(ctx context.Context) ([]*domain.Project, error) {
+	var records []ProjectRecord
+
+	err := database.Conn(ctx, r.db).Order("created_at").Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*domain.Project, 0, len(records))
+	for _, record := range records {
+		project := toDomainProject(record)
+		projects = append(projects, &project)
+	}
+
+	return projects, nil
+}
+
 // FindByNotionDatabaseID retrieves a project by Notion database ID
 func (r *ProjectRepository) FindByNotionDatabaseID(ctx context.Context, notionDatabaseID string) (*domain.Project, error) {
 	var record ProjectRecord
 
-	err := r.db.WithContext(ctx).Where("notion_database_id = ?", notionDatabaseID).First(&record).Error
+	err := database.Conn(ctx, r.db).Where("notion_database_id = ?", notionDatabaseID).First(&record).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrProjectNotFound
@@ -105,13 +124,25 @@ func (r *ProjectRepository) FindByNotionDatabaseID(ctx context.Context, notionDa
 	return &project, nil
 }
 
-// Update updates an existing project
+// syncColumns are the fields of a project's sync state, written only by UpdateSync
+var syncColumns = []string{"SyncStatus", "SyncStatusChangedAt", "SyncLastError", "SyncCursor", "SyncPagesSynced", "SyncStartedAt", "SyncedAt"}
+
+// Update updates an existing project, leaving its sync state untouched so an edit
+// cannot undo progress stored meanwhile by the sync worker
 func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project) error {
 	record := toProjectRecord(*project)
 
-	err := r.db.WithContext(ctx).Save(&record).Error
-	if err != nil {
-		return err
+	result := database.Conn(ctx, r.db).
+		Select("*").
+		Omit(append(syncColumns, "CreatedAt")...).
+		Updates(&record)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrProjectNotFound
 	}
 
 	// Update the domain object with the updated timestamp
@@ -120,9 +151,30 @@ func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project)
 	return nil
 }
 
+// UpdateSync stores the sync state of a project, leaving its other fields untouched
+func (r *ProjectRepository) UpdateSync(ctx context.Context, project *domain.Project) error {
+	record := toProjectRecord(*project)
+
+	result := database.Conn(ctx, r.db).
+		Model(&ProjectRecord{}).
+		Where("id = ?", project.ID).
+		Select(syncColumns).
+		Updates(&record)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
 // Delete removes a project
 func (r *ProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&ProjectRecord{})
+	result := database.Conn(ctx, r.db).Where("id = ?", id).Delete(&ProjectRecord{})
 
 	if result.Error != nil {
 		return result.Error
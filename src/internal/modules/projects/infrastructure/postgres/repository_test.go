@@ -17,6 +17,7 @@ import (
 	"src/internal/database"
 	"src/internal/modules/projects/domain"
 	projectRepo "src/internal/modules/projects/infrastructure/postgres"
+	"src/internal/pkg/outbox"
 )
 
 var (
@@ -83,7 +84,7 @@ var _ = BeforeSuite(func() {
 
 	// Run migrations using GORM AutoMigrate for tests
 	migrator := database.Migrator()
-	if err := migrator.AutoMigrate(&projectRepo.ProjectRecord{}); err != nil {
+	if err := migrator.AutoMigrate(&projectRepo.ProjectRecord{}, &outbox.Record{}); err != nil {
 		Fail("Failed to run AutoMigrate: " + err.Error())
 	}
 
@@ -214,6 +215,106 @@ var _ = Describe("ProjectRepository", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found.NotionWebhookSecret).To(Equal("updated_secret"))
 		})
+
+		It("should store the reconcile interval", func() {
+			project, _ := domain.NewProject(uuid.New(), "db1", "secret1", &mockIDGenerator{}, &mockClock{})
+			repo.Save(ctx, &project)
+
+			Expect(project.SetReconcileInterval(15*time.Minute, &mockClock{})).To(Succeed())
+			Expect(repo.Update(ctx, &project)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, project.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.ReconcileInterval).To(Equal(15 * time.Minute))
+		})
+
+		It("should store the property mapping", func() {
+			project, _ := domain.NewProject(uuid.New(), "db1", "secret1", &mockIDGenerator{}, &mockClock{})
+			repo.Save(ctx, &project)
+
+			mapping := domain.PropertyMapping{Start: "Timeline", End: "Timeline", Status: "Status", Parent: "Parent item"}
+			schema := domain.Schema{
+				"Timeline":    {Type: "date"},
+				"Status":      {Type: "status"},
+				"Parent item": {Type: "relation", RelatedDatabaseID: "db1"},
+			}
+			Expect(project.SetPropertyMapping(mapping, schema, &mockClock{})).To(Succeed())
+			Expect(repo.Update(ctx, &project)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, project.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.PropertyMapping).To(Equal(mapping))
+		})
+
+		It("should keep sync state stored after the project was loaded", func() {
+			project, _ := domain.NewProject(uuid.New(), "db1", "secret1", &mockIDGenerator{}, &mockClock{})
+			repo.Save(ctx, &project)
+			edited, err := repo.FindByID(ctx, project.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			// The sync worker moves the initial sync forward meanwhile
+			Expect(project.StartInitialSync(&mockClock{})).To(Succeed())
+			project.AdvanceInitialSync("cursor_2", 100)
+			Expect(repo.UpdateSync(ctx, &project)).To(Succeed())
+
+			edited.NotionWebhookSecret = "updated_secret"
+			Expect(repo.Update(ctx, edited)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, project.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.NotionWebhookSecret).To(Equal("updated_secret"))
+			Expect(found.Sync.Status).To(Equal(domain.SyncStatusInitialSyncing))
+			Expect(found.Sync.Cursor).To(Equal("cursor_2"))
+			Expect(found.Sync.PagesSynced).To(Equal(100))
+		})
+
+		It("should return error when project does not exist", func() {
+			project := domain.Project{ID: uuid.New()}
+
+			err := repo.Update(ctx, &project)
+			Expect(err).To(MatchError(domain.ErrProjectNotFound))
+		})
+	})
+
+	Describe("UpdateSync", func() {
+		It("should store the sync state without touching other fields", func() {
+			project, _ := domain.NewProject(uuid.New(), "db1", "secret1", &mockIDGenerator{}, &mockClock{})
+			repo.Save(ctx, &project)
+
+			Expect(project.StartInitialSync(&mockClock{})).To(Succeed())
+			project.AdvanceInitialSync("cursor_2", 100)
+			project.NotionWebhookSecret = "unsaved_secret"
+			Expect(repo.UpdateSync(ctx, &project)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, project.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.Sync.Status).To(Equal(domain.SyncStatusInitialSyncing))
+			Expect(found.Sync.Cursor).To(Equal("cursor_2"))
+			Expect(found.Sync.PagesSynced).To(Equal(100))
+			Expect(found.Sync.StartedAt).ToNot(BeNil())
+			Expect(found.NotionWebhookSecret).To(Equal("secret1"))
+		})
+
+		It("should return error when project does not exist", func() {
+			project := domain.Project{ID: uuid.New()}
+
+			err := repo.UpdateSync(ctx, &project)
+			Expect(err).To(MatchError(domain.ErrProjectNotFound))
+		})
+	})
+
+	Describe("FindAll", func() {
+		It("should find the projects of every user", func() {
+			idGen := &mockIDGenerator{}
+			first, _ := domain.NewProject(uuid.New(), "db1", "secret1", idGen, &mockClock{})
+			second, _ := domain.NewProject(uuid.New(), "db2", "secret2", idGen, &mockClock{})
+			repo.Save(ctx, &first)
+			repo.Save(ctx, &second)
+
+			projects, err := repo.FindAll(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(projects).To(HaveLen(2))
+		})
 	})
 
 	Describe("Delete", func() {
@@ -0,0 +1,155 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/database"
+	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/pkg/outbox"
+)
+
+var errRollback = errors.New("rollback requested")
+
+type failingEventRecorder struct{}
+
+func (failingEventRecorder) Record(ctx context.Context, events ...shared.Event) error {
+	return errRollback
+}
+
+var _ = Describe("TransactionManager", func() {
+	var (
+		ctx   context.Context
+		txMgr shared.TransactionManager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		txMgr = database.NewTransactionManager(db)
+		db.Exec("TRUNCATE TABLE projects, outbox_messages CASCADE")
+	})
+
+	newProject := func(notionDatabaseID string, idGen *mockIDGenerator) domain.Project {
+		project, err := domain.NewProject(uuid.New(), notionDatabaseID, "secret", idGen, &mockClock{})
+		Expect(err).ToNot(HaveOccurred())
+		return project
+	}
+
+	It("should commit repository changes when fn succeeds", func() {
+		project := newProject("db_commit", &mockIDGenerator{})
+
+		err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+			return repo.Save(ctx, &project)
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = repo.FindByID(ctx, project.ID)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should roll back repository changes when fn returns an error", func() {
+		project := newProject("db_rollback", &mockIDGenerator{})
+
+		err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := repo.Save(ctx, &project); err != nil {
+				return err
+			}
+
+			// The change is visible inside the transaction
+			_, err := repo.FindByID(ctx, project.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			return errRollback
+		})
+		Expect(err).To(MatchError(errRollback))
+
+		_, err = repo.FindByID(ctx, project.ID)
+		Expect(err).To(Equal(domain.ErrProjectNotFound))
+	})
+
+	It("should roll back only the nested savepoint when an inner call fails", func() {
+		outer := newProject("db_outer", &mockIDGenerator{counter: 0})
+		inner := newProject("db_inner", &mockIDGenerator{counter: 10})
+
+		err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := repo.Save(ctx, &outer); err != nil {
+				return err
+			}
+
+			err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := repo.Save(ctx, &inner); err != nil {
+					return err
+				}
+				return errRollback
+			})
+			Expect(err).To(MatchError(errRollback))
+
+			// The outer transaction carries on without the inner changes
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = repo.FindByID(ctx, outer.ID)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = repo.FindByID(ctx, inner.ID)
+		Expect(err).To(Equal(domain.ErrProjectNotFound))
+	})
+
+	It("should roll back the nested savepoint together with the outer transaction", func() {
+		inner := newProject("db_inner", &mockIDGenerator{})
+
+		err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+			err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+				return repo.Save(ctx, &inner)
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return errRollback
+		})
+		Expect(err).To(MatchError(errRollback))
+
+		_, err = repo.FindByID(ctx, inner.ID)
+		Expect(err).To(Equal(domain.ErrProjectNotFound))
+	})
+
+	Describe("CreateProjectUseCase", func() {
+		It("should store the project and its outbox event atomically", func() {
+			uc := application.NewCreateProjectUseCase(repo, &mockIDGenerator{}, &mockClock{}, txMgr, outbox.New(db))
+
+			resp, err := uc.Execute(ctx, application.CreateProjectRequest{
+				UserID:              uuid.New(),
+				NotionDatabaseID:    "db_outbox",
+				NotionWebhookSecret: "secret",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = repo.FindByID(ctx, resp.Project.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			var records []outbox.Record
+			Expect(db.Find(&records).Error).ToNot(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Topic).To(Equal(domain.ProjectCreatedTopic))
+			Expect(records[0].PublishedAt).To(BeNil())
+		})
+
+		It("should not store the project when the event cannot be recorded", func() {
+			uc := application.NewCreateProjectUseCase(repo, &mockIDGenerator{}, &mockClock{}, txMgr, failingEventRecorder{})
+
+			_, err := uc.Execute(ctx, application.CreateProjectRequest{
+				UserID:              uuid.New(),
+				NotionDatabaseID:    "db_outbox",
+				NotionWebhookSecret: "secret",
+			})
+			Expect(err).To(MatchError(errRollback))
+
+			_, err = repo.FindByNotionDatabaseID(ctx, "db_outbox")
+			Expect(err).To(Equal(domain.ErrProjectNotFound))
+		})
+	})
+})
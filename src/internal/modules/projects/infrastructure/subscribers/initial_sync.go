@@ -0,0 +1,69 @@
+package subscribers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/hibiken/asynq"
+
+	"src/internal/modules/projects/application/tasks"
+	"src/internal/modules/projects/domain"
+	"src/internal/pkg/taskqueue"
+)
+
+// InitialSyncSchedulerHandlerName is the Watermill handler name used by the event worker
+const InitialSyncSchedulerHandlerName = "projects_initial_sync_scheduler"
+
+// InitialSyncScheduler enqueues the initial sync job of every created project
+type InitialSyncScheduler struct {
+	enqueuer taskqueue.Enqueuer
+	logger   *log.Logger
+}
+
+// NewInitialSyncScheduler creates a new initial sync scheduler
+func NewInitialSyncScheduler(enqueuer taskqueue.Enqueuer, logger *log.Logger) *InitialSyncScheduler {
+	return &InitialSyncScheduler{
+		enqueuer: enqueuer,
+		logger:   logger,
+	}
+}
+
+// Register adds the scheduler to the router
+func (s *InitialSyncScheduler) Register(router *message.Router, subscriber message.Subscriber) {
+	router.AddConsumerHandler(
+		InitialSyncSchedulerHandlerName,
+		domain.ProjectCreatedTopic,
+		subscriber,
+		s.Handle,
+	)
+}
+
+// Handle enqueues the initial sync of the project in a ProjectCreated message.
+// Redeliveries are harmless: the task ID is derived from the project, so a sync
+// that is already queued is not queued twice.
+func (s *InitialSyncScheduler) Handle(msg *message.Message) error {
+	var event domain.ProjectCreated
+	if err := json.Unmarshal(msg.Payload, &event); err != nil {
+		s.logger.Printf("Dropping malformed project created message %s: %v", msg.UUID, err)
+		return nil
+	}
+
+	task, err := tasks.NewInitialSyncTask(event.ProjectID)
+	if err != nil {
+		s.logger.Printf("Dropping project created message %s: %v", msg.UUID, err)
+		return nil
+	}
+
+	_, err = s.enqueuer.EnqueueContext(msg.Context(), task)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.logger.Printf("Scheduled initial sync of project %s", event.ProjectID)
+	return nil
+}
@@ -0,0 +1,92 @@
+package subscribers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/projects/application/tasks"
+	"src/internal/modules/projects/domain"
+	"src/internal/modules/projects/infrastructure/subscribers"
+)
+
+// fakeEnqueuer rejects tasks it has already queued, like asynq does for pending
+// tasks with the same ID. Task options cannot be inspected, so the payload stands
+// in for the ID.
+type fakeEnqueuer struct {
+	tasks  []*asynq.Task
+	queued map[string]bool
+	err    error
+}
+
+func (f *fakeEnqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	key := task.Type() + string(task.Payload())
+	if f.queued[key] {
+		return nil, asynq.ErrTaskIDConflict
+	}
+	f.queued[key] = true
+	f.tasks = append(f.tasks, task)
+	return &asynq.TaskInfo{Type: task.Type(), Payload: task.Payload()}, nil
+}
+
+func projectCreatedMessage(projectID uuid.UUID) *message.Message {
+	payload, err := json.Marshal(domain.ProjectCreated{ProjectID: projectID, NotionDatabaseID: "database_123"})
+	Expect(err).ToNot(HaveOccurred())
+	return message.NewMessage(watermill.NewUUID(), payload)
+}
+
+var _ = Describe("InitialSyncScheduler", func() {
+	var (
+		enqueuer  *fakeEnqueuer
+		scheduler *subscribers.InitialSyncScheduler
+	)
+
+	BeforeEach(func() {
+		enqueuer = &fakeEnqueuer{queued: make(map[string]bool)}
+		scheduler = subscribers.NewInitialSyncScheduler(enqueuer, log.New(io.Discard, "", 0))
+	})
+
+	It("enqueues the initial sync of a created project", func() {
+		projectID := uuid.New()
+
+		Expect(scheduler.Handle(projectCreatedMessage(projectID))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(HaveLen(1))
+		Expect(enqueuer.tasks[0].Type()).To(Equal(tasks.TypeInitialSync))
+		payload, err := tasks.ParseInitialSyncPayload(enqueuer.tasks[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.ProjectID).To(Equal(projectID))
+	})
+
+	It("acknowledges redeliveries of a project already queued", func() {
+		projectID := uuid.New()
+
+		Expect(scheduler.Handle(projectCreatedMessage(projectID))).To(Succeed())
+		Expect(scheduler.Handle(projectCreatedMessage(projectID))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(HaveLen(1))
+	})
+
+	It("returns enqueue failures so the message is redelivered", func() {
+		enqueuer.err = errors.New("redis unavailable")
+
+		Expect(scheduler.Handle(projectCreatedMessage(uuid.New()))).To(MatchError("redis unavailable"))
+	})
+
+	It("drops malformed messages", func() {
+		Expect(scheduler.Handle(message.NewMessage(watermill.NewUUID(), []byte("not json")))).To(Succeed())
+		Expect(enqueuer.tasks).To(BeEmpty())
+	})
+})
@@ -3,6 +3,7 @@ package http
 import (
 	"time"
 
+	"src/internal/modules/projects/application"
 	"src/internal/modules/projects/domain"
 )
 
@@ -10,6 +11,13 @@ import (
 type CreateProjectRequestDTO struct {
 	NotionDatabaseID    string `json:"notion_database_id" validate:"required"`
 	NotionWebhookSecret string `json:"notion_webhook_secret" validate:"required"`
+	ReconcileMinutes    int    `json:"reconcile_interval_minutes,omitempty"` // Zero uses the worker default
+}
+
+// UpdateProjectRequestDTO represents the request payload for updating a project.
+// Omitted fields are left unchanged.
+type UpdateProjectRequestDTO struct {
+	ReconcileMinutes *int `json:"reconcile_interval_minutes"` // Zero resets to the worker default
 }
 
 // ProjectResponseDTO represents the response payload for project operations
@@ -18,10 +26,23 @@ type ProjectResponseDTO struct {
 	UserID              string    `json:"user_id"`
 	NotionDatabaseID    string    `json:"notion_database_id"`
 	NotionWebhookSecret string    `json:"notion_webhook_secret,omitempty"` // Hide in responses
+	ReconcileMinutes    int       `json:"reconcile_interval_minutes"`      // Zero when using the worker default
+	Sync                SyncDTO   `json:"sync"`
 	CreatedAt           time.Time `json:"created_at"`
 	UpdatedAt           time.Time `json:"updated_at"`
 }
 
+// SyncDTO represents the sync state of a project. Tasks may lag behind Notion
+// unless the status is "synced".
+type SyncDTO struct {
+	Status          string     `json:"status"`
+	StatusChangedAt time.Time  `json:"status_changed_at"`
+	LastError       string     `json:"last_error,omitempty"`
+	PagesSynced     int        `json:"pages_synced"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	SyncedAt        *time.Time `json:"synced_at,omitempty"`
+}
+
 // ProjectsListResponseDTO represents the response payload for listing projects
 type ProjectsListResponseDTO struct {
 	Projects []ProjectResponseDTO `json:"projects"`
@@ -34,6 +55,15 @@ func toProjectResponseDTO(project domain.Project) ProjectResponseDTO {
 		ID:               project.PublicID, // Use PublicID for API responses
 		UserID:           project.UserID.String(),
 		NotionDatabaseID: project.NotionDatabaseID,
+		ReconcileMinutes: int(project.ReconcileInterval / time.Minute),
+		Sync: SyncDTO{
+			Status:          string(project.Sync.Status),
+			StatusChangedAt: project.Sync.StatusChangedAt,
+			LastError:       project.Sync.LastError,
+			PagesSynced:     project.Sync.PagesSynced,
+			StartedAt:       project.Sync.StartedAt,
+			SyncedAt:        project.Sync.SyncedAt,
+		},
 		// NotionWebhookSecret is omitted for security
 		CreatedAt: project.CreatedAt,
 		UpdatedAt: project.UpdatedAt,
@@ -48,3 +78,52 @@ func toProjectResponseDTOs(projects []*domain.Project) []ProjectResponseDTO {
 	}
 	return dtos
 }
+
+// PropertyMappingDTO names the Notion properties that feed task fields. Empty names
+// leave the field unmapped.
+type PropertyMappingDTO struct {
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	Status     string `json:"status"`
+	Priority   string `json:"priority"`
+	Assignee   string `json:"assignee"`
+	Parent     string `json:"parent"`
+	Dependency string `json:"dependency"`
+}
+
+// PropertyMappingResponseDTO represents a project's property mapping. Suggested,
+// Problems and Properties describe the live schema of the project's database and
+// are only included when it was inspected.
+type PropertyMappingResponseDTO struct {
+	Mapping    PropertyMappingDTO           `json:"mapping"`
+	Suggested  *PropertyMappingDTO          `json:"suggested,omitempty"`
+	Problems   map[string]string            `json:"problems,omitempty"`
+	Properties map[string]SchemaPropertyDTO `json:"properties,omitempty"`
+}
+
+// SchemaPropertyDTO describes a property of a Notion database
+type SchemaPropertyDTO struct {
+	Type              string `json:"type"`
+	RelatedDatabaseID string `json:"related_database_id,omitempty"`
+}
+
+// toPropertyMappingDTO converts a domain PropertyMapping to PropertyMappingDTO
+func toPropertyMappingDTO(mapping domain.PropertyMapping) PropertyMappingDTO {
+	return PropertyMappingDTO(mapping)
+}
+
+// toPropertyMappingResponseDTO converts a project's mapping checked against its schema
+func toPropertyMappingResponseDTO(resp application.GetPropertyMappingResponse) PropertyMappingResponseDTO {
+	suggested := toPropertyMappingDTO(resp.Suggested)
+	properties := make(map[string]SchemaPropertyDTO, len(resp.Schema))
+	for name, property := range resp.Schema {
+		properties[name] = SchemaPropertyDTO(property)
+	}
+
+	return PropertyMappingResponseDTO{
+		Mapping:    toPropertyMappingDTO(resp.Mapping),
+		Suggested:  &suggested,
+		Problems:   resp.Problems,
+		Properties: properties,
+	}
+}
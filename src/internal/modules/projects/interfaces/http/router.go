@@ -1,30 +1,45 @@
 package http
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"src/internal/database"
 	"src/internal/modules/projects/application"
+	"src/internal/modules/projects/domain"
 	"src/internal/modules/projects/infrastructure/postgres"
 	shared "src/internal/modules/shared/domain"
+	usersDomain "src/internal/modules/users/domain"
+	usersPostgres "src/internal/modules/users/infrastructure/postgres"
 	"src/internal/pkg/httpx"
 	"src/internal/pkg/middleware"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/outbox"
 )
 
-// NewRouter creates a new HTTP router for the projects module
-func NewRouter() chi.Router {
+// NewRouter creates a new HTTP router for the projects module.
+// notionOpts configure the Notion client used to inspect project databases.
+func NewRouter(notionOpts ...notion.ClientOption) chi.Router {
 	r := chi.NewRouter()
 
 	// Initialize dependencies
-	repo := postgres.NewProjectRepository(database.GormDB())
+	db := database.GormDB()
+	repo := postgres.NewProjectRepository(db)
 	idGen := shared.NewUUIDGenerator()
 	clock := shared.NewSystemClock()
-	txMgr := shared.NewNoopTransactionManager()
+	txMgr := database.NewTransactionManager(db)
+	events := outbox.New(db)
+	users := usersPostgres.NewUserRepository(db)
+	databases := notion.NewDatabases(notionOpts...)
 
 	// Initialize use cases
-	createProjectUC := application.NewCreateProjectUseCase(repo, idGen, clock, txMgr)
+	createProjectUC := application.NewCreateProjectUseCase(repo, idGen, clock, txMgr, events)
+	updateProjectUC := application.NewUpdateProjectUseCase(repo, clock, txMgr)
+	getPropertyMappingUC := application.NewGetPropertyMappingUseCase(repo, users, databases, clock)
+	updatePropertyMappingUC := application.NewUpdatePropertyMappingUseCase(repo, users, databases, clock, txMgr)
 
 	// Define routes
 	r.Post("/", httpx.EndpointJSON[CreateProjectRequestDTO](func(req *http.Request, body CreateProjectRequestDTO) (int, any, error) {
@@ -42,8 +57,12 @@ func NewRouter() chi.Router {
 			UserID:              userID,
 			NotionDatabaseID:    body.NotionDatabaseID,
 			NotionWebhookSecret: body.NotionWebhookSecret,
+			ReconcileInterval:   time.Duration(body.ReconcileMinutes) * time.Minute,
 		})
 		if err != nil {
+			if errors.Is(err, domain.ErrInvalidReconcileInterval) {
+				return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(err.Error(), nil)
+			}
 			return http.StatusInternalServerError, nil, err
 		}
 
@@ -70,5 +89,83 @@ func NewRouter() chi.Router {
 		return http.StatusOK, dto, nil
 	}))
 
+	r.Patch("/{projectID}", httpx.EndpointJSON[UpdateProjectRequestDTO](func(req *http.Request, body UpdateProjectRequestDTO) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		updateReq := application.UpdateProjectRequest{
+			UserID:   userID,
+			PublicID: chi.URLParam(req, "projectID"),
+		}
+		if body.ReconcileMinutes != nil {
+			interval := time.Duration(*body.ReconcileMinutes) * time.Minute
+			updateReq.ReconcileInterval = &interval
+		}
+
+		project, err := updateProjectUC.Execute(req.Context(), updateReq)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrProjectNotFound):
+				return http.StatusNotFound, nil, httpx.NotFound(err.Error())
+			case errors.Is(err, domain.ErrInvalidReconcileInterval):
+				return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(err.Error(), nil)
+			}
+			return http.StatusInternalServerError, nil, err
+		}
+
+		return http.StatusOK, toProjectResponseDTO(project), nil
+	}))
+
+	r.Get("/{projectID}/property-mapping", httpx.Endpoint(func(req *http.Request) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		resp, err := getPropertyMappingUC.Execute(req.Context(), application.GetPropertyMappingRequest{
+			UserID:   userID,
+			PublicID: chi.URLParam(req, "projectID"),
+		})
+		if err != nil {
+			return propertyMappingError(err)
+		}
+
+		return http.StatusOK, toPropertyMappingResponseDTO(resp), nil
+	}))
+
+	r.Put("/{projectID}/property-mapping", httpx.EndpointJSON[PropertyMappingDTO](func(req *http.Request, body PropertyMappingDTO) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		project, err := updatePropertyMappingUC.Execute(req.Context(), application.UpdatePropertyMappingRequest{
+			UserID:   userID,
+			PublicID: chi.URLParam(req, "projectID"),
+			Mapping:  domain.PropertyMapping(body),
+		})
+		if err != nil {
+			return propertyMappingError(err)
+		}
+
+		return http.StatusOK, PropertyMappingResponseDTO{Mapping: toPropertyMappingDTO(project.PropertyMapping)}, nil
+	}))
+
 	return r
 }
+
+// propertyMappingError maps errors of the property mapping endpoints to responses
+func propertyMappingError(err error) (int, any, error) {
+	var mappingErr *domain.PropertyMappingError
+	switch {
+	case errors.Is(err, domain.ErrProjectNotFound):
+		return http.StatusNotFound, nil, httpx.NotFound(err.Error())
+	case errors.As(err, &mappingErr):
+		return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(err.Error(), mappingErr.Problems)
+	case errors.Is(err, usersDomain.ErrNotionTokenMissing):
+		return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(err.Error(), nil)
+	}
+	return http.StatusInternalServerError, nil, err
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "context"
+
+// Event is a domain event that other modules can react to
+type Event interface {
+	// EventTopic returns the event bus topic the event is published on
+	EventTopic() string
+}
+
+// EventRecorder stores domain events so they are published only after the
+// surrounding transaction commits
+type EventRecorder interface {
+	Record(ctx context.Context, events ...Event) error
+}
+
+// NoopEventRecorder implements EventRecorder by discarding all events
+// Useful for testing or when no one listens for the events
+type NoopEventRecorder struct{}
+
+func NewNoopEventRecorder() EventRecorder {
+	return &NoopEventRecorder{}
+}
+
+func (r *NoopEventRecorder) Record(ctx context.Context, events ...Event) error {
+	return nil
+}
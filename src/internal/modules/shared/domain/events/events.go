@@ -2,6 +2,13 @@ package events
 
 const NotionWebhookReceivedTopic = "notion.webhook.received"
 
+// ProjectIDMetadataKey is the message metadata key holding the internal UUID of the
+// project a triaged Notion event belongs to. It is unset when the project is unknown.
+const ProjectIDMetadataKey = "project_id"
+
 type NotionWebhookReceived struct {
-	Payload []byte
+	// ProjectID is the internal UUID of the project the delivery was validated for.
+	// It is empty when the delivery was validated with the global webhook secret.
+	ProjectID string `json:",omitempty"`
+	Payload   []byte
 }
@@ -0,0 +1,219 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NotionEventType is the "type" field of a Notion webhook event
+type NotionEventType string
+
+const (
+	NotionPageCreated            NotionEventType = "page.created"
+	NotionPagePropertiesUpdated  NotionEventType = "page.properties_updated"
+	NotionPageContentUpdated     NotionEventType = "page.content_updated"
+	NotionPageMoved              NotionEventType = "page.moved"
+	NotionPageDeleted            NotionEventType = "page.deleted"
+	NotionPageUndeleted          NotionEventType = "page.undeleted"
+	NotionPageLocked             NotionEventType = "page.locked"
+	NotionPageUnlocked           NotionEventType = "page.unlocked"
+	NotionDatabaseCreated        NotionEventType = "database.created"
+	NotionDatabaseContentUpdated NotionEventType = "database.content_updated"
+	NotionDatabaseMoved          NotionEventType = "database.moved"
+	NotionDatabaseDeleted        NotionEventType = "database.deleted"
+	NotionDatabaseUndeleted      NotionEventType = "database.undeleted"
+	NotionDatabaseSchemaUpdated  NotionEventType = "database.schema_updated"
+	NotionCommentCreated         NotionEventType = "comment.created"
+	NotionCommentUpdated         NotionEventType = "comment.updated"
+	NotionCommentDeleted         NotionEventType = "comment.deleted"
+)
+
+var (
+	ErrUnknownNotionEventType = errors.New("unknown notion webhook event type")
+	ErrInvalidNotionEvent     = errors.New("invalid notion webhook event")
+)
+
+// Topic returns the event bus topic typed events of this type are published on,
+// e.g. "notion.page.created"
+func (t NotionEventType) Topic() string {
+	return "notion." + string(t)
+}
+
+// NotionEntityRef references a Notion object (page, database, block, comment, user, ...)
+type NotionEntityRef struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// NotionSchemaChange describes one property changed by a database.schema_updated event
+type NotionSchemaChange struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Action string `json:"action"` // created, updated or deleted
+}
+
+// NotionEvent holds the fields shared by every Notion webhook event
+type NotionEvent struct {
+	ID             string            `json:"id"`
+	Type           NotionEventType   `json:"type"`
+	Timestamp      time.Time         `json:"timestamp"`
+	WorkspaceID    string            `json:"workspace_id"`
+	WorkspaceName  string            `json:"workspace_name"`
+	SubscriptionID string            `json:"subscription_id"`
+	IntegrationID  string            `json:"integration_id"`
+	Authors        []NotionEntityRef `json:"authors"`
+	AccessibleBy   []NotionEntityRef `json:"accessible_by,omitempty"`
+	AttemptNumber  int               `json:"attempt_number"`
+	Entity         NotionEntityRef   `json:"entity"`
+}
+
+// NotionEventMeta returns the shared event fields
+func (e NotionEvent) NotionEventMeta() NotionEvent {
+	return e
+}
+
+// EventTopic returns the event bus topic for the event type
+func (e NotionEvent) EventTopic() string {
+	return e.Type.Topic()
+}
+
+// NotionWebhookEvent is implemented by all typed Notion webhook events
+type NotionWebhookEvent interface {
+	NotionEventMeta() NotionEvent
+	EventTopic() string
+}
+
+// NotionPageEvent covers page.created, page.moved, page.deleted, page.undeleted,
+// page.locked and page.unlocked
+type NotionPageEvent struct {
+	NotionEvent
+	Parent NotionEntityRef `json:"parent"`
+}
+
+// PageID returns the ID of the affected page
+func (e NotionPageEvent) PageID() string {
+	return e.Entity.ID
+}
+
+// NotionPagePropertiesUpdatedEvent is sent when page properties change.
+// UpdatedProperties holds property IDs, not names.
+type NotionPagePropertiesUpdatedEvent struct {
+	NotionEvent
+	Parent            NotionEntityRef `json:"parent"`
+	UpdatedProperties []string        `json:"updated_properties"`
+}
+
+// PageID returns the ID of the affected page
+func (e NotionPagePropertiesUpdatedEvent) PageID() string {
+	return e.Entity.ID
+}
+
+// NotionPageContentUpdatedEvent is sent when blocks inside a page change
+type NotionPageContentUpdatedEvent struct {
+	NotionEvent
+	Parent        NotionEntityRef   `json:"parent"`
+	UpdatedBlocks []NotionEntityRef `json:"updated_blocks"`
+}
+
+// PageID returns the ID of the affected page
+func (e NotionPageContentUpdatedEvent) PageID() string {
+	return e.Entity.ID
+}
+
+// NotionDatabaseEvent covers database.created, database.moved, database.deleted
+// and database.undeleted
+type NotionDatabaseEvent struct {
+	NotionEvent
+	Parent NotionEntityRef `json:"parent"`
+}
+
+// DatabaseID returns the ID of the affected database
+func (e NotionDatabaseEvent) DatabaseID() string {
+	return e.Entity.ID
+}
+
+// NotionDatabaseContentUpdatedEvent is sent when rows of a database change
+type NotionDatabaseContentUpdatedEvent struct {
+	NotionEvent
+	Parent        NotionEntityRef   `json:"parent"`
+	UpdatedBlocks []NotionEntityRef `json:"updated_blocks"`
+}
+
+// DatabaseID returns the ID of the affected database
+func (e NotionDatabaseContentUpdatedEvent) DatabaseID() string {
+	return e.Entity.ID
+}
+
+// NotionDatabaseSchemaUpdatedEvent is sent when database properties are added, changed or removed
+type NotionDatabaseSchemaUpdatedEvent struct {
+	NotionEvent
+	Parent            NotionEntityRef      `json:"parent"`
+	UpdatedProperties []NotionSchemaChange `json:"updated_properties"`
+}
+
+// DatabaseID returns the ID of the affected database
+func (e NotionDatabaseSchemaUpdatedEvent) DatabaseID() string {
+	return e.Entity.ID
+}
+
+// NotionCommentEvent covers comment.created, comment.updated and comment.deleted
+type NotionCommentEvent struct {
+	NotionEvent
+	PageID string          `json:"page_id"`
+	Parent NotionEntityRef `json:"parent"`
+}
+
+// CommentID returns the ID of the affected comment
+func (e NotionCommentEvent) CommentID() string {
+	return e.Entity.ID
+}
+
+// notionEnvelope is the wire format of a Notion webhook event
+type notionEnvelope struct {
+	NotionEvent
+	Data json.RawMessage `json:"data"`
+}
+
+// ParseNotionWebhookEvent decodes a raw Notion webhook payload into its typed event.
+// It returns ErrUnknownNotionEventType for event types this service does not handle.
+func ParseNotionWebhookEvent(payload []byte) (NotionWebhookEvent, error) {
+	var envelope notionEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNotionEvent, err)
+	}
+	if envelope.ID == "" || envelope.Type == "" {
+		return nil, fmt.Errorf("%w: missing id or type", ErrInvalidNotionEvent)
+	}
+
+	switch envelope.Type {
+	case NotionPageCreated, NotionPageMoved, NotionPageDeleted, NotionPageUndeleted, NotionPageLocked, NotionPageUnlocked:
+		return decodeNotionData(envelope, NotionPageEvent{NotionEvent: envelope.NotionEvent})
+	case NotionPagePropertiesUpdated:
+		return decodeNotionData(envelope, NotionPagePropertiesUpdatedEvent{NotionEvent: envelope.NotionEvent})
+	case NotionPageContentUpdated:
+		return decodeNotionData(envelope, NotionPageContentUpdatedEvent{NotionEvent: envelope.NotionEvent})
+	case NotionDatabaseCreated, NotionDatabaseMoved, NotionDatabaseDeleted, NotionDatabaseUndeleted:
+		return decodeNotionData(envelope, NotionDatabaseEvent{NotionEvent: envelope.NotionEvent})
+	case NotionDatabaseContentUpdated:
+		return decodeNotionData(envelope, NotionDatabaseContentUpdatedEvent{NotionEvent: envelope.NotionEvent})
+	case NotionDatabaseSchemaUpdated:
+		return decodeNotionData(envelope, NotionDatabaseSchemaUpdatedEvent{NotionEvent: envelope.NotionEvent})
+	case NotionCommentCreated, NotionCommentUpdated, NotionCommentDeleted:
+		return decodeNotionData(envelope, NotionCommentEvent{NotionEvent: envelope.NotionEvent})
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNotionEventType, envelope.Type)
+	}
+}
+
+// decodeNotionData decodes the type-specific "data" object into event
+func decodeNotionData[T NotionWebhookEvent](envelope notionEnvelope, event T) (NotionWebhookEvent, error) {
+	if len(envelope.Data) == 0 || string(envelope.Data) == "null" {
+		return event, nil
+	}
+	if err := json.Unmarshal(envelope.Data, &event); err != nil {
+		return nil, fmt.Errorf("%w: %s data: %v", ErrInvalidNotionEvent, envelope.Type, err)
+	}
+	return event, nil
+}
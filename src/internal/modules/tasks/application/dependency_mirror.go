@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/modules/tasks/domain"
+)
+
+// TaskLister is the subset of the tasks repository used to build a project's dependency graph
+type TaskLister interface {
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*domain.Task, error)
+}
+
+// DependencyMirror keeps task dependencies in line with the Notion relation named
+// by a project's property mapping
+type DependencyMirror struct {
+	tasks        TaskLister
+	dependencies domain.DependencyRepository
+	clock        shared.Clock
+	txMgr        shared.TransactionManager
+}
+
+// NewDependencyMirror creates a new DependencyMirror
+func NewDependencyMirror(
+	tasks TaskLister,
+	dependencies domain.DependencyRepository,
+	clock shared.Clock,
+	txMgr shared.TransactionManager,
+) *DependencyMirror {
+	return &DependencyMirror{
+		tasks:        tasks,
+		dependencies: dependencies,
+		clock:        clock,
+		txMgr:        txMgr,
+	}
+}
+
+// Pull makes every task of the project depend on the tasks of the pages in its
+// dependency relation, as stored by the last sync. Links that already exist keep
+// their type and lag; new ones are finish-to-start without lag. Notion allows
+// relations that form a cycle, so a link that would close one is left out. Pulling
+// a project whose mapping has no dependency relation does nothing.
+func (m *DependencyMirror) Pull(ctx context.Context, project *projectsDomain.Project) error {
+	if project.PropertyMapping.Dependency == "" {
+		return nil
+	}
+
+	return m.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := m.dependencies.LockProject(ctx, project.ID); err != nil {
+			return err
+		}
+
+		tasks, err := m.tasks.FindByProjectID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		dependencies, err := m.dependencies.FindByProjectID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		graph := domain.NewDependencyGraph(tasks, dependencies)
+
+		byPage := make(map[string]*domain.Task, len(tasks))
+		for _, task := range tasks {
+			byPage[task.NotionPageID] = task
+		}
+
+		// Links are removed from every task before any is added, so relations that
+		// changed direction in Notion are not taken for cycles
+		for _, keepNew := range []bool{false, true} {
+			for _, task := range tasks {
+				if task.Archived {
+					continue
+				}
+				if err := m.pullTask(ctx, graph, byPage, task, keepNew); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// pullTask replaces the predecessors of a task with the tasks of its related pages.
+// Unless keepNew is set, only links that exist already are kept.
+func (m *DependencyMirror) pullTask(ctx context.Context, graph *domain.DependencyGraph, byPage map[string]*domain.Task, task *domain.Task, keepNew bool) error {
+	current := graph.Predecessors(task.ID)
+	existing := make(map[uuid.UUID]domain.TaskDependency, len(current))
+	for _, dependency := range current {
+		existing[dependency.PredecessorID] = dependency
+	}
+
+	var wanted []domain.TaskDependency
+	linked := make(map[uuid.UUID]bool)
+	for _, pageID := range task.PredecessorPageIDs {
+		predecessor, exists := byPage[pageID]
+		if !exists || predecessor.ID == task.ID || linked[predecessor.ID] {
+			continue
+		}
+		linked[predecessor.ID] = true
+
+		if dependency, exists := existing[predecessor.ID]; exists {
+			wanted = append(wanted, dependency)
+			continue
+		}
+		if !keepNew {
+			continue
+		}
+		dependency, err := domain.NewTaskDependency(predecessor, task, domain.FinishToStart, 0, m.clock)
+		if err != nil {
+			return err
+		}
+		wanted = append(wanted, dependency)
+	}
+
+	if samePredecessors(current, wanted) {
+		return nil
+	}
+
+	for {
+		err := graph.ReplacePredecessors(task.ID, wanted)
+		var cycle *domain.DependencyCycleError
+		if !errors.As(err, &cycle) {
+			if err != nil {
+				return err
+			}
+			break
+		}
+
+		// The predecessor that closes the cycle comes right before the task
+		closing := cycle.Path[len(cycle.Path)-2].ID
+		wanted = slices.DeleteFunc(wanted, func(dependency domain.TaskDependency) bool {
+			return dependency.PredecessorID == closing
+		})
+	}
+
+	if samePredecessors(current, wanted) {
+		return nil
+	}
+	return m.dependencies.ReplacePredecessors(ctx, task.ID, wanted)
+}
+
+// samePredecessors reports whether two lists of dependencies link the same predecessors
+func samePredecessors(a, b []domain.TaskDependency) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	predecessors := make(map[uuid.UUID]bool, len(a))
+	for _, dependency := range a {
+		predecessors[dependency.PredecessorID] = true
+	}
+	for _, dependency := range b {
+		if !predecessors[dependency.PredecessorID] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package application_test
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/modules/tasks/domain"
+)
+
+// mockDependencyRepository keeps dependencies in memory, by successor
+type mockDependencyRepository struct {
+	dependencies map[uuid.UUID][]domain.TaskDependency
+	locked       []uuid.UUID
+	replaced     int
+}
+
+func newMockDependencyRepository() *mockDependencyRepository {
+	return &mockDependencyRepository{dependencies: make(map[uuid.UUID][]domain.TaskDependency)}
+}
+
+func (m *mockDependencyRepository) LockProject(ctx context.Context, projectID uuid.UUID) error {
+	m.locked = append(m.locked, projectID)
+	return nil
+}
+
+func (m *mockDependencyRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]domain.TaskDependency, error) {
+	var dependencies []domain.TaskDependency
+	for _, links := range m.dependencies {
+		for _, dependency := range links {
+			if dependency.ProjectID == projectID {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+	return dependencies, nil
+}
+
+func (m *mockDependencyRepository) FindBySuccessorID(ctx context.Context, successorID uuid.UUID) ([]domain.TaskDependency, error) {
+	return slices.Clone(m.dependencies[successorID]), nil
+}
+
+func (m *mockDependencyRepository) ReplacePredecessors(ctx context.Context, successorID uuid.UUID, dependencies []domain.TaskDependency) error {
+	m.dependencies[successorID] = slices.Clone(dependencies)
+	m.replaced++
+	return nil
+}
+
+type mockDependencyPuller struct {
+	pulls int
+}
+
+func (m *mockDependencyPuller) Pull(ctx context.Context, project *projectsDomain.Project) error {
+	m.pulls++
+	return nil
+}
+
+var _ = Describe("DependencyMirror", func() {
+	var (
+		ctx          context.Context
+		tasks        *mockTaskRepository
+		dependencies *mockDependencyRepository
+		clock        *mockClock
+		project      *projectsDomain.Project
+		mirror       *application.DependencyMirror
+	)
+
+	// store stores the task of a page whose dependency relation lists the given pages
+	store := func(pageID string, predecessorPageIDs ...string) domain.Task {
+		task, err := domain.NewTask(project.ID, domain.NotionSnapshot{PageID: pageID, Title: pageID, Predecessors: predecessorPageIDs}, clock)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.Upsert(ctx, &task)).To(Succeed())
+		return task
+	}
+
+	// predecessors returns the page IDs of a task's predecessors
+	predecessors := func(task domain.Task) []string {
+		var pageIDs []string
+		for _, dependency := range dependencies.dependencies[task.ID] {
+			predecessor, err := tasks.FindByID(ctx, dependency.PredecessorID)
+			Expect(err).ToNot(HaveOccurred())
+			pageIDs = append(pageIDs, predecessor.NotionPageID)
+		}
+		return pageIDs
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tasks = newMockTaskRepository()
+		dependencies = newMockDependencyRepository()
+		clock = &mockClock{now: time.Date(2025, 10, 26, 9, 0, 0, 0, time.UTC)}
+		project = &projectsDomain.Project{
+			ID:              uuid.New(),
+			PropertyMapping: projectsDomain.PropertyMapping{Dependency: "Blocked by"},
+		}
+		mirror = application.NewDependencyMirror(tasks, dependencies, clock, shared.NewNoopTransactionManager())
+	})
+
+	It("makes tasks depend on the tasks of their related pages", func() {
+		store("design")
+		build := store("build", "design", "unknown")
+		release := store("release", "build", "design")
+
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		Expect(dependencies.locked).To(Equal([]uuid.UUID{project.ID}))
+		Expect(predecessors(build)).To(Equal([]string{"design"}))
+		Expect(predecessors(release)).To(Equal([]string{"build", "design"}))
+		for _, dependency := range dependencies.dependencies[release.ID] {
+			Expect(dependency.Type).To(Equal(domain.FinishToStart))
+			Expect(dependency.LagDays).To(BeZero())
+			Expect(dependency.ProjectID).To(Equal(project.ID))
+		}
+	})
+
+	It("keeps the type and lag of existing links and removes links gone from Notion", func() {
+		design := store("design")
+		build := store("build")
+		release := store("release", "build")
+		dependencies.dependencies[release.ID] = []domain.TaskDependency{
+			{ID: uuid.New(), ProjectID: project.ID, PredecessorID: build.ID, SuccessorID: release.ID, Type: domain.StartToStart, LagDays: 3},
+			{ID: uuid.New(), ProjectID: project.ID, PredecessorID: design.ID, SuccessorID: release.ID, Type: domain.FinishToStart},
+		}
+		kept := dependencies.dependencies[release.ID][0]
+
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		Expect(dependencies.dependencies[release.ID]).To(Equal([]domain.TaskDependency{kept}))
+	})
+
+	It("leaves dependencies that mirror Notion untouched", func() {
+		store("design")
+		store("build", "design")
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+		dependencies.replaced = 0
+
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		Expect(dependencies.replaced).To(BeZero())
+	})
+
+	It("follows a relation that changed direction", func() {
+		design := store("design")
+		build := store("build", "design")
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		design = store("design", "build")
+		build = store("build")
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		Expect(predecessors(build)).To(BeEmpty())
+		Expect(predecessors(design)).To(Equal([]string{"build"}))
+	})
+
+	It("leaves out links that would close a cycle", func() {
+		design := store("design", "release")
+		build := store("build", "design")
+		release := store("release", "build")
+
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		links := len(predecessors(design)) + len(predecessors(build)) + len(predecessors(release))
+		Expect(links).To(Equal(2))
+	})
+
+	It("skips archived tasks", func() {
+		store("design")
+		build := store("build", "design")
+		build.Archive(clock)
+		Expect(tasks.Upsert(ctx, &build)).To(Succeed())
+
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		Expect(predecessors(build)).To(BeEmpty())
+	})
+
+	It("does nothing when the mapping has no dependency relation", func() {
+		store("design")
+		store("build", "design")
+		project.PropertyMapping = projectsDomain.PropertyMapping{}
+
+		Expect(mirror.Pull(ctx, project)).To(Succeed())
+
+		Expect(dependencies.locked).To(BeEmpty())
+		Expect(dependencies.dependencies).To(BeEmpty())
+	})
+})
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/tasks/domain"
+)
+
+// GetTaskTreeRequest identifies a task of the user
+type GetTaskTreeRequest struct {
+	UserID uuid.UUID
+	TaskID uuid.UUID
+}
+
+// GetTaskTreeResponse is a task with its descendants and the path from the root of its hierarchy
+type GetTaskTreeResponse struct {
+	Tree      *domain.TaskTree
+	Ancestors []*domain.Task // Parent first
+}
+
+// GetTaskTreeUseCase reads a task's part of the hierarchy
+type GetTaskTreeUseCase struct {
+	tasks    domain.Repository
+	projects ProjectLookup
+}
+
+// NewGetTaskTreeUseCase creates a new GetTaskTreeUseCase
+func NewGetTaskTreeUseCase(tasks domain.Repository, projects ProjectLookup) *GetTaskTreeUseCase {
+	return &GetTaskTreeUseCase{
+		tasks:    tasks,
+		projects: projects,
+	}
+}
+
+// Execute returns a task of the user with all its descendants. Tasks of other
+// users' projects and of deleted projects are reported as domain.ErrTaskNotFound.
+func (uc *GetTaskTreeUseCase) Execute(ctx context.Context, req GetTaskTreeRequest) (GetTaskTreeResponse, error) {
+	subtree, err := uc.tasks.FindSubtree(ctx, req.TaskID)
+	if err != nil {
+		return GetTaskTreeResponse{}, err
+	}
+
+	project, err := uc.projects.FindByID(ctx, subtree[0].ProjectID)
+	if err != nil && !errors.Is(err, projectsDomain.ErrProjectNotFound) {
+		return GetTaskTreeResponse{}, err
+	}
+	if err != nil || project.UserID != req.UserID {
+		return GetTaskTreeResponse{}, domain.ErrTaskNotFound
+	}
+
+	tree, err := domain.NewTaskTree(req.TaskID, subtree)
+	if err != nil {
+		return GetTaskTreeResponse{}, err
+	}
+
+	ancestors, err := uc.tasks.FindAncestors(ctx, req.TaskID)
+	if err != nil {
+		return GetTaskTreeResponse{}, err
+	}
+
+	return GetTaskTreeResponse{Tree: tree, Ancestors: ancestors}, nil
+}
@@ -0,0 +1,72 @@
+package application_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/modules/tasks/domain"
+)
+
+var _ = Describe("GetTaskTreeUseCase", func() {
+	var (
+		ctx      context.Context
+		tasks    *mockTaskRepository
+		clock    *mockClock
+		project  *projectsDomain.Project
+		projects *mockProjectLookup
+		useCase  *application.GetTaskTreeUseCase
+	)
+
+	store := func(pageID, parentPageID string) domain.Task {
+		task, err := domain.NewTask(project.ID, domain.NotionSnapshot{PageID: pageID, ParentPageID: parentPageID}, clock)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.Upsert(ctx, &task)).To(Succeed())
+		return task
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tasks = newMockTaskRepository()
+		clock = &mockClock{now: time.Date(2025, 10, 24, 9, 0, 0, 0, time.UTC)}
+		project = &projectsDomain.Project{ID: uuid.New(), UserID: uuid.New()}
+		projects = &mockProjectLookup{projects: map[uuid.UUID]*projectsDomain.Project{project.ID: project}}
+		useCase = application.NewGetTaskTreeUseCase(tasks, projects)
+	})
+
+	It("returns a task with its descendants and ancestors", func() {
+		root := store("root", "")
+		// Sub-items stored before their parent are linked once it arrives
+		store("grandchild", "child")
+		child := store("child", "root")
+
+		resp, err := useCase.Execute(ctx, application.GetTaskTreeRequest{UserID: project.UserID, TaskID: child.ID})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Tree.Task.NotionPageID).To(Equal("child"))
+		Expect(resp.Tree.Children).To(HaveLen(1))
+		Expect(resp.Tree.Children[0].Task.NotionPageID).To(Equal("grandchild"))
+		Expect(resp.Tree.Size()).To(Equal(2))
+		Expect(resp.Ancestors).To(HaveLen(1))
+		Expect(resp.Ancestors[0].ID).To(Equal(root.ID))
+	})
+
+	It("hides tasks of other users' projects", func() {
+		task := store("root", "")
+
+		_, err := useCase.Execute(ctx, application.GetTaskTreeRequest{UserID: uuid.New(), TaskID: task.ID})
+
+		Expect(err).To(MatchError(domain.ErrTaskNotFound))
+	})
+
+	It("returns ErrTaskNotFound for unknown tasks", func() {
+		_, err := useCase.Execute(ctx, application.GetTaskTreeRequest{UserID: project.UserID, TaskID: uuid.New()})
+
+		Expect(err).To(MatchError(domain.ErrTaskNotFound))
+	})
+})
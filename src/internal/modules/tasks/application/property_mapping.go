@@ -0,0 +1,176 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/pkg/notion"
+)
+
+// DatabaseSchema describes the properties of a Notion database, for checking a
+// project's property mapping against it
+func DatabaseSchema(database notion.Database) projectsDomain.Schema {
+	schema := make(projectsDomain.Schema, len(database.Properties))
+	for name, property := range database.Properties {
+		described := projectsDomain.SchemaProperty{Type: property.Type}
+		if property.Relation != nil {
+			described.RelatedDatabaseID = property.Relation.DatabaseID
+		}
+		schema[name] = described
+	}
+	return schema
+}
+
+// TaskFieldUpdate lists task fields to write back to Notion. Nil fields are left
+// unchanged; empty values clear their property.
+type TaskFieldUpdate struct {
+	Status       *string
+	Priority     *string
+	Schedule     *Schedule
+	Assignees    []string // Notion user IDs; an empty, non-nil slice clears the assignees
+	ParentPageID *string
+	Predecessors []string // Page IDs; an empty, non-nil slice clears the predecessors
+}
+
+// Schedule is the start and due date of a task; nil dates clear their property
+type Schedule struct {
+	Start *time.Time
+	Due   *time.Time
+}
+
+// PropertyUpdates translates a task field update into the Notion property values
+// of the project's mapping, ready for notion.UpdatePageRequest. The schema decides
+// how a status is written, since it may be mapped to a status or a select property.
+// Updating a field the mapping leaves out returns projectsDomain.ErrUnmappedField.
+func PropertyUpdates(mapping projectsDomain.PropertyMapping, schema projectsDomain.Schema, update TaskFieldUpdate) (map[string]notion.PropertyValue, error) {
+	properties := make(map[string]notion.PropertyValue)
+
+	if update.Status != nil {
+		if mapping.Status == "" {
+			return nil, unmapped("status")
+		}
+		value := notion.PropertyValue{Type: schema[mapping.Status].Type}
+		if value.Type == "" {
+			return nil, fmt.Errorf("%w: property %q not found", projectsDomain.ErrInvalidPropertyMapping, mapping.Status)
+		}
+		if *update.Status != "" {
+			option := &notion.SelectOption{Name: *update.Status}
+			if value.Type == "select" {
+				value.Select = option
+			} else {
+				value.Status = option
+			}
+		}
+		properties[mapping.Status] = value
+	}
+
+	if update.Priority != nil {
+		if mapping.Priority == "" {
+			return nil, unmapped("priority")
+		}
+		value := notion.PropertyValue{Type: "select"}
+		if *update.Priority != "" {
+			value.Select = &notion.SelectOption{Name: *update.Priority}
+		}
+		properties[mapping.Priority] = value
+	}
+
+	if update.Schedule != nil {
+		if err := scheduleUpdates(mapping, *update.Schedule, properties); err != nil {
+			return nil, err
+		}
+	}
+
+	if update.Assignees != nil {
+		if mapping.Assignee == "" {
+			return nil, unmapped("assignee")
+		}
+		value := notion.PropertyValue{Type: "people"}
+		for _, id := range update.Assignees {
+			value.People = append(value.People, notion.User{Object: "user", ID: id})
+		}
+		properties[mapping.Assignee] = value
+	}
+
+	if update.ParentPageID != nil {
+		if mapping.Parent == "" {
+			return nil, unmapped("parent")
+		}
+		value := notion.PropertyValue{Type: "relation"}
+		if *update.ParentPageID != "" {
+			value.Relation = []notion.Relation{{ID: *update.ParentPageID}}
+		}
+		properties[mapping.Parent] = value
+	}
+
+	if update.Predecessors != nil {
+		if mapping.Dependency == "" {
+			return nil, unmapped("dependency")
+		}
+		value := notion.PropertyValue{Type: "relation"}
+		for _, id := range update.Predecessors {
+			value.Relation = append(value.Relation, notion.Relation{ID: id})
+		}
+		properties[mapping.Dependency] = value
+	}
+
+	return properties, nil
+}
+
+// scheduleUpdates adds the date properties of a schedule to properties. When start
+// and end share a property, the schedule is written as its range.
+func scheduleUpdates(mapping projectsDomain.PropertyMapping, schedule Schedule, properties map[string]notion.PropertyValue) error {
+	if mapping.Start != "" && mapping.Start == mapping.End {
+		value := notion.PropertyValue{Type: "date"}
+		start, due := schedule.Start, schedule.Due
+		if start == nil {
+			start = due
+		}
+		if start != nil {
+			value.Date = &notion.DateValue{Start: formatNotionDate(*start)}
+			if due != nil && !due.Equal(*start) {
+				end := formatNotionDate(*due)
+				value.Date.End = &end
+			}
+		}
+		properties[mapping.Start] = value
+		return nil
+	}
+
+	dates := []struct {
+		field    string
+		property string
+		date     *time.Time
+	}{
+		{"start", mapping.Start, schedule.Start},
+		{"end", mapping.End, schedule.Due},
+	}
+	for _, date := range dates {
+		if date.property == "" {
+			if date.date != nil {
+				return unmapped(date.field)
+			}
+			continue
+		}
+
+		value := notion.PropertyValue{Type: "date"}
+		if date.date != nil {
+			value.Date = &notion.DateValue{Start: formatNotionDate(*date.date)}
+		}
+		properties[date.property] = value
+	}
+	return nil
+}
+
+// formatNotionDate formats midnight as a date without a time, like Notion does
+func formatNotionDate(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339)
+}
+
+func unmapped(field string) error {
+	return fmt.Errorf("%w: %s", projectsDomain.ErrUnmappedField, field)
+}
@@ -0,0 +1,165 @@
+package application_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/pkg/notion"
+)
+
+var _ = Describe("PropertyUpdates", func() {
+	var (
+		mapping projectsDomain.PropertyMapping
+		schema  projectsDomain.Schema
+	)
+
+	BeforeEach(func() {
+		mapping = projectsDomain.PropertyMapping{
+			Start:    "Timeline",
+			End:      "Timeline",
+			Status:   "Stage",
+			Priority: "Priority",
+			Assignee: "Owner",
+			Parent:   "Parent item",
+		}
+		schema = projectsDomain.Schema{
+			"Timeline":    {Type: "date"},
+			"Deadline":    {Type: "date"},
+			"Stage":       {Type: "status"},
+			"Priority":    {Type: "select"},
+			"Owner":       {Type: "people"},
+			"Parent item": {Type: "relation"},
+		}
+	})
+
+	// encoded returns the JSON Notion receives for the updates
+	encoded := func(properties map[string]notion.PropertyValue) string {
+		data, err := json.Marshal(notion.UpdatePageRequest{Properties: properties})
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("writes task fields to their mapped properties", func() {
+		status, priority, parent := "Done", "High", "page_0"
+		start := time.Date(2025, 10, 21, 0, 0, 0, 0, time.UTC)
+		due := time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)
+
+		properties, err := application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{
+			Status:       &status,
+			Priority:     &priority,
+			Schedule:     &application.Schedule{Start: &start, Due: &due},
+			Assignees:    []string{"user_1"},
+			ParentPageID: &parent,
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encoded(properties)).To(MatchJSON(`{"properties": {
+			"Stage": {"type": "status", "status": {"name": "Done"}},
+			"Priority": {"type": "select", "select": {"name": "High"}},
+			"Timeline": {"type": "date", "date": {"start": "2025-10-21", "end": "2025-10-24", "time_zone": null}},
+			"Owner": {"type": "people", "people": [{"object": "user", "id": "user_1", "type": "", "name": "", "avatar_url": null}]},
+			"Parent item": {"type": "relation", "relation": [{"id": "page_0"}]}
+		}}`))
+	})
+
+	It("leaves fields without updates unchanged", func() {
+		properties, err := application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(properties).To(BeEmpty())
+	})
+
+	It("writes a status mapped to a select property as a select", func() {
+		schema["Stage"] = projectsDomain.SchemaProperty{Type: "select"}
+		status := "Review"
+
+		properties, err := application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{Status: &status})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(properties["Stage"].Select.Name).To(Equal("Review"))
+		Expect(properties["Stage"].Status).To(BeNil())
+	})
+
+	It("writes start and due dates to separate properties", func() {
+		mapping.End = "Deadline"
+		due := time.Date(2025, 10, 24, 17, 30, 0, 0, time.UTC)
+
+		properties, err := application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{
+			Schedule: &application.Schedule{Due: &due},
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(properties["Timeline"].Date).To(BeNil())
+		Expect(properties["Deadline"].Date.Start).To(Equal("2025-10-24T17:30:00Z"))
+	})
+
+	It("clears properties with empty values", func() {
+		empty := ""
+
+		properties, err := application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{
+			Status:       &empty,
+			Schedule:     &application.Schedule{},
+			Assignees:    []string{},
+			ParentPageID: &empty,
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encoded(properties)).To(MatchJSON(`{"properties": {
+			"Stage": {"type": "status", "status": null},
+			"Timeline": {"type": "date", "date": null},
+			"Owner": {"type": "people", "people": []},
+			"Parent item": {"type": "relation", "relation": []}
+		}}`))
+	})
+
+	It("writes predecessors to the dependency relation", func() {
+		mapping.Dependency = "Blocked by"
+
+		properties, err := application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{Predecessors: []string{"page_1", "page_2"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encoded(properties)).To(MatchJSON(`{"properties": {
+			"Blocked by": {"type": "relation", "relation": [{"id": "page_1"}, {"id": "page_2"}]}
+		}}`))
+
+		properties, err = application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{Predecessors: []string{}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(encoded(properties)).To(MatchJSON(`{"properties": {"Blocked by": {"type": "relation", "relation": []}}}`))
+
+		mapping.Dependency = ""
+		_, err = application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{Predecessors: []string{"page_1"}})
+		Expect(err).To(MatchError(projectsDomain.ErrUnmappedField))
+	})
+
+	It("rejects updates of unmapped fields", func() {
+		mapping.Priority = ""
+		priority := "High"
+
+		_, err := application.PropertyUpdates(mapping, schema, application.TaskFieldUpdate{Priority: &priority})
+
+		Expect(err).To(MatchError(projectsDomain.ErrUnmappedField))
+		Expect(err).To(MatchError(ContainSubstring("priority")))
+	})
+})
+
+var _ = Describe("DatabaseSchema", func() {
+	It("describes property types and relation targets", func() {
+		schema := application.DatabaseSchema(notion.Database{Properties: map[string]notion.Property{
+			"Name": {Type: "title"},
+			"Parent item": {Type: "relation", Relation: &struct {
+				DatabaseID         string `json:"database_id"`
+				SyncedPropertyName string `json:"synced_property_name,omitempty"`
+				SyncedPropertyID   string `json:"synced_property_id,omitempty"`
+			}{DatabaseID: "database_1"}},
+		}})
+
+		Expect(schema).To(Equal(projectsDomain.Schema{
+			"Name":        {Type: "title"},
+			"Parent item": {Type: "relation", RelatedDatabaseID: "database_1"},
+		}))
+	})
+})
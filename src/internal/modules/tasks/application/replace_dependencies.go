@@ -0,0 +1,264 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+)
+
+// PageUpdater writes properties of a single Notion page (see notion.Pages)
+type PageUpdater interface {
+	Update(ctx context.Context, accessToken, pageID string, request *notion.UpdatePageRequest) (*notion.Page, error)
+}
+
+// PredecessorLink describes a dependency on a predecessor
+type PredecessorLink struct {
+	TaskID  uuid.UUID
+	Type    domain.DependencyType
+	LagDays int
+}
+
+// ReplaceDependenciesRequest lists all predecessors of a task of the user
+type ReplaceDependenciesRequest struct {
+	UserID       uuid.UUID
+	TaskID       uuid.UUID
+	Predecessors []PredecessorLink
+}
+
+// ReplaceDependenciesResponse is a task with its new dependencies
+type ReplaceDependenciesResponse struct {
+	Task         *domain.Task
+	Dependencies []domain.TaskDependency
+	Predecessors map[uuid.UUID]*domain.Task // By ID
+}
+
+// ReplaceDependenciesUseCase replaces the predecessors of a task
+type ReplaceDependenciesUseCase struct {
+	tasks        domain.Repository
+	dependencies domain.DependencyRepository
+	projects     ProjectLookup
+	users        UserLookup
+	pages        PageUpdater
+	clock        shared.Clock
+	txMgr        shared.TransactionManager
+}
+
+// NewReplaceDependenciesUseCase creates a new ReplaceDependenciesUseCase
+func NewReplaceDependenciesUseCase(
+	tasks domain.Repository,
+	dependencies domain.DependencyRepository,
+	projects ProjectLookup,
+	users UserLookup,
+	pages PageUpdater,
+	clock shared.Clock,
+	txMgr shared.TransactionManager,
+) *ReplaceDependenciesUseCase {
+	return &ReplaceDependenciesUseCase{
+		tasks:        tasks,
+		dependencies: dependencies,
+		projects:     projects,
+		users:        users,
+		pages:        pages,
+		clock:        clock,
+		txMgr:        txMgr,
+	}
+}
+
+// Execute replaces all predecessors of a task at once. Links to predecessors the
+// task already has are updated in place. A change that would close a cycle returns
+// a *domain.DependencyCycleError and changes nothing. When the project maps a
+// dependency relation, the predecessors are written to the task's Notion page once
+// they are stored; if that write fails, the previous dependencies are restored
+// and the error is returned. Tasks of other users' projects and of deleted
+// projects are reported as domain.ErrTaskNotFound.
+func (uc *ReplaceDependenciesUseCase) Execute(ctx context.Context, req ReplaceDependenciesRequest) (ReplaceDependenciesResponse, error) {
+	task, err := uc.tasks.FindByID(ctx, req.TaskID)
+	if err != nil {
+		return ReplaceDependenciesResponse{}, err
+	}
+
+	project, err := uc.projects.FindByID(ctx, task.ProjectID)
+	if err != nil && !errors.Is(err, projectsDomain.ErrProjectNotFound) {
+		return ReplaceDependenciesResponse{}, err
+	}
+	if err != nil || project.UserID != req.UserID {
+		return ReplaceDependenciesResponse{}, domain.ErrTaskNotFound
+	}
+
+	var accessToken string
+	if project.PropertyMapping.Dependency != "" {
+		owner, err := uc.users.GetByUUID(ctx, project.UserID)
+		if err != nil {
+			return ReplaceDependenciesResponse{}, fmt.Errorf("failed to load project owner: %w", err)
+		}
+		if !owner.HasValidNotionToken(uc.clock) {
+			return ReplaceDependenciesResponse{}, usersDomain.ErrNotionTokenMissing
+		}
+		accessToken = owner.NotionAccessToken
+	}
+
+	resp := ReplaceDependenciesResponse{Predecessors: make(map[uuid.UUID]*domain.Task, len(req.Predecessors))}
+	var previous []domain.TaskDependency
+	var previousPageIDs []string
+	err = uc.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.dependencies.LockProject(ctx, project.ID); err != nil {
+			return err
+		}
+
+		tasks, err := uc.tasks.FindByProjectID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		dependencies, err := uc.dependencies.FindByProjectID(ctx, project.ID)
+		if err != nil {
+			return err
+		}
+		graph := domain.NewDependencyGraph(tasks, dependencies)
+
+		successor, exists := graph.Task(task.ID)
+		if !exists {
+			return domain.ErrTaskNotFound
+		}
+
+		previous = graph.Predecessors(successor.ID)
+		existing := make(map[uuid.UUID]domain.TaskDependency)
+		for _, dependency := range previous {
+			existing[dependency.PredecessorID] = dependency
+		}
+
+		wanted := make([]domain.TaskDependency, 0, len(req.Predecessors))
+		pageIDs := make([]string, 0, len(req.Predecessors))
+		for _, link := range req.Predecessors {
+			predecessor, exists := graph.Task(link.TaskID)
+			if !exists {
+				return fmt.Errorf("%w: %s", domain.ErrPredecessorNotFound, link.TaskID)
+			}
+
+			dependency, err := domain.NewTaskDependency(predecessor, successor, link.Type, link.LagDays, uc.clock)
+			if err != nil {
+				return err
+			}
+			if current, exists := existing[predecessor.ID]; exists {
+				dependency.ID = current.ID
+				dependency.CreatedAt = current.CreatedAt
+			}
+
+			wanted = append(wanted, dependency)
+			pageIDs = append(pageIDs, predecessor.NotionPageID)
+			resp.Predecessors[predecessor.ID] = predecessor
+		}
+
+		if err := graph.ReplacePredecessors(successor.ID, wanted); err != nil {
+			return err
+		}
+		if err := uc.dependencies.ReplacePredecessors(ctx, successor.ID, wanted); err != nil {
+			return err
+		}
+
+		resp.Task = successor
+		resp.Dependencies = wanted
+		if project.PropertyMapping.Dependency == "" {
+			return nil
+		}
+
+		previousPageIDs = successor.PredecessorPageIDs
+		successor.PredecessorPageIDs = pageIDs
+		successor.UpdatedAt = uc.clock.Now()
+		return uc.tasks.Upsert(ctx, successor)
+	})
+	if err != nil {
+		return ReplaceDependenciesResponse{}, err
+	}
+	if project.PropertyMapping.Dependency == "" {
+		return resp, nil
+	}
+
+	// Notion is written after the commit, so the project lock is not held while
+	// the client waits on its rate limiter or retries
+	if err := uc.writeBack(ctx, accessToken, project, resp.Task); err != nil {
+		if restoreErr := uc.restore(ctx, project.ID, resp.Task.ID, resp.Dependencies, previous, previousPageIDs); restoreErr != nil {
+			return ReplaceDependenciesResponse{}, errors.Join(err, restoreErr)
+		}
+		return ReplaceDependenciesResponse{}, err
+	}
+	return resp, nil
+}
+
+// restore puts back the predecessors a task had before a replacement whose write to
+// Notion failed. Dependencies changed again in the meantime are left alone, since
+// the later change is the one the user saw last.
+func (uc *ReplaceDependenciesUseCase) restore(
+	ctx context.Context,
+	projectID, successorID uuid.UUID,
+	replaced, previous []domain.TaskDependency,
+	previousPageIDs []string,
+) error {
+	err := uc.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.dependencies.LockProject(ctx, projectID); err != nil {
+			return err
+		}
+
+		current, err := uc.dependencies.FindBySuccessorID(ctx, successorID)
+		if err != nil {
+			return err
+		}
+		if !sameDependencies(current, replaced) {
+			return nil
+		}
+		if err := uc.dependencies.ReplacePredecessors(ctx, successorID, previous); err != nil {
+			return err
+		}
+
+		successor, err := uc.tasks.FindByID(ctx, successorID)
+		if err != nil {
+			return err
+		}
+		successor.PredecessorPageIDs = previousPageIDs
+		successor.UpdatedAt = uc.clock.Now()
+		return uc.tasks.Upsert(ctx, successor)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore dependencies: %w", err)
+	}
+	return nil
+}
+
+// sameDependencies reports whether two lists hold the same dependencies, with the
+// same type and lag, in any order
+func sameDependencies(a, b []domain.TaskDependency) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byID := make(map[uuid.UUID]domain.TaskDependency, len(a))
+	for _, dependency := range a {
+		byID[dependency.ID] = dependency
+	}
+	for _, dependency := range b {
+		other, exists := byID[dependency.ID]
+		if !exists || other.PredecessorID != dependency.PredecessorID || other.Type != dependency.Type || other.LagDays != dependency.LagDays {
+			return false
+		}
+	}
+	return true
+}
+
+// writeBack sets the dependency relation of a task's Notion page to its predecessors
+func (uc *ReplaceDependenciesUseCase) writeBack(ctx context.Context, accessToken string, project *projectsDomain.Project, task *domain.Task) error {
+	properties, err := PropertyUpdates(project.PropertyMapping, nil, TaskFieldUpdate{Predecessors: task.PredecessorPageIDs})
+	if err != nil {
+		return err
+	}
+
+	if _, err := uc.pages.Update(ctx, accessToken, task.NotionPageID, &notion.UpdatePageRequest{Properties: properties}); err != nil {
+		return fmt.Errorf("failed to write dependencies to Notion: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/notion/notiontest"
+)
+
+var _ = Describe("ReplaceDependenciesUseCase", func() {
+	var (
+		ctx          context.Context
+		server       *notiontest.Server
+		database     notion.Database
+		users        *mockUserLookup
+		tasks        *mockTaskRepository
+		dependencies *mockDependencyRepository
+		clock        *mockClock
+		project      *projectsDomain.Project
+		useCase      *application.ReplaceDependenciesUseCase
+		design       domain.Task
+		build        domain.Task
+		release      domain.Task
+	)
+
+	// store stores the task of a new page of the project's database
+	store := func(title string) domain.Task {
+		page := server.AddPage(notion.Page{
+			Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+			Properties: map[string]notion.PropertyValue{"Name": {Title: []notion.RichText{notion.NewText(title)}}},
+		})
+		task, err := domain.NewTask(project.ID, domain.NotionSnapshot{PageID: page.ID, Title: title}, clock)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.Upsert(ctx, &task)).To(Succeed())
+		return task
+	}
+
+	replace := func(task domain.Task, links ...application.PredecessorLink) (application.ReplaceDependenciesResponse, error) {
+		return useCase.Execute(ctx, application.ReplaceDependenciesRequest{
+			UserID:       project.UserID,
+			TaskID:       task.ID,
+			Predecessors: links,
+		})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = notiontest.NewServer()
+		DeferCleanup(server.Close)
+		database = server.AddDatabase(notion.Database{Properties: map[string]notion.Property{
+			"Name":       {Type: "title", Title: &struct{}{}},
+			"Blocked by": {Type: "relation"},
+		}})
+
+		owner := usersDomain.User{ID: uuid.New(), NotionAccessToken: notiontest.DefaultToken}
+		users = &mockUserLookup{users: map[uuid.UUID]usersDomain.User{owner.ID: owner}}
+
+		project = &projectsDomain.Project{ID: uuid.New(), UserID: owner.ID, NotionDatabaseID: database.ID}
+		projects := &mockProjectLookup{projects: map[uuid.UUID]*projectsDomain.Project{project.ID: project}}
+
+		tasks = newMockTaskRepository()
+		dependencies = newMockDependencyRepository()
+		clock = &mockClock{now: time.Date(2025, 10, 26, 9, 0, 0, 0, time.UTC)}
+		useCase = application.NewReplaceDependenciesUseCase(
+			tasks,
+			dependencies,
+			projects,
+			users,
+			notion.NewPages(server.ClientOptions()...),
+			clock,
+			shared.NewNoopTransactionManager(),
+		)
+
+		design = store("Design")
+		build = store("Build")
+		release = store("Release")
+	})
+
+	It("replaces the predecessors of a task", func() {
+		_, err := replace(release, application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart})
+		Expect(err).ToNot(HaveOccurred())
+		previous := dependencies.dependencies[release.ID][0]
+
+		clock.now = clock.now.Add(time.Hour)
+		resp, err := replace(release,
+			application.PredecessorLink{TaskID: build.ID, Type: domain.StartToStart, LagDays: -1},
+			application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToFinish, LagDays: 2},
+		)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Task.ID).To(Equal(release.ID))
+		Expect(resp.Dependencies).To(Equal(dependencies.dependencies[release.ID]))
+		Expect(resp.Dependencies).To(HaveLen(2))
+		Expect(resp.Dependencies[0].PredecessorID).To(Equal(build.ID))
+		Expect(resp.Dependencies[0].Type).To(Equal(domain.StartToStart))
+		Expect(resp.Dependencies[0].LagDays).To(Equal(-1))
+		Expect(resp.Predecessors[build.ID].Title).To(Equal("Build"))
+
+		// The link to an existing predecessor is updated in place
+		Expect(resp.Dependencies[1].ID).To(Equal(previous.ID))
+		Expect(resp.Dependencies[1].CreatedAt).To(Equal(previous.CreatedAt))
+		Expect(resp.Dependencies[1].UpdatedAt).To(Equal(clock.now))
+		Expect(resp.Dependencies[1].Type).To(Equal(domain.FinishToFinish))
+	})
+
+	It("clears the predecessors of a task", func() {
+		_, err := replace(release, application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart})
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := replace(release)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.Dependencies).To(BeEmpty())
+		Expect(dependencies.dependencies[release.ID]).To(BeEmpty())
+	})
+
+	It("rejects a change that would close a cycle and keeps the dependencies", func() {
+		_, err := replace(build, application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = replace(release, application.PredecessorLink{TaskID: build.ID, Type: domain.FinishToStart})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = replace(design, application.PredecessorLink{TaskID: release.ID, Type: domain.FinishToStart})
+
+		var cycle *domain.DependencyCycleError
+		Expect(err).To(BeAssignableToTypeOf(cycle))
+		Expect(err).To(MatchError(`dependency cycle: "Design" -> "Build" -> "Release" -> "Design"`))
+		Expect(dependencies.dependencies[design.ID]).To(BeEmpty())
+	})
+
+	It("rejects invalid links", func() {
+		_, err := replace(release, application.PredecessorLink{TaskID: design.ID, Type: "XX"})
+		Expect(err).To(MatchError(domain.ErrInvalidDependencyType))
+
+		_, err = replace(release, application.PredecessorLink{TaskID: release.ID, Type: domain.FinishToStart})
+		Expect(err).To(MatchError(domain.ErrSelfDependency))
+
+		_, err = replace(release,
+			application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart},
+			application.PredecessorLink{TaskID: design.ID, Type: domain.StartToStart},
+		)
+		Expect(err).To(MatchError(domain.ErrDuplicateDependency))
+
+		_, err = replace(release, application.PredecessorLink{TaskID: uuid.New(), Type: domain.FinishToStart})
+		Expect(err).To(MatchError(domain.ErrPredecessorNotFound))
+
+		Expect(dependencies.replaced).To(BeZero())
+	})
+
+	It("rejects predecessors of other projects", func() {
+		other, err := domain.NewTask(uuid.New(), domain.NotionSnapshot{PageID: "other", Title: "Other"}, clock)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.Upsert(ctx, &other)).To(Succeed())
+
+		_, err = replace(release, application.PredecessorLink{TaskID: other.ID, Type: domain.FinishToStart})
+
+		Expect(err).To(MatchError(domain.ErrPredecessorNotFound))
+	})
+
+	It("does not write to Notion when the mapping has no dependency relation", func() {
+		_, err := replace(release, application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart})
+
+		Expect(err).ToNot(HaveOccurred())
+		for _, request := range server.Requests() {
+			Expect(request.Method).ToNot(Equal("PATCH"))
+		}
+	})
+
+	It("writes the predecessors to the mapped dependency relation", func() {
+		project.PropertyMapping = projectsDomain.PropertyMapping{Dependency: "Blocked by"}
+
+		_, err := replace(release,
+			application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart},
+			application.PredecessorLink{TaskID: build.ID, Type: domain.StartToStart, LagDays: 1},
+		)
+
+		Expect(err).ToNot(HaveOccurred())
+		page, _ := server.Page(release.NotionPageID)
+		Expect(page.Properties["Blocked by"].Relation).To(Equal([]notion.Relation{{ID: design.NotionPageID}, {ID: build.NotionPageID}}))
+		stored, err := tasks.FindByID(ctx, release.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored.PredecessorPageIDs).To(Equal([]string{design.NotionPageID, build.NotionPageID}))
+	})
+
+	It("restores the previous predecessors when the write to Notion fails", func() {
+		removed, err := domain.NewTask(project.ID, domain.NotionSnapshot{PageID: "removed-page", Title: "Removed"}, clock)
+		Expect(err).ToNot(HaveOccurred())
+		removed.PredecessorPageIDs = []string{design.NotionPageID}
+		Expect(tasks.Upsert(ctx, &removed)).To(Succeed())
+		_, err = replace(removed, application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart})
+		Expect(err).ToNot(HaveOccurred())
+		previous := dependencies.dependencies[removed.ID]
+		project.PropertyMapping = projectsDomain.PropertyMapping{Dependency: "Blocked by"}
+
+		_, err = replace(removed, application.PredecessorLink{TaskID: build.ID, Type: domain.StartToStart})
+
+		var apiErr *notion.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Code).To(Equal("object_not_found"))
+		Expect(dependencies.dependencies[removed.ID]).To(Equal(previous))
+		stored, err := tasks.FindByID(ctx, removed.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored.PredecessorPageIDs).To(Equal([]string{design.NotionPageID}))
+		// The lock is taken again to restore the dependencies after the failed write
+		Expect(dependencies.locked).To(HaveLen(3))
+	})
+
+	It("fails when the dependency relation is mapped and the owner has not connected Notion", func() {
+		project.PropertyMapping = projectsDomain.PropertyMapping{Dependency: "Blocked by"}
+		users.users[project.UserID] = usersDomain.User{ID: project.UserID}
+
+		_, err := replace(release, application.PredecessorLink{TaskID: design.ID, Type: domain.FinishToStart})
+
+		Expect(err).To(MatchError(usersDomain.ErrNotionTokenMissing))
+		Expect(dependencies.replaced).To(BeZero())
+	})
+
+	It("hides tasks of other users' projects", func() {
+		_, err := useCase.Execute(ctx, application.ReplaceDependenciesRequest{UserID: uuid.New(), TaskID: release.ID})
+
+		Expect(err).To(MatchError(domain.ErrTaskNotFound))
+	})
+
+	It("returns ErrTaskNotFound for unknown tasks", func() {
+		_, err := useCase.Execute(ctx, application.ReplaceDependenciesRequest{UserID: project.UserID, TaskID: uuid.New()})
+
+		Expect(err).To(MatchError(domain.ErrTaskNotFound))
+	})
+})
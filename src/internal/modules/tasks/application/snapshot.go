@@ -0,0 +1,129 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/tasks/domain"
+	"src/internal/pkg/notion"
+)
+
+// NotionSnapshot captures the state of a Notion page that a task mirrors. Task fields
+// are read from the properties named by the project's mapping; unmapped fields are
+// left empty. A mapped property that the page lacks, or that has another type, means
+// the database schema changed under the mapping and is reported as
+// projectsDomain.ErrInvalidPropertyMapping.
+func NotionSnapshot(page notion.Page, mapping projectsDomain.PropertyMapping) (domain.NotionSnapshot, error) {
+	properties, err := json.Marshal(page.Properties)
+	if err != nil {
+		return domain.NotionSnapshot{}, fmt.Errorf("failed to marshal properties of page %s: %w", page.ID, err)
+	}
+
+	snapshot := domain.NotionSnapshot{
+		PageID:       page.ID,
+		Title:        page.Title(),
+		Properties:   properties,
+		Archived:     page.Archived || page.InTrash,
+		CreatedAt:    page.CreatedTime,
+		LastEditedAt: page.LastEditedTime,
+	}
+
+	if err := readMappedProperties(&page, mapping, &snapshot); err != nil {
+		return domain.NotionSnapshot{}, fmt.Errorf("%w: page %s: %w", projectsDomain.ErrInvalidPropertyMapping, page.ID, err)
+	}
+	return snapshot, nil
+}
+
+// readMappedProperties copies the mapped properties of a page into snapshot. Values
+// that cannot be parsed, such as malformed dates, leave their field empty.
+func readMappedProperties(page *notion.Page, mapping projectsDomain.PropertyMapping, snapshot *domain.NotionSnapshot) error {
+	if mapping.Status != "" {
+		value, err := mappedProperty(page, mapping.Status, "status", "select")
+		if err != nil {
+			return err
+		}
+		if value.Type == "select" {
+			snapshot.Status, _ = page.Select(mapping.Status)
+		} else {
+			snapshot.Status, _ = page.Status(mapping.Status)
+		}
+	}
+
+	if mapping.Priority != "" {
+		if _, err := mappedProperty(page, mapping.Priority, "select"); err != nil {
+			return err
+		}
+		snapshot.Priority, _ = page.Select(mapping.Priority)
+	}
+
+	if mapping.Start != "" {
+		if _, err := mappedProperty(page, mapping.Start, "date"); err != nil {
+			return err
+		}
+		if dates, err := page.Date(mapping.Start); err == nil && dates != nil {
+			snapshot.StartDate = &dates.Start
+		}
+	}
+
+	if mapping.End != "" {
+		if _, err := mappedProperty(page, mapping.End, "date"); err != nil {
+			return err
+		}
+		// A single date is both the start and the end of its range
+		if dates, err := page.Date(mapping.End); err == nil && dates != nil {
+			snapshot.DueDate = &dates.Start
+			if dates.End != nil {
+				snapshot.DueDate = dates.End
+			}
+		}
+	}
+
+	if mapping.Assignee != "" {
+		if _, err := mappedProperty(page, mapping.Assignee, "people"); err != nil {
+			return err
+		}
+		people, _ := page.People(mapping.Assignee)
+		for _, person := range people {
+			snapshot.Assignees = append(snapshot.Assignees, person.ID)
+		}
+	}
+
+	if mapping.Parent != "" {
+		if _, err := mappedProperty(page, mapping.Parent, "relation"); err != nil {
+			return err
+		}
+		if parents, _ := page.Relations(mapping.Parent); len(parents) > 0 {
+			snapshot.ParentPageID = parents[0]
+		}
+	}
+
+	if mapping.Dependency != "" {
+		if _, err := mappedProperty(page, mapping.Dependency, "relation"); err != nil {
+			return err
+		}
+		snapshot.Predecessors, _ = page.Relations(mapping.Dependency)
+	}
+
+	return nil
+}
+
+// mappedProperty returns the value of a mapped property if it has one of the types
+// its field accepts
+func mappedProperty(page *notion.Page, name string, types ...string) (notion.PropertyValue, error) {
+	value, err := page.Property(name)
+	if err != nil {
+		return value, err
+	}
+	if !slices.Contains(types, value.Type) {
+		return value, &notion.PropertyTypeError{Property: name, Type: value.Type, Expected: types}
+	}
+	return value, nil
+}
+
+// sameNotionID reports whether two Notion IDs are equal, ignoring dashes
+func sameNotionID(a, b string) bool {
+	return strings.EqualFold(strings.ReplaceAll(a, "-", ""), strings.ReplaceAll(b, "-", ""))
+}
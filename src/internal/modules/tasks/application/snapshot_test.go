@@ -0,0 +1,130 @@
+package application_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/pkg/notion"
+)
+
+var _ = Describe("NotionSnapshot", func() {
+	var (
+		page    notion.Page
+		mapping projectsDomain.PropertyMapping
+	)
+
+	BeforeEach(func() {
+		end := "2025-10-24"
+		page = notion.Page{
+			ID: "page_1",
+			Properties: map[string]notion.PropertyValue{
+				"Name":        {Type: "title", Title: []notion.RichText{notion.NewText("Write docs")}},
+				"Status":      {Type: "status", Status: &notion.SelectOption{Name: "In progress"}},
+				"Priority":    {Type: "select", Select: &notion.SelectOption{Name: "High"}},
+				"Timeline":    {Type: "date", Date: &notion.DateValue{Start: "2025-10-21", End: &end}},
+				"Deadline":    {Type: "date", Date: &notion.DateValue{Start: "2025-10-30"}},
+				"Owner":       {Type: "people", People: []notion.User{{ID: "user_1"}, {ID: "user_2"}}},
+				"Parent item": {Type: "relation", Relation: []notion.Relation{{ID: "page_0"}}},
+				"Blocked by":  {Type: "relation", Relation: []notion.Relation{{ID: "page_2"}, {ID: "page_3"}}},
+			},
+		}
+		mapping = projectsDomain.PropertyMapping{
+			Start:      "Timeline",
+			End:        "Timeline",
+			Status:     "Status",
+			Priority:   "Priority",
+			Assignee:   "Owner",
+			Parent:     "Parent item",
+			Dependency: "Blocked by",
+		}
+	})
+
+	It("reads the task fields from the mapped properties", func() {
+		snapshot, err := application.NotionSnapshot(page, mapping)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.Title).To(Equal("Write docs"))
+		Expect(snapshot.Status).To(Equal("In progress"))
+		Expect(snapshot.Priority).To(Equal("High"))
+		Expect(*snapshot.StartDate).To(Equal(time.Date(2025, 10, 21, 0, 0, 0, 0, time.UTC)))
+		Expect(*snapshot.DueDate).To(Equal(time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)))
+		Expect(snapshot.Assignees).To(Equal([]string{"user_1", "user_2"}))
+		Expect(snapshot.ParentPageID).To(Equal("page_0"))
+		Expect(snapshot.Predecessors).To(Equal([]string{"page_2", "page_3"}))
+	})
+
+	It("uses a single date as both start and due date", func() {
+		page.Properties["Timeline"] = notion.PropertyValue{Type: "date", Date: &notion.DateValue{Start: "2025-10-21"}}
+
+		snapshot, err := application.NotionSnapshot(page, mapping)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.StartDate).To(Equal(snapshot.DueDate))
+	})
+
+	It("reads start and due dates from separate properties", func() {
+		mapping.End = "Deadline"
+
+		snapshot, err := application.NotionSnapshot(page, mapping)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*snapshot.StartDate).To(Equal(time.Date(2025, 10, 21, 0, 0, 0, 0, time.UTC)))
+		Expect(*snapshot.DueDate).To(Equal(time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("reads a status mapped to a select property", func() {
+		page.Properties["Stage"] = notion.PropertyValue{Type: "select", Select: &notion.SelectOption{Name: "Review"}}
+		mapping.Status = "Stage"
+
+		snapshot, err := application.NotionSnapshot(page, mapping)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.Status).To(Equal("Review"))
+	})
+
+	It("leaves unmapped fields empty", func() {
+		snapshot, err := application.NotionSnapshot(page, projectsDomain.PropertyMapping{})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.Title).To(Equal("Write docs"))
+		Expect(snapshot.Status).To(BeEmpty())
+		Expect(snapshot.StartDate).To(BeNil())
+		Expect(snapshot.Assignees).To(BeEmpty())
+		Expect(snapshot.ParentPageID).To(BeEmpty())
+		Expect(snapshot.Predecessors).To(BeEmpty())
+	})
+
+	It("leaves fields of empty properties empty", func() {
+		page.Properties["Timeline"] = notion.PropertyValue{Type: "date"}
+		page.Properties["Status"] = notion.PropertyValue{Type: "status"}
+
+		snapshot, err := application.NotionSnapshot(page, mapping)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.Status).To(BeEmpty())
+		Expect(snapshot.StartDate).To(BeNil())
+		Expect(snapshot.DueDate).To(BeNil())
+	})
+
+	It("reports a mapped property the page lacks as an invalid mapping", func() {
+		delete(page.Properties, "Owner")
+
+		_, err := application.NotionSnapshot(page, mapping)
+
+		Expect(err).To(MatchError(projectsDomain.ErrInvalidPropertyMapping))
+		Expect(err).To(MatchError(notion.ErrPropertyNotFound))
+	})
+
+	It("reports a mapped property of another type as an invalid mapping", func() {
+		page.Properties["Timeline"] = notion.PropertyValue{Type: "rich_text"}
+
+		_, err := application.NotionSnapshot(page, mapping)
+
+		Expect(err).To(MatchError(projectsDomain.ErrInvalidPropertyMapping))
+		Expect(err).To(MatchError(ContainSubstring(`property "Timeline" is of type rich_text`)))
+	})
+})
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+)
+
+// PageSyncOutcome describes what synchronizing a page did to the local task
+type PageSyncOutcome string
+
+const (
+	PageSyncCreated  PageSyncOutcome = "created"
+	PageSyncUpdated  PageSyncOutcome = "updated"
+	PageSyncArchived PageSyncOutcome = "archived"
+	PageSyncStale    PageSyncOutcome = "stale"   // The local task is newer than the fetched page
+	PageSyncIgnored  PageSyncOutcome = "ignored" // The page is not part of the project
+)
+
+// ProjectLookup is the subset of the projects repository used to find a page's project
+type ProjectLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*projectsDomain.Project, error)
+}
+
+// UserLookup is the subset of the users repository used to find a project owner's Notion token
+type UserLookup interface {
+	GetByUUID(ctx context.Context, id uuid.UUID) (usersDomain.User, error)
+}
+
+// PageRetriever fetches a single Notion page (see notion.Pages)
+type PageRetriever interface {
+	Retrieve(ctx context.Context, accessToken, pageID string) (*notion.Page, error)
+}
+
+// DependencyPuller mirrors the dependency relation of a project's stored tasks
+// into task dependencies (see DependencyMirror)
+type DependencyPuller interface {
+	Pull(ctx context.Context, project *projectsDomain.Project) error
+}
+
+// ProjectDegrader marks a project degraded (see projects application.ProjectDegrader)
+type ProjectDegrader interface {
+	Degrade(ctx context.Context, projectID uuid.UUID, cause error) error
+}
+
+// PageSyncService keeps tasks up to date with their Notion pages
+type PageSyncService struct {
+	projects ProjectLookup
+	users    UserLookup
+	pages    PageRetriever
+	tasks    domain.Repository
+	deps     DependencyPuller
+	degrader ProjectDegrader
+	clock    shared.Clock
+}
+
+// NewPageSyncService creates a new PageSyncService
+func NewPageSyncService(
+	projects ProjectLookup,
+	users UserLookup,
+	pages PageRetriever,
+	tasks domain.Repository,
+	deps DependencyPuller,
+	degrader ProjectDegrader,
+	clock shared.Clock,
+) *PageSyncService {
+	return &PageSyncService{
+		projects: projects,
+		users:    users,
+		pages:    pages,
+		tasks:    tasks,
+		deps:     deps,
+		degrader: degrader,
+		clock:    clock,
+	}
+}
+
+// SynchronizePage fetches a page and stores it as a task of the project.
+// Pages that were archived, deleted or moved out of the project's database archive
+// their task. A fetched page older than the stored task is discarded, including one
+// that a concurrent sync overtook while it was fetched (see domain.ErrStaleTask);
+// pages with the same last edited time are applied, because Notion reports that
+// time to the minute and a page edited twice within a minute keeps it. Task
+// dependencies are pulled again when a task is created or its dependency relation
+// changed. A page that no longer fits the project's property mapping degrades the
+// project.
+func (s *PageSyncService) SynchronizePage(ctx context.Context, projectID uuid.UUID, pageID string) (PageSyncOutcome, error) {
+	project, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	owner, err := s.users.GetByUUID(ctx, project.UserID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load project owner: %w", err)
+	}
+	if !owner.HasValidNotionToken(s.clock) {
+		return "", usersDomain.ErrNotionTokenMissing
+	}
+
+	existing, err := s.tasks.FindByNotionPageID(ctx, projectID, pageID)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		existing, err = nil, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	page, err := s.pages.Retrieve(ctx, owner.NotionAccessToken, pageID)
+	var apiErr *notion.APIError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+		// Deleted for good, or no longer shared with the integration
+		return s.archive(ctx, existing)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if page.Archived || page.InTrash || !sameNotionID(page.Parent.DatabaseID, project.NotionDatabaseID) {
+		return s.archive(ctx, existing)
+	}
+
+	if existing != nil && page.LastEditedTime.Before(existing.NotionLastEditedAt) {
+		return PageSyncStale, nil
+	}
+
+	snapshot, err := NotionSnapshot(*page, project.PropertyMapping)
+	if errors.Is(err, projectsDomain.ErrInvalidPropertyMapping) {
+		// Every page of the project fails the same way until the mapping is fixed
+		if degradeErr := s.degrader.Degrade(ctx, projectID, err); degradeErr != nil {
+			return "", errors.Join(err, degradeErr)
+		}
+		return "", err
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if existing == nil {
+		task, err := domain.NewTask(projectID, snapshot, s.clock)
+		if err != nil {
+			return "", err
+		}
+		err = s.tasks.Upsert(ctx, &task)
+		if errors.Is(err, domain.ErrStaleTask) {
+			return PageSyncStale, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		// Other tasks may already list the new page as a predecessor
+		if err := s.deps.Pull(ctx, project); err != nil {
+			return "", err
+		}
+		return PageSyncCreated, nil
+	}
+
+	predecessorsChanged := !slices.Equal(existing.PredecessorPageIDs, snapshot.Predecessors)
+	existing.Apply(snapshot, s.clock)
+	err = s.tasks.Upsert(ctx, existing)
+	if errors.Is(err, domain.ErrStaleTask) {
+		return PageSyncStale, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if predecessorsChanged {
+		if err := s.deps.Pull(ctx, project); err != nil {
+			return "", err
+		}
+	}
+	return PageSyncUpdated, nil
+}
+
+// archive archives the task of a page that left the project, if there is one
+func (s *PageSyncService) archive(ctx context.Context, task *domain.Task) (PageSyncOutcome, error) {
+	if task == nil {
+		return PageSyncIgnored, nil
+	}
+	if task.Archived {
+		return PageSyncArchived, nil
+	}
+
+	task.Archive(s.clock)
+	err := s.tasks.Upsert(ctx, task)
+	if errors.Is(err, domain.ErrStaleTask) {
+		// A newer version of the page was stored meanwhile
+		return PageSyncStale, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return PageSyncArchived, nil
+}
@@ -0,0 +1,385 @@
+package application_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/modules/tasks/domain"
+	usersDomain "src/internal/modules/users/domain"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/notion/notiontest"
+)
+
+type mockClock struct {
+	now time.Time
+}
+
+func (m *mockClock) Now() time.Time {
+	return m.now
+}
+
+type mockProjectLookup struct {
+	projects map[uuid.UUID]*projectsDomain.Project
+}
+
+func (m *mockProjectLookup) FindByID(ctx context.Context, id uuid.UUID) (*projectsDomain.Project, error) {
+	project, exists := m.projects[id]
+	if !exists {
+		return nil, projectsDomain.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+type mockUserLookup struct {
+	users map[uuid.UUID]usersDomain.User
+}
+
+func (m *mockUserLookup) GetByUUID(ctx context.Context, id uuid.UUID) (usersDomain.User, error) {
+	user, exists := m.users[id]
+	if !exists {
+		return usersDomain.User{}, usersDomain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+type mockProjectDegrader struct {
+	causes map[uuid.UUID]error
+}
+
+func (m *mockProjectDegrader) Degrade(ctx context.Context, projectID uuid.UUID, cause error) error {
+	m.causes[projectID] = cause
+	return nil
+}
+
+// mockTaskRepository keeps tasks in memory, keyed like the tasks table
+type mockTaskRepository struct {
+	tasks map[string]domain.Task
+}
+
+func newMockTaskRepository() *mockTaskRepository {
+	return &mockTaskRepository{tasks: make(map[string]domain.Task)}
+}
+
+func (m *mockTaskRepository) key(projectID uuid.UUID, notionPageID string) string {
+	return projectID.String() + "/" + notionPageID
+}
+
+// Upsert links parents and sub-items like the repository does
+func (m *mockTaskRepository) Upsert(ctx context.Context, task *domain.Task) error {
+	if existing, exists := m.tasks[m.key(task.ProjectID, task.NotionPageID)]; exists {
+		if existing.NotionLastEditedAt.After(task.NotionLastEditedAt) {
+			return domain.ErrStaleTask
+		}
+		task.ID = existing.ID
+		task.CreatedAt = existing.CreatedAt
+	}
+	task.ParentID = nil
+	if parent, exists := m.tasks[m.key(task.ProjectID, task.ParentNotionPageID)]; exists && task.ParentNotionPageID != "" {
+		task.ParentID = &parent.ID
+	}
+	m.tasks[m.key(task.ProjectID, task.NotionPageID)] = *task
+
+	for key, child := range m.tasks {
+		if child.ProjectID == task.ProjectID && child.ParentNotionPageID == task.NotionPageID {
+			child.ParentID = &task.ID
+			m.tasks[key] = child
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	for _, task := range m.tasks {
+		if task.ID == id {
+			return &task, nil
+		}
+	}
+	return nil, domain.ErrTaskNotFound
+}
+
+func (m *mockTaskRepository) FindByNotionPageID(ctx context.Context, projectID uuid.UUID, notionPageID string) (*domain.Task, error) {
+	task, exists := m.tasks[m.key(projectID, notionPageID)]
+	if !exists {
+		return nil, domain.ErrTaskNotFound
+	}
+	return &task, nil
+}
+
+func (m *mockTaskRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	for _, task := range m.tasks {
+		if task.ProjectID == projectID {
+			tasks = append(tasks, &task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskRepository) FindSubtree(ctx context.Context, id uuid.UUID) ([]*domain.Task, error) {
+	root, err := m.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subtree := []*domain.Task{root}
+	for i := 0; i < len(subtree); i++ {
+		for _, task := range m.tasks {
+			if task.ParentID != nil && *task.ParentID == subtree[i].ID {
+				subtree = append(subtree, &task)
+			}
+		}
+	}
+	return subtree, nil
+}
+
+func (m *mockTaskRepository) FindAncestors(ctx context.Context, id uuid.UUID) ([]*domain.Task, error) {
+	task, err := m.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var ancestors []*domain.Task
+	for task.ParentID != nil {
+		if task, err = m.FindByID(ctx, *task.ParentID); err != nil {
+			return nil, err
+		}
+		ancestors = append(ancestors, task)
+	}
+	return ancestors, nil
+}
+
+func (m *mockTaskRepository) CountByProjectID(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	tasks, _ := m.FindByProjectID(ctx, projectID)
+	return int64(len(tasks)), nil
+}
+
+// racingPages runs race before fetching a page, as a concurrent sync of the same
+// page would while the fetch is in flight
+type racingPages struct {
+	application.PageRetriever
+	race func(pageID string)
+}
+
+func (p racingPages) Retrieve(ctx context.Context, accessToken, pageID string) (*notion.Page, error) {
+	p.race(pageID)
+	return p.PageRetriever.Retrieve(ctx, accessToken, pageID)
+}
+
+var _ = Describe("PageSyncService", func() {
+	var (
+		ctx      context.Context
+		server   *notiontest.Server
+		database notion.Database
+		users    *mockUserLookup
+		tasks    *mockTaskRepository
+		deps     *mockDependencyPuller
+		degrader *mockProjectDegrader
+		clock    *mockClock
+		project  *projectsDomain.Project
+		service  *application.PageSyncService
+	)
+
+	addPage := func(databaseID, title string) notion.Page {
+		return server.AddPage(notion.Page{
+			Parent:     notion.Parent{Type: "database_id", DatabaseID: databaseID},
+			Properties: map[string]notion.PropertyValue{"Name": {Title: []notion.RichText{notion.NewText(title)}}},
+		})
+	}
+
+	storeTask := func(page notion.Page, title string, lastEditedAt time.Time) {
+		task, err := domain.NewTask(project.ID, domain.NotionSnapshot{PageID: page.ID, Title: title, LastEditedAt: lastEditedAt}, clock)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks.Upsert(ctx, &task)).To(Succeed())
+	}
+
+	storedTask := func(page notion.Page) domain.Task {
+		task, err := tasks.FindByNotionPageID(ctx, project.ID, page.ID)
+		Expect(err).ToNot(HaveOccurred())
+		return *task
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = notiontest.NewServer()
+		DeferCleanup(server.Close)
+		database = server.AddDatabase(notion.Database{Properties: map[string]notion.Property{
+			"Name": {Type: "title", Title: &struct{}{}},
+		}})
+
+		owner := usersDomain.User{ID: uuid.New(), NotionAccessToken: notiontest.DefaultToken}
+		users = &mockUserLookup{users: map[uuid.UUID]usersDomain.User{owner.ID: owner}}
+
+		// Users paste database IDs with or without dashes
+		project = &projectsDomain.Project{ID: uuid.New(), UserID: owner.ID, NotionDatabaseID: strings.ReplaceAll(database.ID, "-", "")}
+		projects := &mockProjectLookup{projects: map[uuid.UUID]*projectsDomain.Project{project.ID: project}}
+
+		tasks = newMockTaskRepository()
+		deps = &mockDependencyPuller{}
+		degrader = &mockProjectDegrader{causes: make(map[uuid.UUID]error)}
+		clock = &mockClock{now: time.Date(2025, 10, 21, 9, 0, 0, 0, time.UTC)}
+		service = application.NewPageSyncService(projects, users, notion.NewPages(server.ClientOptions()...), tasks, deps, degrader, clock)
+	})
+
+	It("creates the task of a new page", func() {
+		page := addPage(database.ID, "Write docs")
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncCreated))
+		task := storedTask(page)
+		Expect(task.Title).To(Equal("Write docs"))
+		Expect(task.NotionLastEditedAt).To(Equal(page.LastEditedTime))
+		Expect(task.Properties).To(ContainSubstring(`"Name"`))
+	})
+
+	It("updates a task from a newer page", func() {
+		page := addPage(database.ID, "Write docs")
+		storeTask(page, "Old title", page.LastEditedTime.Add(-time.Hour))
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncUpdated))
+		Expect(storedTask(page).Title).To(Equal("Write docs"))
+	})
+
+	It("pulls task dependencies when a new page may be a predecessor", func() {
+		page := addPage(database.ID, "Write docs")
+
+		_, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deps.pulls).To(Equal(1))
+	})
+
+	It("pulls task dependencies only when the dependency relation changed", func() {
+		project.PropertyMapping = projectsDomain.PropertyMapping{Dependency: "Blocked by"}
+		predecessor := addPage(database.ID, "Design")
+		page := addPage(database.ID, "Build")
+		page.Properties["Blocked by"] = notion.PropertyValue{Type: "relation", Relation: []notion.Relation{{ID: predecessor.ID}}}
+		server.AddPage(page)
+		storeTask(page, "Build", page.LastEditedTime)
+
+		_, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(storedTask(page).PredecessorPageIDs).To(Equal([]string{predecessor.ID}))
+		Expect(deps.pulls).To(Equal(1))
+
+		_, err = service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deps.pulls).To(Equal(1))
+	})
+
+	It("keeps a task that is newer than the fetched page", func() {
+		page := addPage(database.ID, "Write docs")
+		storeTask(page, "Newer title", page.LastEditedTime.Add(time.Hour))
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncStale))
+		Expect(storedTask(page).Title).To(Equal("Newer title"))
+	})
+
+	It("keeps a newer task stored by a concurrent sync while the page was fetched", func() {
+		page := addPage(database.ID, "Write docs")
+		storeTask(page, "Old title", page.LastEditedTime.Add(-time.Hour))
+		projects := &mockProjectLookup{projects: map[uuid.UUID]*projectsDomain.Project{project.ID: project}}
+		pages := racingPages{
+			PageRetriever: notion.NewPages(server.ClientOptions()...),
+			race: func(pageID string) {
+				storeTask(page, "Newer title", page.LastEditedTime.Add(time.Hour))
+			},
+		}
+		service = application.NewPageSyncService(projects, users, pages, tasks, deps, degrader, clock)
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncStale))
+		Expect(storedTask(page).Title).To(Equal("Newer title"))
+		Expect(deps.pulls).To(BeZero())
+	})
+
+	It("archives the task of an archived page", func() {
+		page := addPage(database.ID, "Write docs")
+		storeTask(page, "Write docs", page.LastEditedTime)
+		page.Archived = true
+		server.AddPage(page)
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncArchived))
+		Expect(storedTask(page).Archived).To(BeTrue())
+	})
+
+	It("archives the task of a page that no longer exists", func() {
+		missing := notion.Page{ID: uuid.NewString()}
+		storeTask(missing, "Deleted", clock.now)
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, missing.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncArchived))
+		Expect(storedTask(missing).Archived).To(BeTrue())
+	})
+
+	It("archives the task of a page moved to another database", func() {
+		other := server.AddDatabase(notion.Database{Properties: map[string]notion.Property{"Name": {Type: "title", Title: &struct{}{}}}})
+		page := addPage(other.ID, "Moved")
+		storeTask(page, "Moved", page.LastEditedTime)
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncArchived))
+		Expect(storedTask(page).Archived).To(BeTrue())
+	})
+
+	It("ignores pages outside the project that have no task", func() {
+		other := server.AddDatabase(notion.Database{Properties: map[string]notion.Property{"Name": {Type: "title", Title: &struct{}{}}}})
+		page := addPage(other.ID, "Elsewhere")
+
+		outcome, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(outcome).To(Equal(application.PageSyncIgnored))
+		Expect(tasks.tasks).To(BeEmpty())
+	})
+
+	It("degrades the project when a page no longer fits its property mapping", func() {
+		project.PropertyMapping = projectsDomain.PropertyMapping{Status: "Status"}
+		page := addPage(database.ID, "Write docs")
+
+		_, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).To(MatchError(projectsDomain.ErrInvalidPropertyMapping))
+		Expect(degrader.causes[project.ID]).To(MatchError(err))
+	})
+
+	It("fails when the owner has not connected Notion", func() {
+		page := addPage(database.ID, "Write docs")
+		users.users[project.UserID] = usersDomain.User{ID: project.UserID}
+
+		_, err := service.SynchronizePage(ctx, project.ID, page.ID)
+
+		Expect(err).To(MatchError(usersDomain.ErrNotionTokenMissing))
+	})
+
+	It("returns ErrProjectNotFound for unknown projects", func() {
+		_, err := service.SynchronizePage(ctx, uuid.New(), uuid.NewString())
+
+		Expect(err).To(MatchError(projectsDomain.ErrProjectNotFound))
+	})
+})
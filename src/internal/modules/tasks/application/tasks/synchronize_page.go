@@ -0,0 +1,58 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+// TypeSynchronizePage fetches a Notion page and updates its local task
+const TypeSynchronizePage = "tasks:synchronize_page"
+
+// SynchronizePagePayload is the payload of a TypeSynchronizePage task
+type SynchronizePagePayload struct {
+	ProjectID    uuid.UUID `json:"project_id"`
+	NotionPageID string    `json:"notion_page_id"`
+}
+
+// NewSynchronizePageTask creates the task that synchronizes a page of a project
+func NewSynchronizePageTask(projectID uuid.UUID, notionPageID string) (*asynq.Task, error) {
+	payload, err := json.Marshal(SynchronizePagePayload{ProjectID: projectID, NotionPageID: notionPageID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal synchronize page payload: %w", err)
+	}
+
+	return asynq.NewTask(TypeSynchronizePage, payload), nil
+}
+
+// SynchronizePageDebounce returns the enqueue options that coalesce a burst of
+// events for one page. The task runs at the end of the debounce window containing
+// now, and its ID is derived from the page and the window, so asynq rejects every
+// later event of the window with asynq.ErrTaskIDConflict and the page is fetched
+// once, after the burst. A window of zero or less disables debouncing.
+func SynchronizePageDebounce(projectID uuid.UUID, notionPageID string, now time.Time, window time.Duration) []asynq.Option {
+	if window <= 0 {
+		return nil
+	}
+
+	start := now.Truncate(window)
+	return []asynq.Option{
+		asynq.TaskID(fmt.Sprintf("%s:%s:%s:%d", TypeSynchronizePage, projectID, notionPageID, start.UnixMilli())),
+		asynq.ProcessAt(start.Add(window)),
+	}
+}
+
+// ParseSynchronizePagePayload decodes the payload of a TypeSynchronizePage task
+func ParseSynchronizePagePayload(task *asynq.Task) (SynchronizePagePayload, error) {
+	var payload SynchronizePagePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return SynchronizePagePayload{}, fmt.Errorf("invalid synchronize page payload: %w", err)
+	}
+	if payload.ProjectID == uuid.Nil || payload.NotionPageID == "" {
+		return SynchronizePagePayload{}, fmt.Errorf("invalid synchronize page payload: missing project_id or notion_page_id")
+	}
+	return payload, nil
+}
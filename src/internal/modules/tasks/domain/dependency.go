@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidDependencyType = errors.New("invalid dependency type")
+	ErrSelfDependency        = errors.New("a task cannot depend on itself")
+	ErrDuplicateDependency   = errors.New("a task can depend on another task only once")
+	ErrPredecessorNotFound   = errors.New("predecessor is not a task of the same project")
+	ErrDependencyCycle       = errors.New("dependency cycle")
+)
+
+// DependencyType says which dates of two dependent tasks are linked
+type DependencyType string
+
+const (
+	FinishToStart  DependencyType = "FS" // The successor starts after the predecessor finishes
+	StartToStart   DependencyType = "SS" // The successor starts after the predecessor starts
+	FinishToFinish DependencyType = "FF" // The successor finishes after the predecessor finishes
+	StartToFinish  DependencyType = "SF" // The successor finishes after the predecessor starts
+)
+
+// IsValid reports whether t is one of the four dependency types
+func (t DependencyType) IsValid() bool {
+	switch t {
+	case FinishToStart, StartToStart, FinishToFinish, StartToFinish:
+		return true
+	}
+	return false
+}
+
+// TaskDependency links a task to a predecessor it waits for
+type TaskDependency struct {
+	ID            uuid.UUID
+	ProjectID     uuid.UUID
+	PredecessorID uuid.UUID
+	SuccessorID   uuid.UUID
+	Type          DependencyType
+	LagDays       int // Working days between the linked dates; negative lag lets them overlap
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// NewTaskDependency makes successor wait for predecessor. Both tasks must belong
+// to the same project.
+func NewTaskDependency(predecessor, successor *Task, dependencyType DependencyType, lagDays int, clock Clock) (TaskDependency, error) {
+	if !dependencyType.IsValid() {
+		return TaskDependency{}, fmt.Errorf("%w: %q", ErrInvalidDependencyType, dependencyType)
+	}
+	if predecessor.ID == successor.ID {
+		return TaskDependency{}, ErrSelfDependency
+	}
+	if predecessor.ProjectID != successor.ProjectID {
+		return TaskDependency{}, ErrPredecessorNotFound
+	}
+
+	now := clock.Now()
+	return TaskDependency{
+		ID:            uuid.New(),
+		ProjectID:     successor.ProjectID,
+		PredecessorID: predecessor.ID,
+		SuccessorID:   successor.ID,
+		Type:          dependencyType,
+		LagDays:       lagDays,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// DependencyCycleError is returned for a change that would make a task depend on
+// itself through other tasks
+type DependencyCycleError struct {
+	// Path starts and ends with the same task; each task is a predecessor of the next
+	Path []*Task
+}
+
+func (e *DependencyCycleError) Error() string {
+	steps := make([]string, len(e.Path))
+	for i, task := range e.Path {
+		steps[i] = fmt.Sprintf("%q", task.Title)
+	}
+	return fmt.Sprintf("%s: %s", ErrDependencyCycle, strings.Join(steps, " -> "))
+}
+
+func (e *DependencyCycleError) Unwrap() error {
+	return ErrDependencyCycle
+}
+
+// DependencyGraph holds the tasks of a project and the dependencies between them,
+// which never form a cycle
+type DependencyGraph struct {
+	tasks        map[uuid.UUID]*Task
+	predecessors map[uuid.UUID][]TaskDependency // By successor
+	successors   map[uuid.UUID][]uuid.UUID      // IDs of successors, by predecessor
+}
+
+// NewDependencyGraph creates the graph of a project's tasks and dependencies, as
+// returned by Repository.FindByProjectID and DependencyRepository.FindByProjectID
+func NewDependencyGraph(tasks []*Task, dependencies []TaskDependency) *DependencyGraph {
+	graph := &DependencyGraph{
+		tasks:        make(map[uuid.UUID]*Task, len(tasks)),
+		predecessors: make(map[uuid.UUID][]TaskDependency),
+		successors:   make(map[uuid.UUID][]uuid.UUID),
+	}
+	for _, task := range tasks {
+		graph.tasks[task.ID] = task
+	}
+	for _, dependency := range dependencies {
+		graph.predecessors[dependency.SuccessorID] = append(graph.predecessors[dependency.SuccessorID], dependency)
+		graph.successors[dependency.PredecessorID] = append(graph.successors[dependency.PredecessorID], dependency.SuccessorID)
+	}
+	return graph
+}
+
+// Task returns a task of the graph
+func (g *DependencyGraph) Task(id uuid.UUID) (*Task, bool) {
+	task, exists := g.tasks[id]
+	return task, exists
+}
+
+// Predecessors returns the dependencies of a task on its predecessors
+func (g *DependencyGraph) Predecessors(successorID uuid.UUID) []TaskDependency {
+	return g.predecessors[successorID]
+}
+
+// ReplacePredecessors replaces all dependencies of a task on its predecessors.
+// A change that would close a cycle returns a *DependencyCycleError describing
+// it and leaves the graph unchanged.
+func (g *DependencyGraph) ReplacePredecessors(successorID uuid.UUID, dependencies []TaskDependency) error {
+	if _, exists := g.tasks[successorID]; !exists {
+		return ErrTaskNotFound
+	}
+
+	seen := make(map[uuid.UUID]bool, len(dependencies))
+	for _, dependency := range dependencies {
+		switch {
+		case dependency.SuccessorID != successorID:
+			return fmt.Errorf("dependency %s is not a dependency of task %s", dependency.ID, successorID)
+		case dependency.PredecessorID == successorID:
+			return ErrSelfDependency
+		case seen[dependency.PredecessorID]:
+			return ErrDuplicateDependency
+		}
+		if _, exists := g.tasks[dependency.PredecessorID]; !exists {
+			return ErrPredecessorNotFound
+		}
+		seen[dependency.PredecessorID] = true
+
+		// The new link closes a cycle if the successor already leads to the predecessor
+		if path := g.path(successorID, dependency.PredecessorID); path != nil {
+			return &DependencyCycleError{Path: append(path, g.tasks[successorID])}
+		}
+	}
+
+	for _, dependency := range g.predecessors[successorID] {
+		g.successors[dependency.PredecessorID] = slices.DeleteFunc(g.successors[dependency.PredecessorID], func(id uuid.UUID) bool {
+			return id == successorID
+		})
+	}
+	for _, dependency := range dependencies {
+		g.successors[dependency.PredecessorID] = append(g.successors[dependency.PredecessorID], successorID)
+	}
+	g.predecessors[successorID] = dependencies
+	return nil
+}
+
+// path returns the shortest chain of tasks from one task to another, following
+// dependencies from predecessor to successor; nil when there is none
+func (g *DependencyGraph) path(from, to uuid.UUID) []*Task {
+	previous := map[uuid.UUID]uuid.UUID{from: from}
+	queue := []uuid.UUID{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			var path []*Task
+			for id := to; id != from; id = previous[id] {
+				path = append(path, g.tasks[id])
+			}
+			path = append(path, g.tasks[from])
+			slices.Reverse(path)
+			return path
+		}
+
+		for _, next := range g.successors[current] {
+			if _, visited := previous[next]; !visited {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package domain_test
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/tasks/domain"
+)
+
+type mockClock struct {
+	now time.Time
+}
+
+func (m *mockClock) Now() time.Time {
+	return m.now
+}
+
+var _ = Describe("TaskDependency", func() {
+	var (
+		clock     *mockClock
+		projectID uuid.UUID
+		design    *domain.Task
+		build     *domain.Task
+		release   *domain.Task
+	)
+
+	newTask := func(title string) *domain.Task {
+		return &domain.Task{ID: uuid.New(), ProjectID: projectID, Title: title}
+	}
+
+	link := func(predecessor, successor *domain.Task) domain.TaskDependency {
+		dependency, err := domain.NewTaskDependency(predecessor, successor, domain.FinishToStart, 0, clock)
+		Expect(err).ToNot(HaveOccurred())
+		return dependency
+	}
+
+	BeforeEach(func() {
+		clock = &mockClock{now: time.Date(2025, 10, 26, 9, 0, 0, 0, time.UTC)}
+		projectID = uuid.New()
+		design = newTask("Design")
+		build = newTask("Build")
+		release = newTask("Release")
+	})
+
+	Describe("NewTaskDependency", func() {
+		It("links a successor to its predecessor with a lag in working days", func() {
+			dependency, err := domain.NewTaskDependency(design, build, domain.StartToStart, -2, clock)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dependency.ID).ToNot(Equal(uuid.Nil))
+			Expect(dependency.ProjectID).To(Equal(projectID))
+			Expect(dependency.PredecessorID).To(Equal(design.ID))
+			Expect(dependency.SuccessorID).To(Equal(build.ID))
+			Expect(dependency.Type).To(Equal(domain.StartToStart))
+			Expect(dependency.LagDays).To(Equal(-2))
+			Expect(dependency.CreatedAt).To(Equal(clock.now))
+		})
+
+		It("accepts the four dependency types", func() {
+			for _, dependencyType := range []domain.DependencyType{domain.FinishToStart, domain.StartToStart, domain.FinishToFinish, domain.StartToFinish} {
+				_, err := domain.NewTaskDependency(design, build, dependencyType, 0, clock)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("rejects unknown dependency types", func() {
+			_, err := domain.NewTaskDependency(design, build, "XX", 0, clock)
+
+			Expect(err).To(MatchError(domain.ErrInvalidDependencyType))
+		})
+
+		It("rejects a task depending on itself", func() {
+			_, err := domain.NewTaskDependency(design, design, domain.FinishToStart, 0, clock)
+
+			Expect(err).To(MatchError(domain.ErrSelfDependency))
+		})
+
+		It("rejects predecessors of other projects", func() {
+			other := &domain.Task{ID: uuid.New(), ProjectID: uuid.New()}
+
+			_, err := domain.NewTaskDependency(other, build, domain.FinishToStart, 0, clock)
+
+			Expect(err).To(MatchError(domain.ErrPredecessorNotFound))
+		})
+	})
+
+	Describe("DependencyGraph", func() {
+		var graph *domain.DependencyGraph
+
+		BeforeEach(func() {
+			graph = domain.NewDependencyGraph(
+				[]*domain.Task{design, build, release},
+				[]domain.TaskDependency{link(design, build), link(build, release)},
+			)
+		})
+
+		It("replaces the predecessors of a task", func() {
+			dependencies := []domain.TaskDependency{link(design, release)}
+
+			Expect(graph.ReplacePredecessors(release.ID, dependencies)).To(Succeed())
+			Expect(graph.Predecessors(release.ID)).To(Equal(dependencies))
+		})
+
+		It("rejects a dependency that closes a cycle and describes its path", func() {
+			err := graph.ReplacePredecessors(design.ID, []domain.TaskDependency{link(release, design)})
+
+			var cycle *domain.DependencyCycleError
+			Expect(err).To(BeAssignableToTypeOf(cycle))
+			Expect(err).To(MatchError(domain.ErrDependencyCycle))
+			Expect(err).To(MatchError(`dependency cycle: "Design" -> "Build" -> "Release" -> "Design"`))
+			Expect(graph.Predecessors(design.ID)).To(BeEmpty())
+		})
+
+		It("accepts a reversed link once the old one is removed", func() {
+			Expect(graph.ReplacePredecessors(build.ID, nil)).To(Succeed())
+
+			Expect(graph.ReplacePredecessors(design.ID, []domain.TaskDependency{link(build, design)})).To(Succeed())
+		})
+
+		It("follows links added earlier when looking for cycles", func() {
+			deploy := newTask("Deploy")
+			graph = domain.NewDependencyGraph([]*domain.Task{design, build, release, deploy}, nil)
+			Expect(graph.ReplacePredecessors(build.ID, []domain.TaskDependency{link(design, build)})).To(Succeed())
+			Expect(graph.ReplacePredecessors(release.ID, []domain.TaskDependency{link(build, release)})).To(Succeed())
+			Expect(graph.ReplacePredecessors(deploy.ID, []domain.TaskDependency{link(release, deploy)})).To(Succeed())
+
+			err := graph.ReplacePredecessors(design.ID, []domain.TaskDependency{link(deploy, design)})
+
+			Expect(err).To(MatchError(`dependency cycle: "Design" -> "Build" -> "Release" -> "Deploy" -> "Design"`))
+		})
+
+		It("rejects duplicate predecessors", func() {
+			err := graph.ReplacePredecessors(release.ID, []domain.TaskDependency{link(design, release), link(design, release)})
+
+			Expect(err).To(MatchError(domain.ErrDuplicateDependency))
+			Expect(graph.Predecessors(release.ID)).To(HaveLen(1))
+		})
+
+		It("rejects predecessors outside the graph", func() {
+			err := graph.ReplacePredecessors(release.ID, []domain.TaskDependency{link(newTask("Elsewhere"), release)})
+
+			Expect(err).To(MatchError(domain.ErrPredecessorNotFound))
+		})
+
+		It("returns ErrTaskNotFound for unknown successors", func() {
+			err := graph.ReplacePredecessors(uuid.New(), nil)
+
+			Expect(err).To(MatchError(domain.ErrTaskNotFound))
+		})
+	})
+})
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// TaskTree is a task with its descendants
+type TaskTree struct {
+	Task     *Task
+	Children []*TaskTree
+}
+
+// NewTaskTree arranges a task and its descendants, as returned by
+// Repository.FindSubtree, into a tree rooted at rootID. Children keep the order of
+// tasks; tasks whose parent is not in tasks are left out.
+func NewTaskTree(rootID uuid.UUID, tasks []*Task) (*TaskTree, error) {
+	nodes := make(map[uuid.UUID]*TaskTree, len(tasks))
+	for _, task := range tasks {
+		nodes[task.ID] = &TaskTree{Task: task}
+	}
+
+	root, exists := nodes[rootID]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+
+	for _, task := range tasks {
+		if task.ID == rootID || task.ParentID == nil {
+			continue
+		}
+		if parent, exists := nodes[*task.ParentID]; exists {
+			parent.Children = append(parent.Children, nodes[task.ID])
+		}
+	}
+	return root, nil
+}
+
+// Size returns the number of tasks in the tree
+func (t *TaskTree) Size() int {
+	size := 1
+	for _, child := range t.Children {
+		size += child.Size()
+	}
+	return size
+}
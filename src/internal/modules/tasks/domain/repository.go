@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for task data access
+type Repository interface {
+	// Upsert stores a task, replacing the task of the same project and Notion page if
+	// there is one. The stored task's ID and CreatedAt are copied back into task. A
+	// stored task edited later in Notion than task is kept and ErrStaleTask returned.
+	Upsert(ctx context.Context, task *Task) error
+
+	// FindByID retrieves a task by its ID
+	FindByID(ctx context.Context, id uuid.UUID) (*Task, error)
+
+	// FindByNotionPageID retrieves a project's task by Notion page ID
+	FindByNotionPageID(ctx context.Context, projectID uuid.UUID, notionPageID string) (*Task, error)
+
+	// FindByProjectID retrieves all tasks of a project
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Task, error)
+
+	// FindSubtree retrieves a task followed by all its descendants, ordered by depth
+	FindSubtree(ctx context.Context, id uuid.UUID) ([]*Task, error)
+
+	// FindAncestors retrieves the parent of a task, its parent, and so on up to the root
+	FindAncestors(ctx context.Context, id uuid.UUID) ([]*Task, error)
+
+	// CountByProjectID returns how many tasks a project has
+	CountByProjectID(ctx context.Context, projectID uuid.UUID) (int64, error)
+}
+
+// DependencyRepository defines the interface for task dependency data access
+type DependencyRepository interface {
+	// LockProject keeps other transactions from changing a project's dependencies
+	// until the current transaction ends, so a graph checked for cycles stays valid
+	LockProject(ctx context.Context, projectID uuid.UUID) error
+
+	// FindByProjectID retrieves all dependencies between a project's tasks
+	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]TaskDependency, error)
+
+	// FindBySuccessorID retrieves the dependencies of a task on its predecessors
+	FindBySuccessorID(ctx context.Context, successorID uuid.UUID) ([]TaskDependency, error)
+
+	// ReplacePredecessors replaces the dependencies of a task on its predecessors
+	ReplacePredecessors(ctx context.Context, successorID uuid.UUID, dependencies []TaskDependency) error
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTaskNotFound = errors.New("task not found")
+	ErrStaleTask    = errors.New("a newer version of the page is stored")
+)
+
+// Task is a page of a project's Notion database mirrored locally. Tasks form a
+// hierarchy of any depth through their parent page.
+type Task struct {
+	ID           uuid.UUID // Internal UUID for DB relations and ordering
+	ProjectID    uuid.UUID
+	NotionPageID string
+	Title        string
+	Status       string     // Option name of the page's status
+	Priority     string     // Option name of the page's priority
+	StartDate    *time.Time // Nil when the page has no dates
+	DueDate      *time.Time
+	Assignees    []string // Notion user IDs
+
+	// ParentNotionPageID is the page this task is a sub-item of. ParentID is the
+	// parent's task once it has been stored, and is maintained by the repository.
+	ParentNotionPageID string
+	ParentID           *uuid.UUID
+
+	// PredecessorPageIDs are the pages listed in the project's dependency relation,
+	// when its property mapping has one. The task's predecessors follow them.
+	PredecessorPageIDs []string
+
+	// Properties holds the page properties exactly as returned by Notion
+	Properties json.RawMessage
+
+	Archived           bool
+	NotionCreatedAt    time.Time
+	NotionLastEditedAt time.Time
+	SyncedAt           time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NotionSnapshot is the state of a Notion page that a task mirrors
+type NotionSnapshot struct {
+	PageID       string
+	Title        string
+	Status       string
+	Priority     string
+	StartDate    *time.Time
+	DueDate      *time.Time
+	Assignees    []string
+	ParentPageID string
+	Predecessors []string // Page IDs
+	Properties   json.RawMessage
+	Archived     bool
+	CreatedAt    time.Time
+	LastEditedAt time.Time
+}
+
+// NewTask creates a task for a Notion page of the given project
+func NewTask(projectID uuid.UUID, snapshot NotionSnapshot, clock Clock) (Task, error) {
+	if projectID == uuid.Nil {
+		return Task{}, errors.New("invalid project ID")
+	}
+	if snapshot.PageID == "" {
+		return Task{}, errors.New("notion page ID cannot be empty")
+	}
+
+	now := clock.Now()
+
+	task := Task{
+		ID:           uuid.New(),
+		ProjectID:    projectID,
+		NotionPageID: snapshot.PageID,
+		CreatedAt:    now,
+	}
+	task.Apply(snapshot, clock)
+
+	return task, nil
+}
+
+// Apply updates the task from a newer snapshot of its Notion page
+func (t *Task) Apply(snapshot NotionSnapshot, clock Clock) {
+	now := clock.Now()
+
+	t.Title = snapshot.Title
+	t.Status = snapshot.Status
+	t.Priority = snapshot.Priority
+	t.StartDate = snapshot.StartDate
+	t.DueDate = snapshot.DueDate
+	t.Assignees = snapshot.Assignees
+	if t.ParentNotionPageID != snapshot.ParentPageID {
+		t.ParentID = nil
+	}
+	t.ParentNotionPageID = snapshot.ParentPageID
+	t.PredecessorPageIDs = snapshot.Predecessors
+	t.Properties = snapshot.Properties
+	t.Archived = snapshot.Archived
+	t.NotionCreatedAt = snapshot.CreatedAt
+	t.NotionLastEditedAt = snapshot.LastEditedAt
+	t.SyncedAt = now
+	t.UpdatedAt = now
+}
+
+// Archive marks the task's page as archived, deleted or moved out of the project
+func (t *Task) Archive(clock Clock) {
+	now := clock.Now()
+
+	t.Archived = true
+	t.SyncedAt = now
+	t.UpdatedAt = now
+}
+
+// Mirrors reports whether the task already holds the given snapshot of its page
+func (t Task) Mirrors(snapshot NotionSnapshot) bool {
+	return t.Title == snapshot.Title &&
+		t.Status == snapshot.Status &&
+		t.Priority == snapshot.Priority &&
+		sameTime(t.StartDate, snapshot.StartDate) &&
+		sameTime(t.DueDate, snapshot.DueDate) &&
+		slices.Equal(t.Assignees, snapshot.Assignees) &&
+		t.ParentNotionPageID == snapshot.ParentPageID &&
+		slices.Equal(t.PredecessorPageIDs, snapshot.Predecessors) &&
+		t.Archived == snapshot.Archived &&
+		t.NotionLastEditedAt.Equal(snapshot.LastEditedAt) &&
+		sameJSON(t.Properties, snapshot.Properties)
+}
+
+// sameTime compares two optional times by instant
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// sameJSON compares two JSON documents by value, since stored properties come back
+// from jsonb with different key order and spacing
+func sameJSON(a, b json.RawMessage) bool {
+	var left, right any
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+// Clock interface for dependency injection
+type Clock interface {
+	Now() time.Time
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/modules/tasks/application/tasks"
+)
+
+// PageSynchronizer synchronizes a single page (see application.PageSyncService)
+type PageSynchronizer interface {
+	SynchronizePage(ctx context.Context, projectID uuid.UUID, pageID string) (application.PageSyncOutcome, error)
+}
+
+// SynchronizePageHandler processes tasks.TypeSynchronizePage tasks
+type SynchronizePageHandler struct {
+	synchronizer PageSynchronizer
+	logger       *log.Logger
+}
+
+// NewSynchronizePageHandler creates a new synchronize page task handler
+func NewSynchronizePageHandler(synchronizer PageSynchronizer, logger *log.Logger) *SynchronizePageHandler {
+	return &SynchronizePageHandler{
+		synchronizer: synchronizer,
+		logger:       logger,
+	}
+}
+
+// Register adds the handler to the job worker's mux
+func (h *SynchronizePageHandler) Register(mux *asynq.ServeMux) {
+	mux.Handle(tasks.TypeSynchronizePage, h)
+}
+
+// ProcessTask implements asynq.Handler. Malformed payloads, deleted projects and
+// pages that no longer fit the project's property mapping are not retried; the
+// synchronizer has marked the project degraded. Other errors are retried by asynq.
+func (h *SynchronizePageHandler) ProcessTask(ctx context.Context, task *asynq.Task) error {
+	payload, err := tasks.ParseSynchronizePagePayload(task)
+	if err != nil {
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+
+	outcome, err := h.synchronizer.SynchronizePage(ctx, payload.ProjectID, payload.NotionPageID)
+	if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+		h.logger.Printf("Skipping page %s of deleted project %s", payload.NotionPageID, payload.ProjectID)
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+	if errors.Is(err, projectsDomain.ErrInvalidPropertyMapping) {
+		h.logger.Printf("Skipping page %s of project %s: %v", payload.NotionPageID, payload.ProjectID, err)
+		return fmt.Errorf("%w: %w", err, asynq.SkipRetry)
+	}
+	if err != nil {
+		return fmt.Errorf("synchronizing page %s of project %s failed: %w", payload.NotionPageID, payload.ProjectID, err)
+	}
+
+	h.logger.Printf("Synchronized page %s of project %s: %s", payload.NotionPageID, payload.ProjectID, outcome)
+	return nil
+}
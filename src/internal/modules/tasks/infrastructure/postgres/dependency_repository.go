@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"src/internal/database"
+	"src/internal/modules/tasks/domain"
+)
+
+// DependencyRepository implements domain.DependencyRepository using PostgreSQL/GORM
+type DependencyRepository struct {
+	db *gorm.DB
+}
+
+// NewDependencyRepository creates a new PostgreSQL task dependency repository
+func NewDependencyRepository(db *gorm.DB) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+// LockProject takes a transaction-scoped advisory lock on the project, so checking
+// its graph for cycles and storing the change cannot interleave with another change.
+// It must be called within a transaction.
+func (r *DependencyRepository) LockProject(ctx context.Context, projectID uuid.UUID) error {
+	return database.Conn(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "task_dependencies:"+projectID.String()).Error
+}
+
+// FindByProjectID retrieves all dependencies between a project's tasks
+func (r *DependencyRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]domain.TaskDependency, error) {
+	return r.find(ctx, "project_id = ?", projectID)
+}
+
+// FindBySuccessorID retrieves the dependencies of a task on its predecessors
+func (r *DependencyRepository) FindBySuccessorID(ctx context.Context, successorID uuid.UUID) ([]domain.TaskDependency, error) {
+	return r.find(ctx, "successor_id = ?", successorID)
+}
+
+// ReplacePredecessors deletes the dependencies of a task on its predecessors and
+// stores the given ones instead. Call it within a transaction for the replacement
+// to be atomic.
+func (r *DependencyRepository) ReplacePredecessors(ctx context.Context, successorID uuid.UUID, dependencies []domain.TaskDependency) error {
+	conn := database.Conn(ctx, r.db)
+
+	if err := conn.Where("successor_id = ?", successorID).Delete(&DependencyRecord{}).Error; err != nil {
+		return err
+	}
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	records := make([]DependencyRecord, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		records = append(records, toDependencyRecord(dependency))
+	}
+	return conn.Create(&records).Error
+}
+
+func (r *DependencyRepository) find(ctx context.Context, query string, args ...any) ([]domain.TaskDependency, error) {
+	var records []DependencyRecord
+
+	err := database.Conn(ctx, r.db).
+		Where(query, args...).
+		Order("created_at, id").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]domain.TaskDependency, 0, len(records))
+	for _, record := range records {
+		dependencies = append(dependencies, toDomainDependency(record))
+	}
+	return dependencies, nil
+}
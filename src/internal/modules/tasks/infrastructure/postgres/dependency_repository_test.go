@@ -0,0 +1,95 @@
+package postgres_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/database"
+	"src/internal/modules/tasks/domain"
+)
+
+var _ = Describe("DependencyRepository", func() {
+	var (
+		ctx       context.Context
+		projectID uuid.UUID
+		design    *domain.Task
+		build     *domain.Task
+		release   *domain.Task
+	)
+
+	store := func(pageID string) *domain.Task {
+		task, err := domain.NewTask(projectID, domain.NotionSnapshot{PageID: pageID, Title: "Task " + pageID, CreatedAt: time.Now()}, &mockClock{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.Upsert(ctx, &task)).To(Succeed())
+		return &task
+	}
+
+	link := func(predecessor, successor *domain.Task, dependencyType domain.DependencyType, lagDays int) domain.TaskDependency {
+		dependency, err := domain.NewTaskDependency(predecessor, successor, dependencyType, lagDays, &mockClock{})
+		Expect(err).ToNot(HaveOccurred())
+		return dependency
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		projectID = uuid.New()
+		db.Exec("TRUNCATE TABLE task_dependencies, tasks CASCADE")
+		design = store("design")
+		build = store("build")
+		release = store("release")
+	})
+
+	Describe("ReplacePredecessors", func() {
+		It("should store the dependencies of a task", func() {
+			dependencies := []domain.TaskDependency{link(design, release, domain.StartToFinish, -3)}
+			Expect(dependencyRepo.ReplacePredecessors(ctx, release.ID, dependencies)).To(Succeed())
+
+			found, err := dependencyRepo.FindBySuccessorID(ctx, release.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(HaveLen(1))
+			Expect(found[0].ID).To(Equal(dependencies[0].ID))
+			Expect(found[0].ProjectID).To(Equal(projectID))
+			Expect(found[0].PredecessorID).To(Equal(design.ID))
+			Expect(found[0].Type).To(Equal(domain.StartToFinish))
+			Expect(found[0].LagDays).To(Equal(-3))
+		})
+
+		It("should replace the previous dependencies of the task only", func() {
+			Expect(dependencyRepo.ReplacePredecessors(ctx, build.ID, []domain.TaskDependency{link(design, build, domain.FinishToStart, 0)})).To(Succeed())
+			Expect(dependencyRepo.ReplacePredecessors(ctx, release.ID, []domain.TaskDependency{link(design, release, domain.FinishToStart, 0)})).To(Succeed())
+
+			Expect(dependencyRepo.ReplacePredecessors(ctx, release.ID, []domain.TaskDependency{link(build, release, domain.FinishToStart, 0)})).To(Succeed())
+
+			found, err := dependencyRepo.FindByProjectID(ctx, projectID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(HaveLen(2))
+			successors, err := dependencyRepo.FindBySuccessorID(ctx, release.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(successors).To(HaveLen(1))
+			Expect(successors[0].PredecessorID).To(Equal(build.ID))
+		})
+
+		It("should clear the dependencies of a task", func() {
+			Expect(dependencyRepo.ReplacePredecessors(ctx, release.ID, []domain.TaskDependency{link(design, release, domain.FinishToStart, 0)})).To(Succeed())
+
+			Expect(dependencyRepo.ReplacePredecessors(ctx, release.ID, nil)).To(Succeed())
+
+			found, err := dependencyRepo.FindBySuccessorID(ctx, release.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeEmpty())
+		})
+	})
+
+	Describe("LockProject", func() {
+		It("should lock within a transaction", func() {
+			err := database.NewTransactionManager(db).WithinTransaction(ctx, func(ctx context.Context) error {
+				return dependencyRepo.LockProject(ctx, projectID)
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/tasks/domain"
+)
+
+// TaskRecord represents the tasks table structure in PostgreSQL
+type TaskRecord struct {
+	ID                 uuid.UUID       `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ProjectID          uuid.UUID       `gorm:"not null;type:uuid;uniqueIndex:idx_tasks_project_notion_page,priority:1;index:idx_tasks_project_status,priority:1;index:idx_tasks_project_parent_page,priority:1"`
+	NotionPageID       string          `gorm:"not null;type:varchar(255);uniqueIndex:idx_tasks_project_notion_page,priority:2"`
+	Title              string          `gorm:"not null;type:text"`
+	Status             string          `gorm:"not null;type:varchar(255);default:'';index:idx_tasks_project_status,priority:2"`
+	Priority           string          `gorm:"not null;type:varchar(255);default:''"`
+	StartDate          *time.Time      `gorm:""`
+	DueDate            *time.Time      `gorm:"index"`
+	Assignees          []string        `gorm:"type:jsonb;serializer:json"`
+	ParentNotionPageID string          `gorm:"type:varchar(255);index:idx_tasks_project_parent_page,priority:2"`
+	ParentID           *uuid.UUID      `gorm:"type:uuid;index"`
+	PredecessorPageIDs []string        `gorm:"type:jsonb;serializer:json"`
+	Properties         json.RawMessage `gorm:"type:jsonb"`
+	Archived           bool            `gorm:"not null;default:false"`
+	NotionCreatedAt    time.Time
+	NotionLastEditedAt time.Time
+	SyncedAt           time.Time `gorm:"not null"`
+	CreatedAt          time.Time `gorm:"not null"`
+	UpdatedAt          time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (TaskRecord) TableName() string {
+	return "tasks"
+}
+
+// toDomainTask converts a TaskRecord to a domain Task
+func toDomainTask(record TaskRecord) domain.Task {
+	return domain.Task{
+		ID:                 record.ID,
+		ProjectID:          record.ProjectID,
+		NotionPageID:       record.NotionPageID,
+		Title:              record.Title,
+		Status:             record.Status,
+		Priority:           record.Priority,
+		StartDate:          record.StartDate,
+		DueDate:            record.DueDate,
+		Assignees:          record.Assignees,
+		ParentNotionPageID: record.ParentNotionPageID,
+		ParentID:           record.ParentID,
+		PredecessorPageIDs: record.PredecessorPageIDs,
+		Properties:         record.Properties,
+		Archived:           record.Archived,
+		NotionCreatedAt:    record.NotionCreatedAt,
+		NotionLastEditedAt: record.NotionLastEditedAt,
+		SyncedAt:           record.SyncedAt,
+		CreatedAt:          record.CreatedAt,
+		UpdatedAt:          record.UpdatedAt,
+	}
+}
+
+// toTaskRecord converts a domain Task to a TaskRecord
+func toTaskRecord(task domain.Task) TaskRecord {
+	return TaskRecord{
+		ID:                 task.ID,
+		ProjectID:          task.ProjectID,
+		NotionPageID:       task.NotionPageID,
+		Title:              task.Title,
+		Status:             task.Status,
+		Priority:           task.Priority,
+		StartDate:          task.StartDate,
+		DueDate:            task.DueDate,
+		Assignees:          task.Assignees,
+		ParentNotionPageID: task.ParentNotionPageID,
+		ParentID:           task.ParentID,
+		PredecessorPageIDs: task.PredecessorPageIDs,
+		Properties:         task.Properties,
+		Archived:           task.Archived,
+		NotionCreatedAt:    task.NotionCreatedAt,
+		NotionLastEditedAt: task.NotionLastEditedAt,
+		SyncedAt:           task.SyncedAt,
+		CreatedAt:          task.CreatedAt,
+		UpdatedAt:          task.UpdatedAt,
+	}
+}
+
+// DependencyRecord represents the task_dependencies table structure in PostgreSQL
+type DependencyRecord struct {
+	ID            uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ProjectID     uuid.UUID `gorm:"not null;type:uuid;index"`
+	PredecessorID uuid.UUID `gorm:"not null;type:uuid;index;uniqueIndex:idx_task_dependencies_link,priority:2"`
+	SuccessorID   uuid.UUID `gorm:"not null;type:uuid;uniqueIndex:idx_task_dependencies_link,priority:1"`
+	Type          string    `gorm:"not null;type:varchar(2)"`
+	LagDays       int       `gorm:"not null;default:0"` // Working days
+	CreatedAt     time.Time `gorm:"not null"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (DependencyRecord) TableName() string {
+	return "task_dependencies"
+}
+
+// toDomainDependency converts a DependencyRecord to a domain TaskDependency
+func toDomainDependency(record DependencyRecord) domain.TaskDependency {
+	return domain.TaskDependency{
+		ID:            record.ID,
+		ProjectID:     record.ProjectID,
+		PredecessorID: record.PredecessorID,
+		SuccessorID:   record.SuccessorID,
+		Type:          domain.DependencyType(record.Type),
+		LagDays:       record.LagDays,
+		CreatedAt:     record.CreatedAt,
+		UpdatedAt:     record.UpdatedAt,
+	}
+}
+
+// toDependencyRecord converts a domain TaskDependency to a DependencyRecord
+func toDependencyRecord(dependency domain.TaskDependency) DependencyRecord {
+	return DependencyRecord{
+		ID:            dependency.ID,
+		ProjectID:     dependency.ProjectID,
+		PredecessorID: dependency.PredecessorID,
+		SuccessorID:   dependency.SuccessorID,
+		Type:          string(dependency.Type),
+		LagDays:       dependency.LagDays,
+		CreatedAt:     dependency.CreatedAt,
+		UpdatedAt:     dependency.UpdatedAt,
+	}
+}
@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"src/internal/database"
+	"src/internal/modules/tasks/domain"
+)
+
+// TaskRepository implements domain.Repository using PostgreSQL/GORM
+type TaskRepository struct {
+	db *gorm.DB
+}
+
+// NewTaskRepository creates a new PostgreSQL task repository
+func NewTaskRepository(db *gorm.DB) *TaskRepository {
+	return &TaskRepository{db: db}
+}
+
+// Upsert inserts a task or updates the task of the same project and Notion page.
+// The update is skipped, and domain.ErrStaleTask returned, when the stored task
+// was edited later in Notion; the check is part of the statement, so concurrent
+// syncs of a page cannot store an older version over a newer one.
+func (r *TaskRepository) Upsert(ctx context.Context, task *domain.Task) error {
+	record := toTaskRecord(*task)
+
+	result := database.Conn(ctx, r.db).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "project_id"}, {Name: "notion_page_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"title", "status", "priority", "start_date", "due_date", "assignees", "parent_notion_page_id",
+					"predecessor_page_ids", "properties", "archived", "notion_created_at", "notion_last_edited_at", "synced_at", "updated_at",
+				}),
+				Where: clause.Where{Exprs: []clause.Expression{
+					clause.Expr{SQL: "tasks.notion_last_edited_at <= EXCLUDED.notion_last_edited_at"},
+				}},
+			},
+			clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "created_at"}}},
+		).
+		Create(&record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrStaleTask
+	}
+
+	// An existing row keeps its ID and creation time
+	task.ID = record.ID
+	task.CreatedAt = record.CreatedAt
+
+	parentID, err := r.linkParent(ctx, record)
+	if err != nil {
+		return err
+	}
+	task.ParentID = parentID
+	return nil
+}
+
+// linkParent points a stored task at its parent's task and adopts the sub-items
+// stored before it, so the hierarchy is complete whatever order pages arrive in.
+// It returns the task's parent ID, nil when the parent is not stored yet.
+func (r *TaskRepository) linkParent(ctx context.Context, record TaskRecord) (*uuid.UUID, error) {
+	conn := database.Conn(ctx, r.db)
+
+	var linked struct{ ParentID *uuid.UUID }
+	err := conn.Raw(`
+		UPDATE tasks SET parent_id = (
+			SELECT parent.id FROM tasks parent
+			WHERE parent.project_id = tasks.project_id
+				AND parent.notion_page_id = tasks.parent_notion_page_id
+				AND parent.id <> tasks.id
+		)
+		WHERE id = ?
+		RETURNING parent_id
+	`, record.ID).Scan(&linked).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.Exec(`
+		UPDATE tasks SET parent_id = ?
+		WHERE project_id = ? AND parent_notion_page_id = ? AND id <> ?
+	`, record.ID, record.ProjectID, record.NotionPageID, record.ID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return linked.ParentID, nil
+}
+
+// FindByID retrieves a task by its ID
+func (r *TaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+// FindByNotionPageID retrieves a project's task by Notion page ID
+func (r *TaskRepository) FindByNotionPageID(ctx context.Context, projectID uuid.UUID, notionPageID string) (*domain.Task, error) {
+	return r.findOne(ctx, "project_id = ? AND notion_page_id = ?", projectID, notionPageID)
+}
+
+// FindByProjectID retrieves all tasks of a project
+func (r *TaskRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*domain.Task, error) {
+	var records []TaskRecord
+
+	err := database.Conn(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("notion_created_at, id").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(records))
+	for _, record := range records {
+		task := toDomainTask(record)
+		tasks = append(tasks, &task)
+	}
+
+	return tasks, nil
+}
+
+// FindSubtree retrieves a task followed by all its descendants, ordered by depth.
+// The path of each branch is tracked so a cycle in Notion's data cannot loop forever.
+func (r *TaskRepository) FindSubtree(ctx context.Context, id uuid.UUID) ([]*domain.Task, error) {
+	tasks, err := r.findRecursive(ctx, `
+		WITH RECURSIVE subtree (id, depth, path) AS (
+			SELECT id, 0, ARRAY[id] FROM tasks WHERE id = ?
+			UNION ALL
+			SELECT child.id, subtree.depth + 1, subtree.path || child.id
+			FROM tasks child
+			JOIN subtree ON child.parent_id = subtree.id
+			WHERE child.id <> ALL(subtree.path)
+		)
+		SELECT tasks.* FROM subtree
+		JOIN tasks ON tasks.id = subtree.id
+		ORDER BY subtree.depth, tasks.notion_created_at, tasks.id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, domain.ErrTaskNotFound
+	}
+	return tasks, nil
+}
+
+// FindAncestors retrieves the parent of a task, its parent, and so on up to the root
+func (r *TaskRepository) FindAncestors(ctx context.Context, id uuid.UUID) ([]*domain.Task, error) {
+	if _, err := r.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.findRecursive(ctx, `
+		WITH RECURSIVE ancestors (id, parent_id, depth, path) AS (
+			SELECT parent.id, parent.parent_id, 1, ARRAY[child.id, parent.id]
+			FROM tasks child
+			JOIN tasks parent ON parent.id = child.parent_id
+			WHERE child.id = ?
+			UNION ALL
+			SELECT parent.id, parent.parent_id, ancestors.depth + 1, ancestors.path || parent.id
+			FROM tasks parent
+			JOIN ancestors ON parent.id = ancestors.parent_id
+			WHERE parent.id <> ALL(ancestors.path)
+		)
+		SELECT tasks.* FROM ancestors
+		JOIN tasks ON tasks.id = ancestors.id
+		ORDER BY ancestors.depth
+	`, id)
+}
+
+// CountByProjectID returns how many tasks a project has
+func (r *TaskRepository) CountByProjectID(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).Model(&TaskRecord{}).Where("project_id = ?", projectID).Count(&count).Error
+	return count, err
+}
+
+func (r *TaskRepository) findRecursive(ctx context.Context, query string, args ...any) ([]*domain.Task, error) {
+	var records []TaskRecord
+	if err := database.Conn(ctx, r.db).Raw(query, args...).Scan(&records).Error; err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(records))
+	for _, record := range records {
+		task := toDomainTask(record)
+		tasks = append(tasks, &task)
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) findOne(ctx context.Context, query string, args ...any) (*domain.Task, error) {
+	var record TaskRecord
+
+	err := database.Conn(ctx, r.db).Where(query, args...).First(&record).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	task := toDomainTask(record)
+	return &task, nil
+}
@@ -0,0 +1,274 @@
+package postgres_test
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/gorm"
+
+	"src/internal/config"
+	"src/internal/database"
+	"src/internal/modules/tasks/domain"
+	taskRepo "src/internal/modules/tasks/infrastructure/postgres"
+)
+
+var (
+	db             *gorm.DB
+	repo           *taskRepo.TaskRepository
+	dependencyRepo *taskRepo.DependencyRepository
+)
+
+func mustStartPostgresContainer() (func(context.Context, ...testcontainers.TerminateOption) error, error) {
+	var (
+		dbName = "test_database"
+		dbPwd  = "test_password"
+		dbUser = "test_user"
+	)
+
+	dbContainer, err := postgres.Run(
+		context.Background(),
+		"postgres:latest",
+		postgres.WithDatabase(dbName),
+		postgres.WithUsername(dbUser),
+		postgres.WithPassword(dbPwd),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbHost, err := dbContainer.Host(context.Background())
+	if err != nil {
+		return dbContainer.Terminate, err
+	}
+
+	dbPort, err := dbContainer.MappedPort(context.Background(), "5432/tcp")
+	if err != nil {
+		return dbContainer.Terminate, err
+	}
+
+	testConfig := &config.Config{
+		Port: 8080, // Default for tests
+	}
+	testConfig.Database.Host = dbHost
+	testConfig.Database.Port = dbPort.Port()
+	testConfig.Database.Username = dbUser
+	testConfig.Database.Password = dbPwd
+	testConfig.Database.Database = dbName
+	testConfig.Database.Schema = "public"
+
+	config.SetForTests(testConfig)
+
+	return dbContainer.Terminate, err
+}
+
+var teardown func(context.Context, ...testcontainers.TerminateOption) error
+
+var _ = BeforeSuite(func() {
+	var err error
+	teardown, err = mustStartPostgresContainer()
+	Expect(err).ToNot(HaveOccurred())
+
+	db = database.GormDB()
+
+	// Run migrations using GORM AutoMigrate for tests
+	migrator := database.Migrator()
+	if err := migrator.AutoMigrate(&taskRepo.TaskRecord{}, &taskRepo.DependencyRecord{}); err != nil {
+		Fail("Failed to run AutoMigrate: " + err.Error())
+	}
+
+	repo = taskRepo.NewTaskRepository(db)
+	dependencyRepo = taskRepo.NewDependencyRepository(db)
+})
+
+var _ = AfterSuite(func() {
+	if teardown != nil {
+		if err := teardown(context.Background()); err != nil {
+			log.Fatalf("could not teardown postgres container: %v", err)
+		}
+	}
+})
+
+type mockClock struct{}
+
+func (m *mockClock) Now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+var _ = Describe("TaskRepository", func() {
+	var (
+		ctx       context.Context
+		projectID uuid.UUID
+	)
+
+	// store upserts the task of a page, optionally a sub-item of parentPageID
+	store := func(pageID, parentPageID string) *domain.Task {
+		task, err := domain.NewTask(projectID, domain.NotionSnapshot{
+			PageID:       pageID,
+			Title:        "Task " + pageID,
+			ParentPageID: parentPageID,
+			CreatedAt:    time.Now(),
+		}, &mockClock{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo.Upsert(ctx, &task)).To(Succeed())
+		return &task
+	}
+
+	pageIDs := func(tasks []*domain.Task) []string {
+		ids := make([]string, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.NotionPageID
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		projectID = uuid.New()
+		db.Exec("TRUNCATE TABLE tasks CASCADE")
+	})
+
+	Describe("Upsert", func() {
+		It("should store the task fields", func() {
+			start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+			due := start.Add(72 * time.Hour)
+			task, err := domain.NewTask(projectID, domain.NotionSnapshot{
+				PageID:       "page_1",
+				Title:        "Write docs",
+				Status:       "In progress",
+				Priority:     "High",
+				StartDate:    &start,
+				DueDate:      &due,
+				Assignees:    []string{"user_1", "user_2"},
+				Predecessors: []string{"page_0"},
+			}, &mockClock{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repo.Upsert(ctx, &task)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, task.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.Status).To(Equal("In progress"))
+			Expect(found.Priority).To(Equal("High"))
+			Expect(found.StartDate.Equal(start)).To(BeTrue())
+			Expect(found.DueDate.Equal(due)).To(BeTrue())
+			Expect(found.Assignees).To(Equal([]string{"user_1", "user_2"}))
+			Expect(found.PredecessorPageIDs).To(Equal([]string{"page_0"}))
+		})
+
+		It("should link a sub-item to a stored parent", func() {
+			parent := store("parent", "")
+			child := store("child", "parent")
+
+			Expect(child.ParentID).To(Equal(&parent.ID))
+		})
+
+		It("should adopt sub-items stored before their parent", func() {
+			child := store("child", "parent")
+			Expect(child.ParentID).To(BeNil())
+
+			parent := store("parent", "")
+
+			found, err := repo.FindByID(ctx, child.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.ParentID).To(Equal(&parent.ID))
+		})
+
+		It("should detach a task moved out of its parent", func() {
+			store("parent", "")
+			store("child", "parent")
+
+			moved := store("child", "")
+
+			Expect(moved.ParentID).To(BeNil())
+		})
+
+		It("should keep a task edited later in Notion and leave its parent alone", func() {
+			store("parent", "")
+			editedAt := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+			newer, err := domain.NewTask(projectID, domain.NotionSnapshot{PageID: "child", Title: "Newer", LastEditedAt: editedAt.Add(time.Hour)}, &mockClock{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repo.Upsert(ctx, &newer)).To(Succeed())
+
+			older, err := domain.NewTask(projectID, domain.NotionSnapshot{PageID: "child", Title: "Older", ParentPageID: "parent", LastEditedAt: editedAt}, &mockClock{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repo.Upsert(ctx, &older)).To(MatchError(domain.ErrStaleTask))
+
+			found, err := repo.FindByID(ctx, newer.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.Title).To(Equal("Newer"))
+			Expect(found.ParentNotionPageID).To(BeEmpty())
+			Expect(found.ParentID).To(BeNil())
+		})
+
+		It("should store a version edited at the same time", func() {
+			editedAt := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+			first, err := domain.NewTask(projectID, domain.NotionSnapshot{PageID: "page_1", Title: "First", LastEditedAt: editedAt}, &mockClock{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repo.Upsert(ctx, &first)).To(Succeed())
+
+			second, err := domain.NewTask(projectID, domain.NotionSnapshot{PageID: "page_1", Title: "Second", LastEditedAt: editedAt}, &mockClock{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repo.Upsert(ctx, &second)).To(Succeed())
+
+			Expect(second.ID).To(Equal(first.ID))
+			found, err := repo.FindByID(ctx, first.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.Title).To(Equal("Second"))
+		})
+	})
+
+	Describe("FindSubtree", func() {
+		It("should find a task and all its descendants by depth", func() {
+			root := store("root", "")
+			store("child", "root")
+			store("grandchild", "child")
+			store("great_grandchild", "grandchild")
+			store("unrelated", "")
+
+			subtree, err := repo.FindSubtree(ctx, root.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pageIDs(subtree)).To(Equal([]string{"root", "child", "grandchild", "great_grandchild"}))
+		})
+
+		It("should stop at cycles", func() {
+			first := store("first", "second")
+			store("second", "first")
+
+			subtree, err := repo.FindSubtree(ctx, first.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pageIDs(subtree)).To(Equal([]string{"first", "second"}))
+		})
+
+		It("should return error when task does not exist", func() {
+			_, err := repo.FindSubtree(ctx, uuid.New())
+			Expect(err).To(MatchError(domain.ErrTaskNotFound))
+		})
+	})
+
+	Describe("FindAncestors", func() {
+		It("should find the path to the root, parent first", func() {
+			store("root", "")
+			store("child", "root")
+			grandchild := store("grandchild", "child")
+
+			ancestors, err := repo.FindAncestors(ctx, grandchild.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pageIDs(ancestors)).To(Equal([]string{"child", "root"}))
+		})
+
+		It("should return error when task does not exist", func() {
+			_, err := repo.FindAncestors(ctx, uuid.New())
+			Expect(err).To(MatchError(domain.ErrTaskNotFound))
+		})
+	})
+})
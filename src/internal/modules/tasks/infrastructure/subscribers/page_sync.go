@@ -0,0 +1,92 @@
+package subscribers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+
+	shared "src/internal/modules/shared/domain"
+	sharedEvents "src/internal/modules/shared/domain/events"
+	"src/internal/modules/tasks/application/tasks"
+	"src/internal/pkg/taskqueue"
+)
+
+// PageSyncSchedulerHandlerName prefixes the Watermill handler names used by the event worker
+const PageSyncSchedulerHandlerName = "tasks_page_sync_scheduler"
+
+// pageSyncEventTypes are the Notion events that can change a page's task
+var pageSyncEventTypes = []sharedEvents.NotionEventType{
+	sharedEvents.NotionPageCreated,
+	sharedEvents.NotionPagePropertiesUpdated,
+	sharedEvents.NotionPageMoved,
+	sharedEvents.NotionPageDeleted,
+	sharedEvents.NotionPageUndeleted,
+}
+
+// PageSyncScheduler enqueues a tasks.TypeSynchronizePage job for triaged page events,
+// coalescing events for the same page within the debounce window
+type PageSyncScheduler struct {
+	enqueuer taskqueue.Enqueuer
+	clock    shared.Clock
+	debounce time.Duration
+	logger   *log.Logger
+}
+
+// NewPageSyncScheduler creates a new page sync scheduler
+func NewPageSyncScheduler(enqueuer taskqueue.Enqueuer, clock shared.Clock, debounce time.Duration, logger *log.Logger) *PageSyncScheduler {
+	return &PageSyncScheduler{
+		enqueuer: enqueuer,
+		clock:    clock,
+		debounce: debounce,
+		logger:   logger,
+	}
+}
+
+// Register adds one handler per page event topic to the router
+func (s *PageSyncScheduler) Register(router *message.Router, subscriber message.Subscriber) {
+	for _, eventType := range pageSyncEventTypes {
+		router.AddConsumerHandler(
+			PageSyncSchedulerHandlerName+"_"+string(eventType),
+			eventType.Topic(),
+			subscriber,
+			s.Handle,
+		)
+	}
+}
+
+// Handle schedules the synchronization of the page in a triaged page event.
+// Events without a known project are acknowledged and dropped.
+func (s *PageSyncScheduler) Handle(msg *message.Message) error {
+	var event struct {
+		Entity sharedEvents.NotionEntityRef `json:"entity"`
+	}
+	if err := json.Unmarshal(msg.Payload, &event); err != nil || event.Entity.ID == "" {
+		s.logger.Printf("Dropping malformed page event %s", msg.UUID)
+		return nil
+	}
+
+	projectID, err := uuid.Parse(msg.Metadata.Get(sharedEvents.ProjectIDMetadataKey))
+	if err != nil {
+		s.logger.Printf("Ignoring page event %s: no project for page %s", msg.UUID, event.Entity.ID)
+		return nil
+	}
+
+	task, err := tasks.NewSynchronizePageTask(projectID, event.Entity.ID)
+	if err != nil {
+		s.logger.Printf("Dropping page event %s: %v", msg.UUID, err)
+		return nil
+	}
+
+	opts := tasks.SynchronizePageDebounce(projectID, event.Entity.ID, s.clock.Now(), s.debounce)
+	_, err = s.enqueuer.EnqueueContext(msg.Context(), task, opts...)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		// Already scheduled for this window
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,163 @@
+package subscribers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sharedEvents "src/internal/modules/shared/domain/events"
+	"src/internal/modules/tasks/application/tasks"
+	"src/internal/modules/tasks/infrastructure/subscribers"
+)
+
+type mockClock struct {
+	now time.Time
+}
+
+func (m *mockClock) Now() time.Time {
+	return m.now
+}
+
+// queuedTask is a task accepted by fakeEnqueuer with the options it was given
+type queuedTask struct {
+	task      *asynq.Task
+	processAt time.Time
+}
+
+// fakeEnqueuer rejects task IDs it has already queued, like asynq does for
+// pending tasks with the same ID. Tasks without an ID are always accepted.
+type fakeEnqueuer struct {
+	tasks []queuedTask
+	ids   map[string]bool
+	err   error
+}
+
+func (f *fakeEnqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	queued := queuedTask{task: task}
+	for _, opt := range opts {
+		switch opt.Type() {
+		case asynq.TaskIDOpt:
+			id := opt.Value().(string)
+			if f.ids[id] {
+				return nil, asynq.ErrTaskIDConflict
+			}
+			f.ids[id] = true
+		case asynq.ProcessAtOpt:
+			queued.processAt = opt.Value().(time.Time)
+		}
+	}
+	f.tasks = append(f.tasks, queued)
+	return &asynq.TaskInfo{Type: task.Type(), Payload: task.Payload()}, nil
+}
+
+func pageEventMessage(projectID uuid.UUID, pageID string) *message.Message {
+	payload, err := json.Marshal(sharedEvents.NotionPageEvent{NotionEvent: sharedEvents.NotionEvent{
+		ID:     uuid.NewString(),
+		Type:   sharedEvents.NotionPagePropertiesUpdated,
+		Entity: sharedEvents.NotionEntityRef{ID: pageID, Type: "page"},
+	}})
+	Expect(err).ToNot(HaveOccurred())
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	if projectID != uuid.Nil {
+		msg.Metadata.Set(sharedEvents.ProjectIDMetadataKey, projectID.String())
+	}
+	return msg
+}
+
+var _ = Describe("PageSyncScheduler", func() {
+	const debounce = 5 * time.Second
+
+	var (
+		enqueuer  *fakeEnqueuer
+		clock     *mockClock
+		scheduler *subscribers.PageSyncScheduler
+		projectID uuid.UUID
+		pageID    string
+	)
+
+	BeforeEach(func() {
+		enqueuer = &fakeEnqueuer{ids: make(map[string]bool)}
+		clock = &mockClock{now: time.Date(2025, 10, 21, 9, 0, 1, 0, time.UTC)}
+		scheduler = subscribers.NewPageSyncScheduler(enqueuer, clock, debounce, log.New(io.Discard, "", 0))
+		projectID = uuid.New()
+		pageID = uuid.NewString()
+	})
+
+	It("enqueues the synchronization of the page at the end of the window", func() {
+		Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(HaveLen(1))
+		Expect(enqueuer.tasks[0].processAt).To(Equal(time.Date(2025, 10, 21, 9, 0, 5, 0, time.UTC)))
+		payload, err := tasks.ParseSynchronizePagePayload(enqueuer.tasks[0].task)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(payload.ProjectID).To(Equal(projectID))
+		Expect(payload.NotionPageID).To(Equal(pageID))
+	})
+
+	It("coalesces a burst of events for one page into one task", func() {
+		for range 20 {
+			Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(Succeed())
+			clock.now = clock.now.Add(100 * time.Millisecond)
+		}
+
+		Expect(enqueuer.tasks).To(HaveLen(1))
+	})
+
+	It("schedules another task for events after the window", func() {
+		Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(Succeed())
+		clock.now = clock.now.Add(debounce)
+		Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(HaveLen(2))
+	})
+
+	It("schedules each page separately", func() {
+		Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(Succeed())
+		Expect(scheduler.Handle(pageEventMessage(projectID, uuid.NewString()))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(HaveLen(2))
+	})
+
+	It("enqueues every event when debouncing is disabled", func() {
+		scheduler = subscribers.NewPageSyncScheduler(enqueuer, clock, 0, log.New(io.Discard, "", 0))
+
+		Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(Succeed())
+		Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(HaveLen(2))
+		Expect(enqueuer.tasks[0].processAt).To(BeZero())
+	})
+
+	It("drops events of pages outside any project", func() {
+		Expect(scheduler.Handle(pageEventMessage(uuid.Nil, pageID))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(BeEmpty())
+	})
+
+	It("drops malformed messages", func() {
+		Expect(scheduler.Handle(message.NewMessage(watermill.NewUUID(), []byte("not json")))).To(Succeed())
+
+		Expect(enqueuer.tasks).To(BeEmpty())
+	})
+
+	It("returns enqueue failures so the message is redelivered", func() {
+		enqueuer.err = errors.New("redis unavailable")
+
+		Expect(scheduler.Handle(pageEventMessage(projectID, pageID))).To(MatchError("redis unavailable"))
+	})
+})
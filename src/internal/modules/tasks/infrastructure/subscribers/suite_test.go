@@ -0,0 +1,13 @@
+package subscribers_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSubscribers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Task Subscribers Suite")
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"time"
+
+	"src/internal/modules/tasks/application"
+	"src/internal/modules/tasks/domain"
+)
+
+// TaskResponseDTO represents a task in API responses
+type TaskResponseDTO struct {
+	ID           string     `json:"id"`
+	NotionPageID string     `json:"notion_page_id"`
+	ParentID     *string    `json:"parent_id"`
+	Title        string     `json:"title"`
+	Status       string     `json:"status"`
+	Priority     string     `json:"priority"`
+	StartDate    *time.Time `json:"start_date"`
+	DueDate      *time.Time `json:"due_date"`
+	Assignees    []string   `json:"assignees"`
+	Archived     bool       `json:"archived"`
+	SyncedAt     time.Time  `json:"synced_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TaskTreeResponseDTO represents a task with its descendants
+type TaskTreeResponseDTO struct {
+	TaskResponseDTO
+	Children []TaskTreeResponseDTO `json:"children"`
+}
+
+// TaskSubtreeResponseDTO represents the response payload for a task's hierarchy
+type TaskSubtreeResponseDTO struct {
+	Task      TaskTreeResponseDTO `json:"task"`
+	Ancestors []TaskResponseDTO   `json:"ancestors"` // Parent first
+	Count     int                 `json:"count"`     // Tasks in the subtree, including the root
+}
+
+// toTaskResponseDTO converts a domain Task to TaskResponseDTO
+func toTaskResponseDTO(task domain.Task) TaskResponseDTO {
+	dto := TaskResponseDTO{
+		ID:           task.ID.String(),
+		NotionPageID: task.NotionPageID,
+		Title:        task.Title,
+		Status:       task.Status,
+		Priority:     task.Priority,
+		StartDate:    task.StartDate,
+		DueDate:      task.DueDate,
+		Assignees:    task.Assignees,
+		Archived:     task.Archived,
+		SyncedAt:     task.SyncedAt,
+		UpdatedAt:    task.UpdatedAt,
+	}
+	if dto.Assignees == nil {
+		dto.Assignees = []string{}
+	}
+	if task.ParentID != nil {
+		parentID := task.ParentID.String()
+		dto.ParentID = &parentID
+	}
+	return dto
+}
+
+// toTaskTreeResponseDTO converts a domain TaskTree to TaskTreeResponseDTO
+func toTaskTreeResponseDTO(tree *domain.TaskTree) TaskTreeResponseDTO {
+	dto := TaskTreeResponseDTO{
+		TaskResponseDTO: toTaskResponseDTO(*tree.Task),
+		Children:        make([]TaskTreeResponseDTO, 0, len(tree.Children)),
+	}
+	for _, child := range tree.Children {
+		dto.Children = append(dto.Children, toTaskTreeResponseDTO(child))
+	}
+	return dto
+}
+
+// toTaskResponseDTOs converts a slice of domain Tasks to TaskResponseDTOs
+func toTaskResponseDTOs(tasks []*domain.Task) []TaskResponseDTO {
+	dtos := make([]TaskResponseDTO, 0, len(tasks))
+	for _, task := range tasks {
+		dtos = append(dtos, toTaskResponseDTO(*task))
+	}
+	return dtos
+}
+
+// PredecessorLinkDTO represents a dependency on a predecessor in API requests
+type PredecessorLinkDTO struct {
+	TaskID  string `json:"task_id"`
+	Type    string `json:"type"` // FS, SS, FF or SF; FS when empty
+	LagDays int    `json:"lag_days"`
+}
+
+// ReplaceDependenciesRequestDTO represents the request payload for replacing a task's predecessors
+type ReplaceDependenciesRequestDTO struct {
+	Predecessors []PredecessorLinkDTO `json:"predecessors"`
+}
+
+// DependencyResponseDTO represents a dependency on a predecessor in API responses
+type DependencyResponseDTO struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Title     string    `json:"title"`
+	Type      string    `json:"type"`
+	LagDays   int       `json:"lag_days"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TaskDependenciesResponseDTO represents the response payload for a task's predecessors
+type TaskDependenciesResponseDTO struct {
+	TaskID       string                  `json:"task_id"`
+	Predecessors []DependencyResponseDTO `json:"predecessors"`
+}
+
+// CycleStepDTO represents a task on the path of a dependency cycle
+type CycleStepDTO struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// toTaskDependenciesResponseDTO converts a task's new dependencies to TaskDependenciesResponseDTO
+func toTaskDependenciesResponseDTO(resp application.ReplaceDependenciesResponse) TaskDependenciesResponseDTO {
+	dto := TaskDependenciesResponseDTO{
+		TaskID:       resp.Task.ID.String(),
+		Predecessors: make([]DependencyResponseDTO, 0, len(resp.Dependencies)),
+	}
+	for _, dependency := range resp.Dependencies {
+		dto.Predecessors = append(dto.Predecessors, DependencyResponseDTO{
+			ID:        dependency.ID.String(),
+			TaskID:    dependency.PredecessorID.String(),
+			Title:     resp.Predecessors[dependency.PredecessorID].Title,
+			Type:      string(dependency.Type),
+			LagDays:   dependency.LagDays,
+			CreatedAt: dependency.CreatedAt,
+		})
+	}
+	return dto
+}
+
+// toCycleStepDTOs converts the path of a dependency cycle to CycleStepDTOs
+func toCycleStepDTOs(path []*domain.Task) []CycleStepDTO {
+	dtos := make([]CycleStepDTO, 0, len(path))
+	for _, task := range path {
+		dtos = append(dtos, CycleStepDTO{ID: task.ID.String(), Title: task.Title})
+	}
+	return dtos
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"src/internal/database"
+	projectsPostgres "src/internal/modules/projects/infrastructure/postgres"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/modules/tasks/application"
+	"src/internal/modules/tasks/domain"
+	"src/internal/modules/tasks/infrastructure/postgres"
+	usersDomain "src/internal/modules/users/domain"
+	usersPostgres "src/internal/modules/users/infrastructure/postgres"
+	"src/internal/pkg/httpx"
+	"src/internal/pkg/middleware"
+	"src/internal/pkg/notion"
+)
+
+// NewRouter creates a new HTTP router for the tasks module.
+// notionOpts configure the Notion client used to write dependencies back to Notion.
+func NewRouter(notionOpts ...notion.ClientOption) chi.Router {
+	r := chi.NewRouter()
+
+	// Initialize dependencies
+	db := database.GormDB()
+	repo := postgres.NewTaskRepository(db)
+	dependencies := postgres.NewDependencyRepository(db)
+	projects := projectsPostgres.NewProjectRepository(db)
+	users := usersPostgres.NewUserRepository(db)
+	clock := shared.NewSystemClock()
+	txMgr := database.NewTransactionManager(db)
+
+	// Initialize use cases
+	getTaskTreeUC := application.NewGetTaskTreeUseCase(repo, projects)
+	replaceDependenciesUC := application.NewReplaceDependenciesUseCase(
+		repo,
+		dependencies,
+		projects,
+		users,
+		notion.NewPages(notionOpts...),
+		clock,
+		txMgr,
+	)
+
+	// Define routes
+	r.Get("/{taskID}/subtree", httpx.Endpoint(func(req *http.Request) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		taskID, err := uuid.Parse(chi.URLParam(req, "taskID"))
+		if err != nil {
+			return http.StatusNotFound, nil, httpx.NotFound(domain.ErrTaskNotFound.Error())
+		}
+
+		resp, err := getTaskTreeUC.Execute(req.Context(), application.GetTaskTreeRequest{UserID: userID, TaskID: taskID})
+		if err != nil {
+			if errors.Is(err, domain.ErrTaskNotFound) {
+				return http.StatusNotFound, nil, httpx.NotFound(err.Error())
+			}
+			return http.StatusInternalServerError, nil, err
+		}
+
+		dto := TaskSubtreeResponseDTO{
+			Task:      toTaskTreeResponseDTO(resp.Tree),
+			Ancestors: toTaskResponseDTOs(resp.Ancestors),
+			Count:     resp.Tree.Size(),
+		}
+		return http.StatusOK, dto, nil
+	}))
+
+	r.Put("/{taskID}/dependencies", httpx.EndpointJSON[ReplaceDependenciesRequestDTO](func(req *http.Request, body ReplaceDependenciesRequestDTO) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		taskID, err := uuid.Parse(chi.URLParam(req, "taskID"))
+		if err != nil {
+			return http.StatusNotFound, nil, httpx.NotFound(domain.ErrTaskNotFound.Error())
+		}
+
+		replaceReq := application.ReplaceDependenciesRequest{
+			UserID:       userID,
+			TaskID:       taskID,
+			Predecessors: make([]application.PredecessorLink, 0, len(body.Predecessors)),
+		}
+		for _, link := range body.Predecessors {
+			predecessorID, err := uuid.Parse(link.TaskID)
+			if err != nil {
+				return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(domain.ErrPredecessorNotFound.Error(), nil)
+			}
+			dependencyType := domain.DependencyType(link.Type)
+			if dependencyType == "" {
+				dependencyType = domain.FinishToStart
+			}
+			replaceReq.Predecessors = append(replaceReq.Predecessors, application.PredecessorLink{
+				TaskID:  predecessorID,
+				Type:    dependencyType,
+				LagDays: link.LagDays,
+			})
+		}
+
+		resp, err := replaceDependenciesUC.Execute(req.Context(), replaceReq)
+		if err != nil {
+			return dependenciesError(err)
+		}
+
+		return http.StatusOK, toTaskDependenciesResponseDTO(resp), nil
+	}))
+
+	return r
+}
+
+// dependenciesError maps errors of the dependencies endpoint to responses
+func dependenciesError(err error) (int, any, error) {
+	var cycle *domain.DependencyCycleError
+	switch {
+	case errors.Is(err, domain.ErrTaskNotFound):
+		return http.StatusNotFound, nil, httpx.NotFound(err.Error())
+	case errors.As(err, &cycle):
+		return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(err.Error(), toCycleStepDTOs(cycle.Path))
+	case errors.Is(err, domain.ErrInvalidDependencyType),
+		errors.Is(err, domain.ErrSelfDependency),
+		errors.Is(err, domain.ErrDuplicateDependency),
+		errors.Is(err, domain.ErrPredecessorNotFound),
+		errors.Is(err, usersDomain.ErrNotionTokenMissing):
+		return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(err.Error(), nil)
+	}
+	return http.StatusInternalServerError, nil, err
+}
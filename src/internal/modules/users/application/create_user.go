@@ -20,10 +20,11 @@ type CreateUserResponse struct {
 
 // CreateUserUseCase handles user creation business logic
 type CreateUserUseCase struct {
-	repo  domain.UserRepository
-	idGen shared.IDGenerator
-	clock shared.Clock
-	txMgr shared.TransactionManager
+	repo   domain.UserRepository
+	idGen  shared.IDGenerator
+	clock  shared.Clock
+	txMgr  shared.TransactionManager
+	events shared.EventRecorder
 }
 
 // NewCreateUserUseCase creates a new CreateUserUseCase
@@ -32,12 +33,14 @@ func NewCreateUserUseCase(
 	idGen shared.IDGenerator,
 	clock shared.Clock,
 	txMgr shared.TransactionManager,
+	events shared.EventRecorder,
 ) *CreateUserUseCase {
 	return &CreateUserUseCase{
-		repo:  repo,
-		idGen: idGen,
-		clock: clock,
-		txMgr: txMgr,
+		repo:   repo,
+		idGen:  idGen,
+		clock:  clock,
+		txMgr:  txMgr,
+		events: events,
 	}
 }
 
@@ -67,6 +70,11 @@ func (uc *CreateUserUseCase) Execute(ctx context.Context, req CreateUserRequest)
 			return err
 		}
 
+		// Record the event in the same transaction as the user
+		if err := uc.events.Record(ctx, domain.NewUserCreated(createdUser)); err != nil {
+			return err
+		}
+
 		response = CreateUserResponse{User: createdUser}
 		return nil
 	})
@@ -31,6 +31,7 @@ type NotionOAuthUseCase struct {
 	repo         domain.UserRepository
 	clock        shared.Clock
 	txMgr        shared.TransactionManager
+	events       shared.EventRecorder
 	idGen        shared.IDGenerator
 	notionClient *notion.Service
 }
@@ -40,6 +41,7 @@ func NewNotionOAuthUseCase(
 	repo domain.UserRepository,
 	clock shared.Clock,
 	txMgr shared.TransactionManager,
+	events shared.EventRecorder,
 	idGen shared.IDGenerator,
 	notionClient *notion.Service,
 ) *NotionOAuthUseCase {
@@ -47,6 +49,7 @@ func NewNotionOAuthUseCase(
 		repo:         repo,
 		clock:        clock,
 		txMgr:        txMgr,
+		events:       events,
 		idGen:        idGen,
 		notionClient: notionClient,
 	}
@@ -54,32 +57,32 @@ func NewNotionOAuthUseCase(
 
 // Execute completes the Notion OAuth flow and creates or updates a user
 func (uc *NotionOAuthUseCase) Execute(ctx context.Context, req NotionOAuthRequest) (NotionOAuthResponse, error) {
-	var response NotionOAuthResponse
-
-	err := uc.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
-		// Exchange code for token
-		tokenResp, err := uc.notionClient.ExchangeCodeForToken(req.Code)
-		if err != nil {
-			return fmt.Errorf("failed to exchange code for token ( us ): %w", err)
-		}
+	// Notion is called before the transaction, so no connection is held open while
+	// the client waits on its rate limiter or retries
+	tokenResp, err := uc.notionClient.ExchangeCodeForToken(ctx, req.Code)
+	if err != nil {
+		return NotionOAuthResponse{}, fmt.Errorf("failed to exchange code for token ( us ): %w", err)
+	}
 
-		// Get user info from Notion
-		log.Println("tokenResp", tokenResp)
-		notionUser, err := uc.notionClient.GetCurrentUser(tokenResp.AccessToken)
-		if err != nil {
-			return fmt.Errorf("failed to get user info ( us ): %w", err)
-		}
+	// Get user info from Notion
+	log.Println("tokenResp", tokenResp)
+	notionUser, err := uc.notionClient.GetCurrentUser(ctx, tokenResp.AccessToken)
+	if err != nil {
+		return NotionOAuthResponse{}, fmt.Errorf("failed to get user info ( us ): %w", err)
+	}
 
-		// Extract email from person object
-		email := ""
-		if notionUser.Person != nil && notionUser.Person.Email != "" {
-			email = notionUser.Person.Email
-		} else if notionUser.Bot != nil && notionUser.Bot.Owner.User.Person != nil && notionUser.Bot.Owner.User.Person.Email != "" {
-			email = notionUser.Bot.Owner.User.Person.Email
-		} else {
-			return fmt.Errorf("no email available from Notion user")
-		}
+	// Extract email from person object
+	email := ""
+	if notionUser.Person != nil && notionUser.Person.Email != "" {
+		email = notionUser.Person.Email
+	} else if notionUser.Bot != nil && notionUser.Bot.Owner.User.Person != nil && notionUser.Bot.Owner.User.Person.Email != "" {
+		email = notionUser.Bot.Owner.User.Person.Email
+	} else {
+		return NotionOAuthResponse{}, fmt.Errorf("no email available from Notion user")
+	}
 
+	var response NotionOAuthResponse
+	err = uc.txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
 		// Try to find existing user by email
 		user, err := uc.repo.GetByEmail(ctx, email)
 		if err == domain.ErrUserNotFound {
@@ -103,6 +106,10 @@ func (uc *NotionOAuthUseCase) Execute(ctx context.Context, req NotionOAuthReques
 			if err != nil {
 				return fmt.Errorf("failed to save new user: %w", err)
 			}
+
+			if err := uc.events.Record(ctx, domain.NewUserCreated(user)); err != nil {
+				return fmt.Errorf("failed to record user created event: %w", err)
+			}
 		} else if err != nil {
 			return fmt.Errorf("failed to check existing user: %w", err)
 		} else {
@@ -128,6 +135,10 @@ func (uc *NotionOAuthUseCase) Execute(ctx context.Context, req NotionOAuthReques
 			}
 		}
 
+		if err := uc.events.Record(ctx, domain.NewUserNotionConnected(user)); err != nil {
+			return fmt.Errorf("failed to record notion connected event: %w", err)
+		}
+
 		// Generate JWT token for the user
 		jwtToken, err := middleware.GenerateJWTToken(user.ID)
 		if err != nil {
@@ -24,9 +24,10 @@ type UpdateNotionTokenResponse struct {
 
 // UpdateNotionTokenUseCase handles updating user's Notion token
 type UpdateNotionTokenUseCase struct {
-	repo  domain.UserRepository
-	clock shared.Clock
-	txMgr shared.TransactionManager
+	repo   domain.UserRepository
+	clock  shared.Clock
+	txMgr  shared.TransactionManager
+	events shared.EventRecorder
 }
 
 // NewUpdateNotionTokenUseCase creates a new UpdateNotionTokenUseCase
@@ -34,11 +35,13 @@ func NewUpdateNotionTokenUseCase(
 	repo domain.UserRepository,
 	clock shared.Clock,
 	txMgr shared.TransactionManager,
+	events shared.EventRecorder,
 ) *UpdateNotionTokenUseCase {
 	return &UpdateNotionTokenUseCase{
-		repo:  repo,
-		clock: clock,
-		txMgr: txMgr,
+		repo:   repo,
+		clock:  clock,
+		txMgr:  txMgr,
+		events: events,
 	}
 }
 
@@ -62,6 +65,10 @@ func (uc *UpdateNotionTokenUseCase) Execute(ctx context.Context, req UpdateNotio
 			return err
 		}
 
+		if err := uc.events.Record(ctx, domain.NewUserNotionConnected(updatedUser)); err != nil {
+			return err
+		}
+
 		response = UpdateNotionTokenResponse{User: updatedUser}
 		return nil
 	})
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserCreatedTopic         = "users.user_created"
+	UserNotionConnectedTopic = "users.notion_connected"
+)
+
+// UserCreated is emitted when a new user is stored
+type UserCreated struct {
+	UserID    uuid.UUID `json:"user_id"`
+	PublicID  string    `json:"public_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EventTopic implements shared.Event
+func (UserCreated) EventTopic() string {
+	return UserCreatedTopic
+}
+
+// NewUserCreated builds the UserCreated event for the given user
+func NewUserCreated(user User) UserCreated {
+	return UserCreated{
+		UserID:    user.ID,
+		PublicID:  user.PublicID,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+// UserNotionConnected is emitted when a user's Notion token is stored or refreshed.
+// The access token itself is never part of the event.
+type UserNotionConnected struct {
+	UserID            uuid.UUID `json:"user_id"`
+	PublicID          string    `json:"public_id"`
+	NotionWorkspaceID string    `json:"notion_workspace_id"`
+	NotionBotID       string    `json:"notion_bot_id"`
+	ConnectedAt       time.Time `json:"connected_at"`
+}
+
+// EventTopic implements shared.Event
+func (UserNotionConnected) EventTopic() string {
+	return UserNotionConnectedTopic
+}
+
+// NewUserNotionConnected builds the UserNotionConnected event for the given user
+func NewUserNotionConnected(user User) UserNotionConnected {
+	return UserNotionConnected{
+		UserID:            user.ID,
+		PublicID:          user.PublicID,
+		NotionWorkspaceID: user.NotionWorkspaceID,
+		NotionBotID:       user.NotionBotID,
+		ConnectedAt:       user.UpdatedAt,
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 // UserRepository defines the interface for user data access
 type UserRepository interface {
@@ -10,6 +14,9 @@ type UserRepository interface {
 	// GetByID retrieves a user by ID
 	GetByID(ctx context.Context, id string) (User, error)
 
+	// GetByUUID retrieves a user by internal UUID, as referenced by other modules
+	GetByUUID(ctx context.Context, id uuid.UUID) (User, error)
+
 	// GetByEmail retrieves a user by email
 	GetByEmail(ctx context.Context, email string) (User, error)
 
@@ -3,8 +3,10 @@ package postgres
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"src/internal/database"
 	"src/internal/modules/users/domain"
 )
 
@@ -22,7 +24,7 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 func (r *UserRepository) Create(ctx context.Context, user domain.User) (domain.User, error) {
 	record := toUserRecord(user)
 
-	if err := r.db.WithContext(ctx).Create(&record).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Create(&record).Error; err != nil {
 		return domain.User{}, err
 	}
 
@@ -33,7 +35,22 @@ func (r *UserRepository) Create(ctx context.Context, user domain.User) (domain.U
 func (r *UserRepository) GetByID(ctx context.Context, id string) (domain.User, error) {
 	var record UserRecord
 
-	err := r.db.WithContext(ctx).Where("public_id = ?", id).First(&record).Error
+	err := database.Conn(ctx, r.db).Where("public_id = ?", id).First(&record).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return domain.User{}, domain.ErrUserNotFound
+		}
+		return domain.User{}, err
+	}
+
+	return toDomainUser(record), nil
+}
+
+// GetByUUID retrieves a user by internal UUID from the database
+func (r *UserRepository) GetByUUID(ctx context.Context, id uuid.UUID) (domain.User, error) {
+	var record UserRecord
+
+	err := database.Conn(ctx, r.db).Where("id = ?", id).First(&record).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return domain.User{}, domain.ErrUserNotFound
@@ -48,7 +65,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (domain.User, e
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (domain.User, error) {
 	var record UserRecord
 
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&record).Error
+	err := database.Conn(ctx, r.db).Where("email = ?", email).First(&record).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return domain.User{}, domain.ErrUserNotFound
@@ -63,7 +80,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (domain.U
 func (r *UserRepository) Update(ctx context.Context, user domain.User) (domain.User, error) {
 	record := toUserRecord(user)
 
-	err := r.db.WithContext(ctx).Save(&record).Error
+	err := database.Conn(ctx, r.db).Save(&record).Error
 	if err != nil {
 		return domain.User{}, err
 	}
@@ -73,7 +90,7 @@ func (r *UserRepository) Update(ctx context.Context, user domain.User) (domain.U
 
 // Delete removes a user from the database
 func (r *UserRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Where("public_id = ?", id).Delete(&UserRecord{})
+	result := database.Conn(ctx, r.db).Where("public_id = ?", id).Delete(&UserRecord{})
 
 	if result.Error != nil {
 		return result.Error
@@ -90,7 +107,7 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 func (r *UserRepository) List(ctx context.Context, offset, limit int) ([]domain.User, error) {
 	var records []UserRecord
 
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Offset(offset).
 		Limit(limit).
 		Order("created_at DESC").
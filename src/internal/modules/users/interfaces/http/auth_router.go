@@ -13,18 +13,22 @@ import (
 	"src/internal/modules/users/infrastructure/postgres"
 	"src/internal/pkg/httpx"
 	"src/internal/pkg/notion"
+	"src/internal/pkg/outbox"
 )
 
-// NewAuthRouter creates a new HTTP router for authentication endpoints
-func NewAuthRouter() chi.Router {
+// NewAuthRouter creates a new HTTP router for authentication endpoints.
+// notionOpts configure the Notion client, e.g. a rate limiter shared across processes.
+func NewAuthRouter(notionOpts ...notion.ClientOption) chi.Router {
 	r := chi.NewRouter()
 
 	// Initialize dependencies
 	cfg := config.Get()
-	repo := postgres.NewUserRepository(database.GormDB())
+	db := database.GormDB()
+	repo := postgres.NewUserRepository(db)
 	idGen := shared.NewUUIDGenerator()
 	clock := shared.NewSystemClock()
-	txMgr := shared.NewNoopTransactionManager()
+	txMgr := database.NewTransactionManager(db)
+	events := outbox.New(db)
 
 	// Initialize Notion service
 	notionService := notion.NewService(notion.ServiceConfig{
@@ -32,11 +36,11 @@ func NewAuthRouter() chi.Router {
 		ClientSecret: cfg.Notion.ClientSecret,
 		RedirectURI:  cfg.Notion.RedirectURL,
 		APIVersion:   cfg.Notion.APIVersion,
-	})
+	}, notionOpts...)
 
 	// Initialize use cases
 	getAuthURLUC := application.NewGetAuthorizationURLUseCase(notionService)
-	notionOAuthUC := application.NewNotionOAuthUseCase(repo, clock, txMgr, idGen, notionService)
+	notionOAuthUC := application.NewNotionOAuthUseCase(repo, clock, txMgr, events, idGen, notionService)
 
 	// Notion OAuth routes
 	r.Route("/notion", func(r chi.Router) {
@@ -11,6 +11,7 @@ import (
 	"src/internal/modules/users/domain"
 	"src/internal/modules/users/infrastructure/postgres"
 	"src/internal/pkg/httpx"
+	"src/internal/pkg/outbox"
 )
 
 // NewRouter creates a new HTTP router for the users module
@@ -18,13 +19,15 @@ func NewRouter() chi.Router {
 	r := chi.NewRouter()
 
 	// Initialize dependencies
-	repo := postgres.NewUserRepository(database.GormDB())
+	db := database.GormDB()
+	repo := postgres.NewUserRepository(db)
 	idGen := shared.NewUUIDGenerator()
 	clock := shared.NewSystemClock()
-	txMgr := shared.NewNoopTransactionManager()
+	txMgr := database.NewTransactionManager(db)
+	events := outbox.New(db)
 
 	// Initialize use cases
-	createUserUC := application.NewCreateUserUseCase(repo, idGen, clock, txMgr)
+	createUserUC := application.NewCreateUserUseCase(repo, idGen, clock, txMgr, events)
 	getUserUC := application.NewGetUserUseCase(repo)
 	getUserByEmailUC := application.NewGetUserByEmailUseCase(repo)
 
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/modules/webhooks/domain"
+)
+
+// ProjectFinder is the subset of the projects repository used to check project ownership
+type ProjectFinder interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*projectsDomain.Project, error)
+	FindByPublicID(ctx context.Context, publicID string) (*projectsDomain.Project, error)
+}
+
+// ListDeliveriesRequest contains the filters for listing a project's deliveries
+type ListDeliveriesRequest struct {
+	UserID           uuid.UUID
+	ProjectPublicID  string
+	NotionEventType  string
+	SignatureVerdict domain.SignatureVerdict
+	PublishOutcome   domain.PublishOutcome
+	Since            *time.Time
+	Until            *time.Time
+	Limit            int
+	Offset           int
+}
+
+// ListDeliveriesResponse contains the matching deliveries
+type ListDeliveriesResponse struct {
+	Deliveries []*domain.Delivery
+}
+
+// ReplayDeliveryResponse contains the replayed delivery and the newly published event
+type ReplayDeliveryResponse struct {
+	Delivery *domain.Delivery
+	Event    *domain.WebhookEvent
+}
+
+// DeliveryService handles inspection and replay of stored webhook deliveries
+type DeliveryService struct {
+	repo        domain.DeliveryRepository
+	projects    ProjectFinder
+	publisher   domain.WebhookEventPublisher
+	clock       shared.Clock
+	idGenerator shared.IDGenerator
+}
+
+// NewDeliveryService creates a new delivery service
+func NewDeliveryService(
+	repo domain.DeliveryRepository,
+	projects ProjectFinder,
+	publisher domain.WebhookEventPublisher,
+	clock shared.Clock,
+	idGenerator shared.IDGenerator,
+) *DeliveryService {
+	return &DeliveryService{
+		repo:        repo,
+		projects:    projects,
+		publisher:   publisher,
+		clock:       clock,
+		idGenerator: idGenerator,
+	}
+}
+
+// ListDeliveries lists deliveries of a project owned by the user, newest first
+func (s *DeliveryService) ListDeliveries(ctx context.Context, req ListDeliveriesRequest) (ListDeliveriesResponse, error) {
+	project, err := s.projects.FindByPublicID(ctx, req.ProjectPublicID)
+	if err != nil {
+		return ListDeliveriesResponse{}, err
+	}
+	if project.UserID != req.UserID {
+		return ListDeliveriesResponse{}, projectsDomain.ErrProjectNotFound
+	}
+
+	deliveries, err := s.repo.List(ctx, domain.DeliveryFilter{
+		ProjectID:        project.ID,
+		NotionEventType:  req.NotionEventType,
+		SignatureVerdict: req.SignatureVerdict,
+		PublishOutcome:   req.PublishOutcome,
+		Since:            req.Since,
+		Until:            req.Until,
+		Limit:            req.Limit,
+		Offset:           req.Offset,
+	})
+	if err != nil {
+		return ListDeliveriesResponse{}, err
+	}
+
+	return ListDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+// GetDelivery retrieves a delivery that belongs to one of the user's projects
+func (s *DeliveryService) GetDelivery(ctx context.Context, userID, deliveryID uuid.UUID) (*domain.Delivery, error) {
+	delivery, err := s.repo.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deliveries that never resolved to a project are not visible through the API
+	if delivery.ProjectID == nil {
+		return nil, domain.ErrDeliveryNotFound
+	}
+
+	project, err := s.projects.FindByID(ctx, *delivery.ProjectID)
+	if err == projectsDomain.ErrProjectNotFound {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if project.UserID != userID {
+		return nil, domain.ErrDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+// ReplayDelivery publishes a stored delivery onto the event bus again.
+// Replays bypass deduplication on purpose.
+func (s *DeliveryService) ReplayDelivery(ctx context.Context, userID, deliveryID uuid.UUID) (ReplayDeliveryResponse, error) {
+	delivery, err := s.GetDelivery(ctx, userID, deliveryID)
+	if err != nil {
+		return ReplayDeliveryResponse{}, err
+	}
+
+	if delivery.SignatureVerdict != domain.SignatureVerdictValid || delivery.Classification == domain.WebhookEventTypeVerification {
+		return ReplayDeliveryResponse{}, domain.ErrDeliveryNotReplayable
+	}
+
+	event := domain.NewWebhookEvent(
+		s.idGenerator.NewID("webhook"),
+		delivery.Payload,
+		domain.WebhookEventTypeRegular,
+	)
+	event.ProjectID = delivery.ProjectID.String()
+
+	if err := s.publisher.PublishEvent(event); err != nil {
+		return ReplayDeliveryResponse{}, err
+	}
+
+	now := s.clock.Now()
+	if err := s.repo.MarkReplayed(ctx, delivery.ID, now); err != nil {
+		return ReplayDeliveryResponse{}, err
+	}
+	delivery.ReplayCount++
+	delivery.LastReplayedAt = &now
+
+	return ReplayDeliveryResponse{Delivery: delivery, Event: event}, nil
+}
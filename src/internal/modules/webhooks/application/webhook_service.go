@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	shared "src/internal/modules/shared/domain"
 	"src/internal/modules/webhooks/domain"
@@ -12,39 +13,60 @@ import (
 type WebhookProcessingRequest struct {
 	Payload        domain.WebhookPayload
 	Signature      *domain.WebhookSignature
+	ProjectID      string
 	RequestContext context.Context
 }
 
 // WebhookProcessingResponse represents the response from webhook processing
 type WebhookProcessingResponse struct {
-	Event   *domain.WebhookEvent
-	Message string
-	Success bool
+	Event     *domain.WebhookEvent
+	Message   string
+	Success   bool
+	Duplicate bool
 }
 
+// maxClockSkew is how far in the future an event timestamp may be
+const maxClockSkew = 5 * time.Minute
+
 // WebhookService handles webhook processing use cases
 type WebhookService struct {
-	validator   domain.WebhookValidator
-	classifier  domain.WebhookRequestClassifier
-	publisher   domain.WebhookEventPublisher
-	idGenerator shared.IDGenerator
+	validator    domain.WebhookValidator
+	classifier   domain.WebhookRequestClassifier
+	publisher    domain.WebhookEventPublisher
+	deduplicator domain.WebhookDeduplicator
+	clock        shared.Clock
+	replayWindow time.Duration
+	idGenerator  shared.IDGenerator
 }
 
-// NewWebhookService creates a new webhook service
+// NewWebhookService creates a new webhook service.
+// Events older than replayWindow are rejected; zero disables the check.
 func NewWebhookService(
 	validator domain.WebhookValidator,
 	classifier domain.WebhookRequestClassifier,
 	publisher domain.WebhookEventPublisher,
+	deduplicator domain.WebhookDeduplicator,
+	clock shared.Clock,
+	replayWindow time.Duration,
 	idGenerator shared.IDGenerator,
 ) *WebhookService {
 	return &WebhookService{
-		validator:   validator,
-		classifier:  classifier,
-		publisher:   publisher,
-		idGenerator: idGenerator,
+		validator:    validator,
+		classifier:   classifier,
+		publisher:    publisher,
+		deduplicator: deduplicator,
+		clock:        clock,
+		replayWindow: replayWindow,
+		idGenerator:  idGenerator,
 	}
 }
 
+// deliveryHeader holds the payload fields used for replay protection
+type deliveryHeader struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // ProcessWebhook processes a webhook request
 func (s *WebhookService) ProcessWebhook(ctx context.Context, req WebhookProcessingRequest) (WebhookProcessingResponse, error) {
 	// Validate signature
@@ -67,10 +89,41 @@ func (s *WebhookService) ProcessWebhook(ctx context.Context, req WebhookProcessi
 		req.Payload,
 		eventType,
 	)
+	event.ProjectID = req.ProjectID
 
 	// For verification events, don't publish to avoid unnecessary processing
 	if eventType != domain.WebhookEventTypeVerification {
+		var header deliveryHeader
+		_ = json.Unmarshal(req.Payload, &header) // Payloads without these fields skip the checks
+
+		if err := s.checkTimestamp(header.Timestamp); err != nil {
+			return WebhookProcessingResponse{Success: false}, err
+		}
+
+		// Notion retries reuse the event ID, so it identifies the delivery
+		if header.ID != "" {
+			duplicate, err := s.deduplicator.Claim(ctx, header.ID)
+			if err != nil {
+				return WebhookProcessingResponse{Success: false}, domain.WebhookProcessingError{
+					Code:    "DEDUPLICATION_FAILED",
+					Message: err.Error(),
+				}
+			}
+			if duplicate {
+				return WebhookProcessingResponse{
+					Event:     event,
+					Message:   "Duplicate webhook event ignored",
+					Success:   true,
+					Duplicate: true,
+				}, nil
+			}
+		}
+
 		if err := s.publisher.PublishEvent(event); err != nil {
+			// Let Notion's retry publish the event
+			if header.ID != "" {
+				_ = s.deduplicator.Release(ctx, header.ID)
+			}
 			return WebhookProcessingResponse{Success: false}, err
 		}
 	}
@@ -87,6 +140,19 @@ func (s *WebhookService) ProcessWebhook(ctx context.Context, req WebhookProcessi
 	}, nil
 }
 
+// checkTimestamp rejects events outside the replay window
+func (s *WebhookService) checkTimestamp(timestamp time.Time) error {
+	if s.replayWindow <= 0 || timestamp.IsZero() {
+		return nil
+	}
+
+	now := s.clock.Now()
+	if timestamp.Before(now.Add(-s.replayWindow)) || timestamp.After(now.Add(maxClockSkew)) {
+		return domain.ErrStaleTimestamp
+	}
+	return nil
+}
+
 // VerificationTokenResponse represents a verification token response
 type VerificationTokenResponse struct {
 	Token   string
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrDeliveryNotReplayable = errors.New("only deliveries with a valid signature that are not verification requests can be replayed")
+)
+
+// SignatureVerdict is the result of validating a delivery's signature
+type SignatureVerdict string
+
+const (
+	SignatureVerdictValid      SignatureVerdict = "valid"
+	SignatureVerdictInvalid    SignatureVerdict = "invalid"
+	SignatureVerdictMissing    SignatureVerdict = "missing"
+	SignatureVerdictNotChecked SignatureVerdict = "not_checked" // rejected before validation, e.g. unknown project
+)
+
+// PublishOutcome tells what happened to a delivery after validation
+type PublishOutcome string
+
+const (
+	PublishOutcomePublished PublishOutcome = "published"
+	PublishOutcomeDuplicate PublishOutcome = "duplicate"
+	PublishOutcomeSkipped   PublishOutcome = "skipped" // verification requests are never published
+	PublishOutcomeRejected  PublishOutcome = "rejected"
+	PublishOutcomeFailed    PublishOutcome = "failed"
+)
+
+// Delivery is one webhook request as received from Notion, with what we did with it
+type Delivery struct {
+	ID               uuid.UUID
+	ProjectID        *uuid.UUID // nil when the delivery did not resolve to a project
+	NotionEventID    string
+	NotionEventType  string
+	Headers          map[string]string
+	Payload          WebhookPayload
+	SignatureVerdict SignatureVerdict
+	Classification   WebhookEventType
+	PublishOutcome   PublishOutcome
+	Error            string
+	StatusCode       int
+	Latency          time.Duration
+	ReceivedAt       time.Time
+	ReplayCount      int
+	LastReplayedAt   *time.Time
+}
+
+// DeliveryFilter narrows down a delivery listing
+type DeliveryFilter struct {
+	ProjectID        uuid.UUID
+	NotionEventType  string
+	SignatureVerdict SignatureVerdict
+	PublishOutcome   PublishOutcome
+	Since            *time.Time
+	Until            *time.Time
+	Limit            int
+	Offset           int
+}
+
+// DeliveryRepository stores webhook deliveries
+type DeliveryRepository interface {
+	// Save persists a delivery
+	Save(ctx context.Context, delivery *Delivery) error
+
+	// FindByID retrieves a delivery by its ID
+	FindByID(ctx context.Context, id uuid.UUID) (*Delivery, error)
+
+	// List retrieves deliveries matching the filter, newest first
+	List(ctx context.Context, filter DeliveryFilter) ([]*Delivery, error)
+
+	// MarkReplayed records that a delivery was re-published
+	MarkReplayed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
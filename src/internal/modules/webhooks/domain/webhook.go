@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 // WebhookPayload represents the raw payload received from a webhook
 type WebhookPayload []byte
@@ -22,9 +25,10 @@ func (r VerificationRequest) IsVerification() bool {
 
 // WebhookEvent represents a processed webhook event
 type WebhookEvent struct {
-	ID      string
-	Payload WebhookPayload
-	Type    WebhookEventType
+	ID        string
+	ProjectID string // Internal project UUID, empty when the project could not be resolved
+	Payload   WebhookPayload
+	Type      WebhookEventType
 }
 
 // WebhookEventType represents the type of webhook event
@@ -58,6 +62,40 @@ func NewWebhookSignature(headerValue, secret string) *WebhookSignature {
 	}
 }
 
+// WebhookSecretSource tells which secret a delivery was validated with
+type WebhookSecretSource string
+
+const (
+	WebhookSecretSourceProject WebhookSecretSource = "project"
+	WebhookSecretSourceGlobal  WebhookSecretSource = "global"
+)
+
+// WebhookTarget is the project a webhook delivery is addressed to and the secret it is signed with
+type WebhookTarget struct {
+	ProjectID       string
+	ProjectPublicID string
+	Secret          string
+	Source          WebhookSecretSource
+}
+
+// WebhookSecretResolver resolves the project (and its webhook secret) a delivery belongs to
+type WebhookSecretResolver interface {
+	// ResolveByProjectPublicID resolves the project from a per-project webhook URL
+	ResolveByProjectPublicID(ctx context.Context, publicID string) (*WebhookTarget, error)
+
+	// ResolveByPayload resolves the project from the Notion database referenced in the payload
+	ResolveByPayload(ctx context.Context, payload WebhookPayload) (*WebhookTarget, error)
+}
+
+// WebhookDeduplicator remembers which deliveries were already accepted
+type WebhookDeduplicator interface {
+	// Claim records key and reports whether it had already been claimed
+	Claim(ctx context.Context, key string) (duplicate bool, err error)
+
+	// Release forgets key so a failed delivery can be accepted again
+	Release(ctx context.Context, key string) error
+}
+
 // WebhookValidator handles webhook signature validation
 type WebhookValidator interface {
 	ValidateSignature(signature *WebhookSignature, payload WebhookPayload) error
@@ -89,4 +127,7 @@ var (
 	ErrMissingSignature = WebhookProcessingError{Code: "MISSING_SIGNATURE", Message: "Missing webhook signature header"}
 	ErrInvalidPayload   = WebhookProcessingError{Code: "INVALID_PAYLOAD", Message: "Invalid webhook payload"}
 	ErrProcessingFailed = WebhookProcessingError{Code: "PROCESSING_FAILED", Message: "Failed to process webhook"}
+	ErrProjectNotFound  = WebhookProcessingError{Code: "PROJECT_NOT_FOUND", Message: "No project matches the webhook"}
+	ErrSecretMissing    = WebhookProcessingError{Code: "SECRET_NOT_CONFIGURED", Message: "Webhook secret not configured"}
+	ErrStaleTimestamp   = WebhookProcessingError{Code: "STALE_TIMESTAMP", Message: "Webhook timestamp outside the accepted window"}
 )
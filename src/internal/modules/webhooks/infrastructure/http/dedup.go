@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultDedupTTL       = 24 * time.Hour
+	redisDedupKeyPrefix   = "webhooks:notion:delivery:"
+	memoryDedupSweepEvery = 1024
+)
+
+// RedisDeduplicator implements WebhookDeduplicator with SET NX and a TTL,
+// so every API instance shares the same view of accepted deliveries
+type RedisDeduplicator struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisDeduplicator creates a new Redis-backed deduplicator
+func NewRedisDeduplicator(client *redis.Client, ttl time.Duration) *RedisDeduplicator {
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+	return &RedisDeduplicator{client: client, ttl: ttl}
+}
+
+// Claim records key and reports whether it had already been claimed
+func (d *RedisDeduplicator) Claim(ctx context.Context, key string) (bool, error) {
+	claimed, err := d.client.SetNX(ctx, redisDedupKeyPrefix+key, 1, d.ttl).Result()
+	if err != nil {
+		return false, err
+	}
+	return !claimed, nil
+}
+
+// Release forgets key so a failed delivery can be accepted again
+func (d *RedisDeduplicator) Release(ctx context.Context, key string) error {
+	return d.client.Del(ctx, redisDedupKeyPrefix+key).Err()
+}
+
+// MemoryDeduplicator implements WebhookDeduplicator in process memory.
+// It only deduplicates deliveries that reach the same instance; use
+// RedisDeduplicator when running more than one API process.
+type MemoryDeduplicator struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	seen   map[string]time.Time
+	claims int
+}
+
+// NewMemoryDeduplicator creates a new in-memory deduplicator
+func NewMemoryDeduplicator(ttl time.Duration) *MemoryDeduplicator {
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+	return &MemoryDeduplicator{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+// Claim records key and reports whether it had already been claimed
+func (d *MemoryDeduplicator) Claim(ctx context.Context, key string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+
+	// Drop expired keys now and then so the map does not grow forever
+	d.claims++
+	if d.claims%memoryDedupSweepEvery == 0 {
+		for k, expiresAt := range d.seen {
+			if now.After(expiresAt) {
+				delete(d.seen, k)
+			}
+		}
+	}
+
+	if expiresAt, ok := d.seen[key]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+
+	d.seen[key] = now.Add(d.ttl)
+	return false, nil
+}
+
+// Release forgets key so a failed delivery can be accepted again
+func (d *MemoryDeduplicator) Release(ctx context.Context, key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.seen, key)
+	return nil
+}
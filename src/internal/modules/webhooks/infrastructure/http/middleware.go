@@ -3,9 +3,16 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"io"
+	"log"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
 	"src/internal/config"
 	"src/internal/modules/webhooks/domain"
 	"src/internal/pkg/httpx"
@@ -14,37 +21,57 @@ import (
 type contextKey string
 
 const (
-	webhookBodyKey contextKey = "webhook_body"
+	webhookBodyKey   contextKey = "webhook_body"
+	webhookTargetKey contextKey = "webhook_target"
+
+	// ProjectPublicIDParam is the URL parameter of per-project webhook routes
+	ProjectPublicIDParam = "projectPublicID"
 )
 
-// WebhookMiddleware validates webhook signature and stores raw body in context
+// WebhookMiddleware resolves the target project, validates the webhook signature
+// with its secret and stores the raw body and target in context
 type WebhookMiddleware struct {
 	validator domain.WebhookValidator
+	resolver  domain.WebhookSecretResolver
+	logger    *log.Logger
 }
 
-// NewWebhookMiddleware creates a new webhook middleware
-func NewWebhookMiddleware(validator domain.WebhookValidator) *WebhookMiddleware {
+// NewWebhookMiddleware creates a new webhook middleware.
+// When resolver is nil every delivery is validated with the global secret.
+func NewWebhookMiddleware(validator domain.WebhookValidator, resolver domain.WebhookSecretResolver, logger *log.Logger) *WebhookMiddleware {
 	return &WebhookMiddleware{
 		validator: validator,
+		resolver:  resolver,
+		logger:    logger,
 	}
 }
 
 // Handler returns the HTTP handler for webhook validation
 func (m *WebhookMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		cfg := config.Get()
-		if cfg.Notion.WebhookSecret == "" {
-			httpx.WriteJSON(w, http.StatusInternalServerError, map[string]string{
-				"error": "Webhook secret not configured",
+		// Read the raw body
+		body, err := readRequestBody(r)
+		if err != nil {
+			httpx.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "Failed to read request body",
 			})
 			return
 		}
 
-		// Read the raw body
-		body, err := m.readRequestBody(r)
+		// Resolve the project and the secret the delivery must be signed with
+		target, err := m.resolveTarget(r, body)
 		if err != nil {
-			httpx.WriteJSON(w, http.StatusBadRequest, map[string]string{
-				"error": "Failed to read request body",
+			m.audit(r, body, target, err)
+			AnnotateDelivery(r.Context(), func(d *domain.Delivery) {
+				d.Error = err.Error()
+			})
+
+			statusCode := http.StatusInternalServerError
+			if errors.Is(err, domain.ErrProjectNotFound) {
+				statusCode = http.StatusNotFound
+			}
+			httpx.WriteJSON(w, statusCode, map[string]string{
+				"error": err.Error(),
 			})
 			return
 		}
@@ -52,11 +79,21 @@ func (m *WebhookMiddleware) Handler(next http.Handler) http.Handler {
 		// Create signature for validation
 		signature := domain.NewWebhookSignature(
 			r.Header.Get("X-Notion-Signature"),
-			cfg.Notion.WebhookSecret,
+			target.Secret,
 		)
 
 		// Validate signature
 		if err := m.validator.ValidateSignature(signature, body); err != nil {
+			m.audit(r, body, target, err)
+			AnnotateDelivery(r.Context(), func(d *domain.Delivery) {
+				d.ProjectID = targetProjectID(target)
+				d.SignatureVerdict = domain.SignatureVerdictInvalid
+				if err == domain.ErrMissingSignature {
+					d.SignatureVerdict = domain.SignatureVerdictMissing
+				}
+				d.Error = err.Error()
+			})
+
 			statusCode := http.StatusUnauthorized
 			if err == domain.ErrInvalidPayload {
 				statusCode = http.StatusBadRequest
@@ -68,14 +105,87 @@ func (m *WebhookMiddleware) Handler(next http.Handler) http.Handler {
 			return
 		}
 
-		// Signature is valid, store raw body in context
+		AnnotateDelivery(r.Context(), func(d *domain.Delivery) {
+			d.ProjectID = targetProjectID(target)
+			d.SignatureVerdict = domain.SignatureVerdictValid
+		})
+
+		// Signature is valid, store raw body and target in context
 		ctx := context.WithValue(r.Context(), webhookBodyKey, body)
+		ctx = context.WithValue(ctx, webhookTargetKey, target)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// resolveTarget finds the secret for the request. A per-project URL must match a
+// project; otherwise the project is looked up from the payload and the global
+// secret is used as a fallback when no project matches.
+func (m *WebhookMiddleware) resolveTarget(r *http.Request, body domain.WebhookPayload) (*domain.WebhookTarget, error) {
+	if publicID := chi.URLParam(r, ProjectPublicIDParam); publicID != "" {
+		if m.resolver == nil {
+			return nil, domain.ErrProjectNotFound
+		}
+		return m.resolver.ResolveByProjectPublicID(r.Context(), publicID)
+	}
+
+	if m.resolver != nil {
+		target, err := m.resolver.ResolveByPayload(r.Context(), body)
+		if err == nil {
+			return target, nil
+		}
+		if !errors.Is(err, domain.ErrProjectNotFound) {
+			return nil, err
+		}
+	}
+
+	cfg := config.Get()
+	if cfg.Notion.WebhookSecret == "" {
+		return nil, domain.ErrSecretMissing
+	}
+
+	return &domain.WebhookTarget{
+		Secret: cfg.Notion.WebhookSecret,
+		Source: domain.WebhookSecretSourceGlobal,
+	}, nil
+}
+
+// targetProjectID returns the project UUID of target, or nil for the global secret
+func targetProjectID(target *domain.WebhookTarget) *uuid.UUID {
+	if target == nil || target.ProjectID == "" {
+		return nil
+	}
+	id, err := uuid.Parse(target.ProjectID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// audit records a rejected delivery with enough detail to trace it later.
+// The secret and signature value are never logged.
+func (m *WebhookMiddleware) audit(r *http.Request, body domain.WebhookPayload, target *domain.WebhookTarget, reason error) {
+	projectPublicID := chi.URLParam(r, ProjectPublicIDParam)
+	source := "none"
+	if target != nil {
+		projectPublicID = target.ProjectPublicID
+		source = string(target.Source)
+	}
+
+	bodyHash := sha256.Sum256(body)
+	m.logger.Printf(
+		"webhook rejected: reason=%q project=%q secret_source=%s path=%s remote_addr=%s signature_present=%t body_sha256=%s",
+		reason.Error(),
+		projectPublicID,
+		source,
+		r.URL.Path,
+		r.RemoteAddr,
+		r.Header.Get("X-Notion-Signature") != "",
+		hex.EncodeToString(bodyHash[:]),
+	)
+}
+
 // readRequestBody reads the request body and restores it
-func (m *WebhookMiddleware) readRequestBody(r *http.Request) (domain.WebhookPayload, error) {
+func readRequestBody(r *http.Request) (domain.WebhookPayload, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		return nil, err
@@ -98,3 +208,15 @@ func GetWebhookBody(ctx context.Context) (domain.WebhookPayload, error) {
 	}
 	return body, nil
 }
+
+// GetWebhookTarget retrieves the resolved webhook target from request context
+func GetWebhookTarget(ctx context.Context) (*domain.WebhookTarget, error) {
+	target, ok := ctx.Value(webhookTargetKey).(*domain.WebhookTarget)
+	if !ok {
+		return nil, domain.WebhookProcessingError{
+			Code:    "CONTEXT_ERROR",
+			Message: "webhook target not found in context",
+		}
+	}
+	return target, nil
+}
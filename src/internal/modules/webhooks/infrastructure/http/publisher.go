@@ -29,7 +29,8 @@ func NewWatermillEventPublisher(publisher message.Publisher, logger *log.Logger)
 func (p *WatermillEventPublisher) PublishEvent(event *domain.WebhookEvent) error {
 	// Convert domain event to shared event
 	sharedEvent := sharedEvents.NotionWebhookReceived{
-		Payload: []byte(event.Payload),
+		ProjectID: event.ProjectID,
+		Payload:   []byte(event.Payload),
 	}
 
 	eventBytes, err := json.Marshal(sharedEvent)
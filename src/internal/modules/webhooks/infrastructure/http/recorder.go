@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/webhooks/domain"
+)
+
+const webhookDeliveryKey contextKey = "webhook_delivery"
+
+// recordedHeaders are stored with every delivery; anything else is dropped
+var recordedHeaders = []string{
+	"Content-Type",
+	"User-Agent",
+	"X-Notion-Signature",
+	"X-Forwarded-For",
+	"X-Request-Id",
+}
+
+// DeliveryRecorder stores every webhook request in the delivery log.
+// It wraps WebhookMiddleware; later stages fill in the verdict and outcome with
+// AnnotateDelivery. Failing to store a delivery never fails the request.
+type DeliveryRecorder struct {
+	repo   domain.DeliveryRepository
+	logger *log.Logger
+}
+
+// NewDeliveryRecorder creates a new delivery recorder
+func NewDeliveryRecorder(repo domain.DeliveryRepository, logger *log.Logger) *DeliveryRecorder {
+	return &DeliveryRecorder{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Handler returns the HTTP handler that records deliveries
+func (rec *DeliveryRecorder) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		body, err := readRequestBody(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		delivery := &domain.Delivery{
+			ID:               uuid.New(),
+			Headers:          make(map[string]string),
+			Payload:          body,
+			SignatureVerdict: domain.SignatureVerdictNotChecked,
+			ReceivedAt:       start.UTC(),
+		}
+		for _, name := range recordedHeaders {
+			if value := r.Header.Get(name); value != "" {
+				delivery.Headers[name] = value
+			}
+		}
+
+		var event struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(body, &event) == nil {
+			delivery.NotionEventID = event.ID
+			delivery.NotionEventType = event.Type
+		}
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		ctx := context.WithValue(r.Context(), webhookDeliveryKey, delivery)
+		next.ServeHTTP(sw, r.WithContext(ctx))
+
+		delivery.StatusCode = sw.status
+		delivery.Latency = time.Since(start)
+		if delivery.PublishOutcome == "" {
+			delivery.PublishOutcome = domain.PublishOutcomeRejected
+			if sw.status < http.StatusBadRequest {
+				delivery.PublishOutcome = domain.PublishOutcomeSkipped
+			}
+		}
+
+		// The request context may already be cancelled once the response is written
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), 5*time.Second)
+		defer cancel()
+		if err := rec.repo.Save(saveCtx, delivery); err != nil {
+			rec.logger.Printf("Failed to record webhook delivery %s: %v", delivery.ID, err)
+		}
+	})
+}
+
+// AnnotateDelivery updates the delivery being recorded for this request, if any
+func AnnotateDelivery(ctx context.Context, fn func(delivery *domain.Delivery)) {
+	if delivery, ok := ctx.Value(webhookDeliveryKey).(*domain.Delivery); ok {
+		fn(delivery)
+	}
+}
+
+// statusWriter captures the status code written by the handler
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/webhooks/domain"
+)
+
+// ProjectLookup is the subset of the projects repository used to resolve webhook targets
+type ProjectLookup interface {
+	FindByPublicID(ctx context.Context, publicID string) (*projectsDomain.Project, error)
+	FindByNotionDatabaseID(ctx context.Context, notionDatabaseID string) (*projectsDomain.Project, error)
+}
+
+// ProjectSecretResolver implements WebhookSecretResolver using Project.NotionWebhookSecret
+type ProjectSecretResolver struct {
+	projects ProjectLookup
+}
+
+// NewProjectSecretResolver creates a new project-backed secret resolver
+func NewProjectSecretResolver(projects ProjectLookup) *ProjectSecretResolver {
+	return &ProjectSecretResolver{projects: projects}
+}
+
+// ResolveByProjectPublicID resolves the project from a per-project webhook URL
+func (r *ProjectSecretResolver) ResolveByProjectPublicID(ctx context.Context, publicID string) (*domain.WebhookTarget, error) {
+	project, err := r.projects.FindByPublicID(ctx, publicID)
+	if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+		return nil, domain.ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return projectTarget(project), nil
+}
+
+// ResolveByPayload resolves the project from the Notion database the event refers to:
+// the entity itself for database events, or the parent database for page events
+func (r *ProjectSecretResolver) ResolveByPayload(ctx context.Context, payload domain.WebhookPayload) (*domain.WebhookTarget, error) {
+	var envelope struct {
+		Entity struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"entity"`
+		Data struct {
+			Parent struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			} `json:"parent"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	var databaseIDs []string
+	if envelope.Entity.Type == "database" {
+		databaseIDs = append(databaseIDs, envelope.Entity.ID)
+	}
+	if envelope.Data.Parent.Type == "database" {
+		databaseIDs = append(databaseIDs, envelope.Data.Parent.ID)
+	}
+
+	for _, databaseID := range databaseIDs {
+		for _, candidate := range notionIDVariants(databaseID) {
+			project, err := r.projects.FindByNotionDatabaseID(ctx, candidate)
+			if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return projectTarget(project), nil
+		}
+	}
+
+	return nil, domain.ErrProjectNotFound
+}
+
+func projectTarget(project *projectsDomain.Project) *domain.WebhookTarget {
+	return &domain.WebhookTarget{
+		ProjectID:       project.ID.String(),
+		ProjectPublicID: project.PublicID,
+		Secret:          project.NotionWebhookSecret,
+		Source:          domain.WebhookSecretSourceProject,
+	}
+}
+
+// notionIDVariants returns the ID as sent and with dashes removed or added,
+// because users paste Notion database IDs in both forms
+func notionIDVariants(id string) []string {
+	if id == "" {
+		return nil
+	}
+
+	compact := strings.ReplaceAll(id, "-", "")
+	if compact != id {
+		return []string{id, compact}
+	}
+	if len(compact) == 32 {
+		dashed := compact[:8] + "-" + compact[8:12] + "-" + compact[12:16] + "-" + compact[16:20] + "-" + compact[20:]
+		return []string{id, dashed}
+	}
+	return []string{id}
+}
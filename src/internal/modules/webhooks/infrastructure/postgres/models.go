@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"src/internal/modules/webhooks/domain"
+)
+
+// DeliveryRecord represents the webhook_deliveries table structure in PostgreSQL
+type DeliveryRecord struct {
+	ID               uuid.UUID         `gorm:"primaryKey;type:uuid"`
+	ProjectID        *uuid.UUID        `gorm:"type:uuid;index:idx_webhook_deliveries_project_received,priority:1"`
+	NotionEventID    string            `gorm:"type:varchar(255);index"`
+	NotionEventType  string            `gorm:"type:varchar(255)"`
+	Headers          map[string]string `gorm:"type:jsonb;serializer:json"`
+	Payload          []byte            `gorm:"type:bytea"` // Stored verbatim, it may not be valid JSON
+	SignatureVerdict string            `gorm:"not null;type:varchar(32)"`
+	Classification   string            `gorm:"type:varchar(32)"`
+	PublishOutcome   string            `gorm:"not null;type:varchar(32)"`
+	Error            string            `gorm:"type:text"`
+	StatusCode       int               `gorm:"not null"`
+	LatencyMs        int64             `gorm:"not null"`
+	ReceivedAt       time.Time         `gorm:"not null;index:idx_webhook_deliveries_project_received,priority:2,sort:desc"`
+	ReplayCount      int               `gorm:"not null;default:0"`
+	LastReplayedAt   *time.Time
+}
+
+// TableName specifies the table name for GORM
+func (DeliveryRecord) TableName() string {
+	return "webhook_deliveries"
+}
+
+// toDomainDelivery converts a DeliveryRecord to a domain Delivery
+func toDomainDelivery(record DeliveryRecord) domain.Delivery {
+	return domain.Delivery{
+		ID:               record.ID,
+		ProjectID:        record.ProjectID,
+		NotionEventID:    record.NotionEventID,
+		NotionEventType:  record.NotionEventType,
+		Headers:          record.Headers,
+		Payload:          record.Payload,
+		SignatureVerdict: domain.SignatureVerdict(record.SignatureVerdict),
+		Classification:   domain.WebhookEventType(record.Classification),
+		PublishOutcome:   domain.PublishOutcome(record.PublishOutcome),
+		Error:            record.Error,
+		StatusCode:       record.StatusCode,
+		Latency:          time.Duration(record.LatencyMs) * time.Millisecond,
+		ReceivedAt:       record.ReceivedAt,
+		ReplayCount:      record.ReplayCount,
+		LastReplayedAt:   record.LastReplayedAt,
+	}
+}
+
+// toDeliveryRecord converts a domain Delivery to a DeliveryRecord
+func toDeliveryRecord(delivery domain.Delivery) DeliveryRecord {
+	return DeliveryRecord{
+		ID:               delivery.ID,
+		ProjectID:        delivery.ProjectID,
+		NotionEventID:    delivery.NotionEventID,
+		NotionEventType:  delivery.NotionEventType,
+		Headers:          delivery.Headers,
+		Payload:          delivery.Payload,
+		SignatureVerdict: string(delivery.SignatureVerdict),
+		Classification:   string(delivery.Classification),
+		PublishOutcome:   string(delivery.PublishOutcome),
+		Error:            delivery.Error,
+		StatusCode:       delivery.StatusCode,
+		LatencyMs:        delivery.Latency.Milliseconds(),
+		ReceivedAt:       delivery.ReceivedAt,
+		ReplayCount:      delivery.ReplayCount,
+		LastReplayedAt:   delivery.LastReplayedAt,
+	}
+}
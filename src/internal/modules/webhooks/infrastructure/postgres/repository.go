@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"src/internal/database"
+	"src/internal/modules/webhooks/domain"
+)
+
+const (
+	defaultDeliveryListLimit = 50
+	maxDeliveryListLimit     = 200
+)
+
+// DeliveryRepository implements domain.DeliveryRepository using PostgreSQL/GORM
+type DeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliveryRepository creates a new PostgreSQL webhook delivery repository
+func NewDeliveryRepository(db *gorm.DB) *DeliveryRepository {
+	return &DeliveryRepository{db: db}
+}
+
+// Save persists a delivery
+func (r *DeliveryRepository) Save(ctx context.Context, delivery *domain.Delivery) error {
+	record := toDeliveryRecord(*delivery)
+	return database.Conn(ctx, r.db).Create(&record).Error
+}
+
+// FindByID retrieves a delivery by its ID
+func (r *DeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Delivery, error) {
+	var record DeliveryRecord
+
+	err := database.Conn(ctx, r.db).Where("id = ?", id).First(&record).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	delivery := toDomainDelivery(record)
+	return &delivery, nil
+}
+
+// List retrieves deliveries matching the filter, newest first
+func (r *DeliveryRepository) List(ctx context.Context, filter domain.DeliveryFilter) ([]*domain.Delivery, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultDeliveryListLimit
+	}
+	if limit > maxDeliveryListLimit {
+		limit = maxDeliveryListLimit
+	}
+
+	query := database.Conn(ctx, r.db).Where("project_id = ?", filter.ProjectID)
+	if filter.NotionEventType != "" {
+		query = query.Where("notion_event_type = ?", filter.NotionEventType)
+	}
+	if filter.SignatureVerdict != "" {
+		query = query.Where("signature_verdict = ?", filter.SignatureVerdict)
+	}
+	if filter.PublishOutcome != "" {
+		query = query.Where("publish_outcome = ?", filter.PublishOutcome)
+	}
+	if filter.Since != nil {
+		query = query.Where("received_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("received_at < ?", *filter.Until)
+	}
+
+	var records []DeliveryRecord
+	err := query.
+		Order("received_at DESC").
+		Limit(limit).
+		Offset(filter.Offset).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*domain.Delivery, 0, len(records))
+	for _, record := range records {
+		delivery := toDomainDelivery(record)
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+// MarkReplayed records that a delivery was re-published
+func (r *DeliveryRepository) MarkReplayed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := database.Conn(ctx, r.db).
+		Model(&DeliveryRecord{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"replay_count":     gorm.Expr("replay_count + 1"),
+			"last_replayed_at": at,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDeliveryNotFound
+	}
+	return nil
+}
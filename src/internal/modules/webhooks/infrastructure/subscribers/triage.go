@@ -0,0 +1,87 @@
+package subscribers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+
+	sharedEvents "src/internal/modules/shared/domain/events"
+)
+
+// WebhookTriageHandlerName is the Watermill handler name used by the event worker
+const WebhookTriageHandlerName = "webhook_triage"
+
+// WebhookTriage decodes raw NotionWebhookReceived events and republishes each one
+// as its typed event on a per-type topic (see sharedEvents.NotionEventType.Topic)
+type WebhookTriage struct {
+	publisher message.Publisher
+	logger    *log.Logger
+}
+
+// NewWebhookTriage creates a new webhook triage handler
+func NewWebhookTriage(publisher message.Publisher, logger *log.Logger) *WebhookTriage {
+	return &WebhookTriage{
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+// Register adds the triage handler to the router
+func (t *WebhookTriage) Register(router *message.Router, subscriber message.Subscriber) {
+	router.AddConsumerHandler(
+		WebhookTriageHandlerName,
+		sharedEvents.NotionWebhookReceivedTopic,
+		subscriber,
+		t.Handle,
+	)
+}
+
+// Handle processes a single NotionWebhookReceived message.
+// Payloads that can never be decoded are logged and acknowledged so they are not
+// redelivered forever; publish failures are returned so the message is retried.
+func (t *WebhookTriage) Handle(msg *message.Message) error {
+	var received sharedEvents.NotionWebhookReceived
+	if err := json.Unmarshal(msg.Payload, &received); err != nil {
+		t.logger.Printf("Dropping malformed webhook message %s: %v", msg.UUID, err)
+		return nil
+	}
+
+	event, err := sharedEvents.ParseNotionWebhookEvent(received.Payload)
+	if errors.Is(err, sharedEvents.ErrUnknownNotionEventType) {
+		t.logger.Printf("Ignoring webhook message %s: %v", msg.UUID, err)
+		return nil
+	}
+	if err != nil {
+		t.logger.Printf("Dropping invalid webhook message %s: %v", msg.UUID, err)
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.logger.Printf("Dropping webhook message %s: failed to marshal typed event: %v", msg.UUID, err)
+		return nil
+	}
+
+	// The Notion event ID keeps the outgoing UUID stable across redeliveries,
+	// so downstream consumers can deduplicate
+	meta := event.NotionEventMeta()
+	out := message.NewMessage(meta.ID, payload)
+	for key, value := range msg.Metadata {
+		out.Metadata.Set(key, value)
+	}
+	out.Metadata.Set("notion_event_type", string(meta.Type))
+	out.Metadata.Set("notion_workspace_id", meta.WorkspaceID)
+	if received.ProjectID != "" {
+		out.Metadata.Set(sharedEvents.ProjectIDMetadataKey, received.ProjectID)
+	}
+	out.SetContext(msg.Context())
+
+	if err := t.publisher.Publish(event.EventTopic(), out); err != nil {
+		return err
+	}
+
+	t.logger.Printf("Triaged webhook message %s as %s", msg.UUID, event.EventTopic())
+	return nil
+}
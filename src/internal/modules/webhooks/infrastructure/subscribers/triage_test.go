@@ -0,0 +1,170 @@
+package subscribers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sharedEvents "src/internal/modules/shared/domain/events"
+	"src/internal/modules/webhooks/infrastructure/subscribers"
+)
+
+const pagePropertiesUpdatedPayload = `{
+	"id": "367cba44-b6f3-4c92-81e7-6a2e9659efd4",
+	"timestamp": "2024-12-05T23:55:34.285Z",
+	"workspace_id": "13950b26-c203-4f3b-b97d-93ec06319565",
+	"workspace_name": "Quantify Labs",
+	"subscription_id": "29d75c0d-5546-4414-8459-7b7a92f1fc4b",
+	"integration_id": "0ef2e755-4912-8096-91c1-00376a88a5ca",
+	"type": "page.properties_updated",
+	"authors": [{"id": "c7c11cca-1d73-471d-9b6e-bdef51470190", "type": "person"}],
+	"attempt_number": 1,
+	"entity": {"id": "153104cd-477e-809d-8dc4-ff2d96ae3090", "type": "page"},
+	"data": {
+		"parent": {"id": "1a2b3c4d-477e-809d-8dc4-ff2d96ae3090", "type": "database"},
+		"updated_properties": ["XGe%40", "bDf%5B"]
+	}
+}`
+
+const commentCreatedPayload = `{
+	"id": "c6d1f0a2-4b0e-4a8b-9f1f-2c1d3e4f5a6b",
+	"timestamp": "2024-12-05T23:57:05.379Z",
+	"workspace_id": "13950b26-c203-4f3b-b97d-93ec06319565",
+	"type": "comment.created",
+	"authors": [{"id": "c7c11cca-1d73-471d-9b6e-bdef51470190", "type": "person"}],
+	"attempt_number": 1,
+	"entity": {"id": "15510b26-c203-4f3b-b97d-93ec06319565", "type": "comment"},
+	"data": {
+		"page_id": "153104cd-477e-809d-8dc4-ff2d96ae3090",
+		"parent": {"id": "153104cd-477e-809d-8dc4-ff2d96ae3090", "type": "page"}
+	}
+}`
+
+func receivedMessage(payload string) *message.Message {
+	body, err := json.Marshal(sharedEvents.NotionWebhookReceived{Payload: []byte(payload)})
+	Expect(err).ToNot(HaveOccurred())
+	return message.NewMessage(watermill.NewUUID(), body)
+}
+
+var _ = Describe("WebhookTriage", func() {
+	var (
+		pubSub *gochannel.GoChannel
+		triage *subscribers.WebhookTriage
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		pubSub = gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+		triage = subscribers.NewWebhookTriage(pubSub, log.New(io.Discard, "", 0))
+		ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	})
+
+	AfterEach(func() {
+		cancel()
+		Expect(pubSub.Close()).To(Succeed())
+	})
+
+	It("should republish page.properties_updated on its own topic", func() {
+		messages, err := pubSub.Subscribe(ctx, "notion.page.properties_updated")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(triage.Handle(receivedMessage(pagePropertiesUpdatedPayload))).To(Succeed())
+
+		var msg *message.Message
+		Eventually(messages).Should(Receive(&msg))
+		msg.Ack()
+
+		Expect(msg.UUID).To(Equal("367cba44-b6f3-4c92-81e7-6a2e9659efd4"))
+		Expect(msg.Metadata.Get("notion_event_type")).To(Equal("page.properties_updated"))
+		Expect(msg.Metadata.Get(sharedEvents.ProjectIDMetadataKey)).To(BeEmpty())
+
+		var event sharedEvents.NotionPagePropertiesUpdatedEvent
+		Expect(json.Unmarshal(msg.Payload, &event)).To(Succeed())
+		Expect(event.PageID()).To(Equal("153104cd-477e-809d-8dc4-ff2d96ae3090"))
+		Expect(event.WorkspaceID).To(Equal("13950b26-c203-4f3b-b97d-93ec06319565"))
+		Expect(event.Authors).To(HaveLen(1))
+		Expect(event.Parent.Type).To(Equal("database"))
+		Expect(event.UpdatedProperties).To(Equal([]string{"XGe%40", "bDf%5B"}))
+		Expect(event.Timestamp).To(Equal(time.Date(2024, 12, 5, 23, 55, 34, 285000000, time.UTC)))
+	})
+
+	It("should pass on the project the delivery was validated for", func() {
+		messages, err := pubSub.Subscribe(ctx, "notion.page.properties_updated")
+		Expect(err).ToNot(HaveOccurred())
+
+		body, err := json.Marshal(sharedEvents.NotionWebhookReceived{
+			ProjectID: "8a1f9c3e-2b4d-4e6f-8a0b-1c2d3e4f5a6b",
+			Payload:   []byte(pagePropertiesUpdatedPayload),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(triage.Handle(message.NewMessage(watermill.NewUUID(), body))).To(Succeed())
+
+		var msg *message.Message
+		Eventually(messages).Should(Receive(&msg))
+		msg.Ack()
+
+		Expect(msg.Metadata.Get(sharedEvents.ProjectIDMetadataKey)).To(Equal("8a1f9c3e-2b4d-4e6f-8a0b-1c2d3e4f5a6b"))
+	})
+
+	It("should republish comment.created on its own topic", func() {
+		messages, err := pubSub.Subscribe(ctx, sharedEvents.NotionCommentCreated.Topic())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(triage.Handle(receivedMessage(commentCreatedPayload))).To(Succeed())
+
+		var msg *message.Message
+		Eventually(messages).Should(Receive(&msg))
+		msg.Ack()
+
+		var event sharedEvents.NotionCommentEvent
+		Expect(json.Unmarshal(msg.Payload, &event)).To(Succeed())
+		Expect(event.CommentID()).To(Equal("15510b26-c203-4f3b-b97d-93ec06319565"))
+		Expect(event.PageID).To(Equal("153104cd-477e-809d-8dc4-ff2d96ae3090"))
+	})
+
+	It("should acknowledge unknown event types without republishing", func() {
+		messages, err := pubSub.Subscribe(ctx, "notion.view.created")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(triage.Handle(receivedMessage(`{"id": "1", "type": "view.created"}`))).To(Succeed())
+
+		Consistently(messages, 100*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("should acknowledge payloads that are not Notion events", func() {
+		Expect(triage.Handle(receivedMessage(`not json`))).To(Succeed())
+		Expect(triage.Handle(message.NewMessage(watermill.NewUUID(), []byte(`not json`)))).To(Succeed())
+	})
+})
+
+var _ = Describe("ParseNotionWebhookEvent", func() {
+	It("should decode database.schema_updated property changes", func() {
+		event, err := sharedEvents.ParseNotionWebhookEvent([]byte(`{
+			"id": "1",
+			"type": "database.schema_updated",
+			"entity": {"id": "db-1", "type": "database"},
+			"data": {"updated_properties": [{"id": "p1", "name": "Status", "action": "updated"}]}
+		}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		schemaEvent, ok := event.(sharedEvents.NotionDatabaseSchemaUpdatedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(schemaEvent.DatabaseID()).To(Equal("db-1"))
+		Expect(schemaEvent.UpdatedProperties).To(ConsistOf(sharedEvents.NotionSchemaChange{ID: "p1", Name: "Status", Action: "updated"}))
+		Expect(schemaEvent.EventTopic()).To(Equal("notion.database.schema_updated"))
+	})
+
+	It("should reject payloads without an event ID or type", func() {
+		_, err := sharedEvents.ParseNotionWebhookEvent([]byte(`{"verification_token": "abc"}`))
+		Expect(err).To(MatchError(sharedEvents.ErrInvalidNotionEvent))
+	})
+})
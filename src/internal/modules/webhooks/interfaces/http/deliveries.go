@@ -0,0 +1,141 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	projectsDomain "src/internal/modules/projects/domain"
+	"src/internal/modules/webhooks/application"
+	"src/internal/modules/webhooks/domain"
+	"src/internal/pkg/httpx"
+	"src/internal/pkg/middleware"
+)
+
+// NewDeliveriesRouter creates the authenticated router for inspecting and replaying
+// stored webhook deliveries. It expects JWT authentication to run before it.
+func NewDeliveriesRouter(service *application.DeliveryService) chi.Router {
+	r := chi.NewRouter()
+
+	// GET /?project_id=...&event_type=...&signature_verdict=...&publish_outcome=...&since=...&until=...&limit=...&offset=...
+	r.Get("/", httpx.Endpoint(func(req *http.Request) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		listReq, err := parseListDeliveriesRequest(req)
+		if err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+		listReq.UserID = userID
+
+		resp, err := service.ListDeliveries(req.Context(), listReq)
+		if err != nil {
+			if errors.Is(err, projectsDomain.ErrProjectNotFound) {
+				return http.StatusNotFound, nil, httpx.NotFound(err.Error())
+			}
+			return http.StatusInternalServerError, nil, err
+		}
+
+		dto := DeliveriesListResponseDTO{
+			Deliveries: toDeliveryResponseDTOs(resp.Deliveries),
+			Count:      len(resp.Deliveries),
+		}
+		return http.StatusOK, dto, nil
+	}))
+
+	r.Get("/{deliveryID}", httpx.Endpoint(func(req *http.Request) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		deliveryID, err := uuid.Parse(chi.URLParam(req, "deliveryID"))
+		if err != nil {
+			return http.StatusBadRequest, nil, httpx.BadRequest("Invalid delivery ID", nil)
+		}
+
+		delivery, err := service.GetDelivery(req.Context(), userID, deliveryID)
+		if err != nil {
+			if errors.Is(err, domain.ErrDeliveryNotFound) {
+				return http.StatusNotFound, nil, httpx.NotFound(err.Error())
+			}
+			return http.StatusInternalServerError, nil, err
+		}
+
+		return http.StatusOK, toDeliveryResponseDTO(*delivery, true), nil
+	}))
+
+	r.Post("/{deliveryID}/replay", httpx.Endpoint(func(req *http.Request) (int, any, error) {
+		userID, err := middleware.GetUserID(req.Context())
+		if err != nil {
+			return http.StatusUnauthorized, nil, err
+		}
+
+		deliveryID, err := uuid.Parse(chi.URLParam(req, "deliveryID"))
+		if err != nil {
+			return http.StatusBadRequest, nil, httpx.BadRequest("Invalid delivery ID", nil)
+		}
+
+		resp, err := service.ReplayDelivery(req.Context(), userID, deliveryID)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrDeliveryNotFound):
+				return http.StatusNotFound, nil, httpx.NotFound(err.Error())
+			case errors.Is(err, domain.ErrDeliveryNotReplayable):
+				return http.StatusUnprocessableEntity, nil, httpx.Unprocessable(err.Error(), nil)
+			}
+			return http.StatusInternalServerError, nil, err
+		}
+
+		dto := ReplayDeliveryResponseDTO{
+			Delivery: toDeliveryResponseDTO(*resp.Delivery, false),
+			EventID:  resp.Event.ID,
+		}
+		return http.StatusAccepted, dto, nil
+	}))
+
+	return r
+}
+
+// parseListDeliveriesRequest reads the listing filters from the query string
+func parseListDeliveriesRequest(req *http.Request) (application.ListDeliveriesRequest, error) {
+	query := req.URL.Query()
+
+	listReq := application.ListDeliveriesRequest{
+		ProjectPublicID:  query.Get("project_id"),
+		NotionEventType:  query.Get("event_type"),
+		SignatureVerdict: domain.SignatureVerdict(query.Get("signature_verdict")),
+		PublishOutcome:   domain.PublishOutcome(query.Get("publish_outcome")),
+	}
+	if listReq.ProjectPublicID == "" {
+		return listReq, httpx.BadRequest("Validation failed", map[string]string{"project_id": "field is required"})
+	}
+
+	for name, target := range map[string]**time.Time{"since": &listReq.Since, "until": &listReq.Until} {
+		if value := query.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return listReq, httpx.BadRequest("Validation failed", map[string]string{name: "must be an RFC 3339 timestamp"})
+			}
+			*target = &t
+		}
+	}
+
+	for name, target := range map[string]*int{"limit": &listReq.Limit, "offset": &listReq.Offset} {
+		if value := query.Get(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return listReq, httpx.BadRequest("Validation failed", map[string]string{name: "must be a non-negative integer"})
+			}
+			*target = n
+		}
+	}
+
+	return listReq, nil
+}
@@ -0,0 +1,295 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/config"
+	projectsDomain "src/internal/modules/projects/domain"
+	shared "src/internal/modules/shared/domain"
+	sharedEvents "src/internal/modules/shared/domain/events"
+	"src/internal/modules/webhooks/application"
+	"src/internal/modules/webhooks/domain"
+	webhookInfra "src/internal/modules/webhooks/infrastructure/http"
+	webhooksHTTP "src/internal/modules/webhooks/interfaces/http"
+	"src/internal/pkg/middleware"
+)
+
+// memoryDeliveryRepository implements domain.DeliveryRepository in memory
+type memoryDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries map[uuid.UUID]*domain.Delivery
+}
+
+func newMemoryDeliveryRepository() *memoryDeliveryRepository {
+	return &memoryDeliveryRepository{deliveries: make(map[uuid.UUID]*domain.Delivery)}
+}
+
+func (m *memoryDeliveryRepository) Save(ctx context.Context, delivery *domain.Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *delivery
+	m.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (m *memoryDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delivery, ok := m.deliveries[id]
+	if !ok {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	found := *delivery
+	return &found, nil
+}
+
+func (m *memoryDeliveryRepository) List(ctx context.Context, filter domain.DeliveryFilter) ([]*domain.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*domain.Delivery
+	for _, delivery := range m.deliveries {
+		if delivery.ProjectID == nil || *delivery.ProjectID != filter.ProjectID {
+			continue
+		}
+		if filter.PublishOutcome != "" && delivery.PublishOutcome != filter.PublishOutcome {
+			continue
+		}
+		found := *delivery
+		deliveries = append(deliveries, &found)
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ReceivedAt.After(deliveries[j].ReceivedAt) })
+	return deliveries, nil
+}
+
+func (m *memoryDeliveryRepository) MarkReplayed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delivery, ok := m.deliveries[id]
+	if !ok {
+		return domain.ErrDeliveryNotFound
+	}
+	delivery.ReplayCount++
+	delivery.LastReplayedAt = &at
+	return nil
+}
+
+func (m *memoryDeliveryRepository) all() []*domain.Delivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deliveries []*domain.Delivery
+	for _, delivery := range m.deliveries {
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries
+}
+
+// fakeProjectFinder knows a single project
+type fakeProjectFinder struct {
+	project projectsDomain.Project
+}
+
+func (f *fakeProjectFinder) FindByID(ctx context.Context, id uuid.UUID) (*projectsDomain.Project, error) {
+	if id != f.project.ID {
+		return nil, projectsDomain.ErrProjectNotFound
+	}
+	return &f.project, nil
+}
+
+func (f *fakeProjectFinder) FindByPublicID(ctx context.Context, publicID string) (*projectsDomain.Project, error) {
+	if publicID != f.project.PublicID {
+		return nil, projectsDomain.ErrProjectNotFound
+	}
+	return &f.project, nil
+}
+
+func (f *fakeProjectFinder) FindByNotionDatabaseID(ctx context.Context, notionDatabaseID string) (*projectsDomain.Project, error) {
+	if notionDatabaseID != f.project.NotionDatabaseID {
+		return nil, projectsDomain.ErrProjectNotFound
+	}
+	return &f.project, nil
+}
+
+var _ = Describe("Webhook delivery log", func() {
+	var (
+		pubSub     *gochannel.GoChannel
+		repo       *memoryDeliveryRepository
+		project    projectsDomain.Project
+		webhooks   http.Handler
+		deliveries http.Handler
+		ownerID    uuid.UUID
+	)
+
+	sign := func(payload []byte, secret string) string {
+		h := hmac.New(sha256.New, []byte(secret))
+		h.Write(payload)
+		return "sha256=" + hex.EncodeToString(h.Sum(nil))
+	}
+
+	deliver := func(payload []byte, signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/notion/"+project.PublicID, bytes.NewReader(payload))
+		req.Header.Set("X-Notion-Signature", signature)
+		rec := httptest.NewRecorder()
+		webhooks.ServeHTTP(rec, req)
+		return rec
+	}
+
+	asUser := func(req *http.Request, userID uuid.UUID) *http.Request {
+		return req.WithContext(middleware.SetUserID(req.Context(), userID))
+	}
+
+	BeforeEach(func() {
+		config.SetForTests(testWebhookConfig())
+
+		ownerID = uuid.New()
+		project = projectsDomain.Project{
+			ID:                  uuid.New(),
+			PublicID:            "project_log",
+			UserID:              ownerID,
+			NotionDatabaseID:    "db-log",
+			NotionWebhookSecret: "project-secret",
+		}
+		projects := &fakeProjectFinder{project: project}
+
+		pubSub = gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+		repo = newMemoryDeliveryRepository()
+
+		webhooks = webhooksHTTP.NewRouter(
+			pubSub,
+			webhooksHTTP.WithSecretResolver(webhookInfra.NewProjectSecretResolver(projects)),
+			webhooksHTTP.WithDeliveryLog(repo),
+		)
+		deliveries = webhooksHTTP.NewDeliveriesRouter(application.NewDeliveryService(
+			repo,
+			projects,
+			webhookInfra.NewWatermillEventPublisher(pubSub, log.New(GinkgoWriter, "", 0)),
+			shared.NewSystemClock(),
+			shared.NewUUIDGenerator(),
+		))
+	})
+
+	AfterEach(func() {
+		config.SetForTests(nil)
+	})
+
+	payload := []byte(`{"id": "evt-log-1", "type": "page.created", "entity": {"id": "page-1", "type": "page"}}`)
+
+	It("should record accepted deliveries with their verdict and outcome", func() {
+		rec := deliver(payload, sign(payload, "project-secret"))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+
+		stored := repo.all()
+		Expect(stored).To(HaveLen(1))
+		Expect(*stored[0].ProjectID).To(Equal(project.ID))
+		Expect(stored[0].NotionEventID).To(Equal("evt-log-1"))
+		Expect(stored[0].NotionEventType).To(Equal("page.created"))
+		Expect(stored[0].SignatureVerdict).To(Equal(domain.SignatureVerdictValid))
+		Expect(stored[0].Classification).To(Equal(domain.WebhookEventTypeRegular))
+		Expect(stored[0].PublishOutcome).To(Equal(domain.PublishOutcomePublished))
+		Expect(stored[0].StatusCode).To(Equal(http.StatusOK))
+		Expect(stored[0].Headers).To(HaveKey("X-Notion-Signature"))
+		Expect([]byte(stored[0].Payload)).To(Equal(payload))
+	})
+
+	It("should record rejected deliveries", func() {
+		rec := deliver(payload, sign(payload, "wrong-secret"))
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+
+		stored := repo.all()
+		Expect(stored).To(HaveLen(1))
+		Expect(stored[0].SignatureVerdict).To(Equal(domain.SignatureVerdictInvalid))
+		Expect(stored[0].PublishOutcome).To(Equal(domain.PublishOutcomeRejected))
+		Expect(stored[0].Error).To(ContainSubstring("INVALID_SIGNATURE"))
+	})
+
+	It("should list deliveries of the user's project", func() {
+		deliver(payload, sign(payload, "project-secret"))
+		deliver(payload, sign(payload, "wrong-secret"))
+
+		req := httptest.NewRequest("GET", "/?project_id=project_log&publish_outcome=published", nil)
+		rec := httptest.NewRecorder()
+		deliveries.ServeHTTP(rec, asUser(req, ownerID))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var resp webhooksHTTP.DeliveriesListResponseDTO
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp.Count).To(Equal(1))
+		Expect(resp.Deliveries[0].PublishOutcome).To(Equal("published"))
+	})
+
+	It("should hide deliveries from other users", func() {
+		deliver(payload, sign(payload, "project-secret"))
+
+		req := httptest.NewRequest("GET", "/?project_id=project_log", nil)
+		rec := httptest.NewRecorder()
+		deliveries.ServeHTTP(rec, asUser(req, uuid.New()))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+
+		req = httptest.NewRequest("GET", "/"+repo.all()[0].ID.String(), nil)
+		rec = httptest.NewRecorder()
+		deliveries.ServeHTTP(rec, asUser(req, uuid.New()))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should require a project filter", func() {
+		req := httptest.NewRequest("GET", "/", nil)
+		rec := httptest.NewRecorder()
+		deliveries.ServeHTTP(rec, asUser(req, ownerID))
+
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should re-publish a stored delivery", func() {
+		deliver(payload, sign(payload, "project-secret"))
+		deliveryID := repo.all()[0].ID
+
+		messages, err := pubSub.Subscribe(context.Background(), sharedEvents.NotionWebhookReceivedTopic)
+		Expect(err).ToNot(HaveOccurred())
+
+		req := httptest.NewRequest("POST", "/"+deliveryID.String()+"/replay", nil)
+		rec := httptest.NewRecorder()
+		deliveries.ServeHTTP(rec, asUser(req, ownerID))
+
+		Expect(rec.Code).To(Equal(http.StatusAccepted))
+
+		var msg *message.Message
+		Eventually(messages).Should(Receive(&msg))
+		msg.Ack()
+
+		var published sharedEvents.NotionWebhookReceived
+		Expect(json.Unmarshal(msg.Payload, &published)).To(Succeed())
+		Expect(published.Payload).To(Equal(payload))
+		Expect(published.ProjectID).To(Equal(project.ID.String()))
+
+		stored, err := repo.FindByID(context.Background(), deliveryID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stored.ReplayCount).To(Equal(1))
+	})
+
+	It("should refuse to replay deliveries with an invalid signature", func() {
+		deliver(payload, sign(payload, "wrong-secret"))
+
+		req := httptest.NewRequest("POST", "/"+repo.all()[0].ID.String()+"/replay", nil)
+		rec := httptest.NewRecorder()
+		deliveries.ServeHTTP(rec, asUser(req, ownerID))
+
+		Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+	})
+})
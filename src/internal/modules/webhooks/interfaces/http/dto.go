@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"src/internal/modules/webhooks/domain"
+)
+
+// DeliveryResponseDTO represents a stored webhook delivery in API responses
+type DeliveryResponseDTO struct {
+	ID               string            `json:"id"`
+	ProjectID        string            `json:"project_id,omitempty"`
+	NotionEventID    string            `json:"notion_event_id,omitempty"`
+	NotionEventType  string            `json:"notion_event_type,omitempty"`
+	SignatureVerdict string            `json:"signature_verdict"`
+	Classification   string            `json:"classification,omitempty"`
+	PublishOutcome   string            `json:"publish_outcome"`
+	Error            string            `json:"error,omitempty"`
+	StatusCode       int               `json:"status_code"`
+	LatencyMs        int64             `json:"latency_ms"`
+	ReceivedAt       time.Time         `json:"received_at"`
+	ReplayCount      int               `json:"replay_count"`
+	LastReplayedAt   *time.Time        `json:"last_replayed_at,omitempty"`
+	Headers          map[string]string `json:"headers,omitempty"`
+	Payload          any               `json:"payload,omitempty"`
+}
+
+// DeliveriesListResponseDTO represents the response payload for listing deliveries
+type DeliveriesListResponseDTO struct {
+	Deliveries []DeliveryResponseDTO `json:"deliveries"`
+	Count      int                   `json:"count"`
+}
+
+// ReplayDeliveryResponseDTO represents the response payload for a replay
+type ReplayDeliveryResponseDTO struct {
+	Delivery DeliveryResponseDTO `json:"delivery"`
+	EventID  string              `json:"event_id"`
+}
+
+// toDeliveryResponseDTO converts a domain Delivery to DeliveryResponseDTO.
+// Headers and payload are only included when withDetails is set.
+func toDeliveryResponseDTO(delivery domain.Delivery, withDetails bool) DeliveryResponseDTO {
+	dto := DeliveryResponseDTO{
+		ID:               delivery.ID.String(),
+		NotionEventID:    delivery.NotionEventID,
+		NotionEventType:  delivery.NotionEventType,
+		SignatureVerdict: string(delivery.SignatureVerdict),
+		Classification:   string(delivery.Classification),
+		PublishOutcome:   string(delivery.PublishOutcome),
+		Error:            delivery.Error,
+		StatusCode:       delivery.StatusCode,
+		LatencyMs:        delivery.Latency.Milliseconds(),
+		ReceivedAt:       delivery.ReceivedAt,
+		ReplayCount:      delivery.ReplayCount,
+		LastReplayedAt:   delivery.LastReplayedAt,
+	}
+	if delivery.ProjectID != nil {
+		dto.ProjectID = delivery.ProjectID.String()
+	}
+
+	if withDetails {
+		dto.Headers = delivery.Headers
+		// Show JSON payloads as JSON and anything else as a string
+		if json.Valid(delivery.Payload) {
+			dto.Payload = json.RawMessage(delivery.Payload)
+		} else {
+			dto.Payload = delivery.Payload.String()
+		}
+	}
+
+	return dto
+}
+
+// toDeliveryResponseDTOs converts a slice of domain Deliveries to DeliveryResponseDTOs
+func toDeliveryResponseDTOs(deliveries []*domain.Delivery) []DeliveryResponseDTO {
+	dtos := make([]DeliveryResponseDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dtos = append(dtos, toDeliveryResponseDTO(*delivery, false))
+	}
+	return dtos
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -40,28 +41,55 @@ func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get signature for processing
-	cfg := config.Get()
+	// Get the project and secret the middleware validated the delivery with
+	target, err := webhookInfra.GetWebhookTarget(r.Context())
+	if err != nil {
+		httpx.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	signature := domain.NewWebhookSignature(
 		r.Header.Get("X-Notion-Signature"),
-		cfg.Notion.WebhookSecret,
+		target.Secret,
 	)
 
 	// Process webhook through application service
 	req := application.WebhookProcessingRequest{
 		Payload:        payload,
 		Signature:      signature,
+		ProjectID:      target.ProjectID,
 		RequestContext: r.Context(),
 	}
 
 	response, err := h.webhookService.ProcessWebhook(r.Context(), req)
+	webhookInfra.AnnotateDelivery(r.Context(), func(d *domain.Delivery) {
+		switch {
+		case err != nil:
+			d.PublishOutcome = domain.PublishOutcomeFailed
+			if domainErr, ok := err.(domain.WebhookProcessingError); ok && domainErr.Code == domain.ErrStaleTimestamp.Code {
+				d.PublishOutcome = domain.PublishOutcomeRejected
+			}
+			d.Error = err.Error()
+		case response.Duplicate:
+			d.PublishOutcome = domain.PublishOutcomeDuplicate
+		case response.Event.Type == domain.WebhookEventTypeVerification:
+			d.PublishOutcome = domain.PublishOutcomeSkipped
+		default:
+			d.PublishOutcome = domain.PublishOutcomePublished
+		}
+		if response.Event != nil {
+			d.Classification = response.Event.Type
+		}
+	})
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if domainErr, ok := err.(domain.WebhookProcessingError); ok {
 			switch domainErr.Code {
 			case "INVALID_SIGNATURE", "MISSING_SIGNATURE":
 				statusCode = http.StatusUnauthorized
-			case "INVALID_PAYLOAD":
+			case "INVALID_PAYLOAD", "STALE_TIMESTAMP":
 				statusCode = http.StatusBadRequest
 			}
 		}
@@ -95,32 +123,103 @@ func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RouterOption configures the webhooks router
+type RouterOption func(*routerOptions)
+
+type routerOptions struct {
+	resolver     domain.WebhookSecretResolver
+	deduplicator domain.WebhookDeduplicator
+	deliveries   domain.DeliveryRepository
+	replayWindow time.Duration
+	logger       *log.Logger
+}
+
+// WithSecretResolver enables per-project webhook secrets.
+// Without it every delivery is validated with the global Notion webhook secret.
+func WithSecretResolver(resolver domain.WebhookSecretResolver) RouterOption {
+	return func(o *routerOptions) {
+		o.resolver = resolver
+	}
+}
+
+// WithDeduplicator sets the store used to drop repeated deliveries.
+// Without it deliveries are deduplicated in process memory.
+func WithDeduplicator(deduplicator domain.WebhookDeduplicator) RouterOption {
+	return func(o *routerOptions) {
+		o.deduplicator = deduplicator
+	}
+}
+
+// WithDeliveryLog stores every delivery in the given repository
+func WithDeliveryLog(deliveries domain.DeliveryRepository) RouterOption {
+	return func(o *routerOptions) {
+		o.deliveries = deliveries
+	}
+}
+
+// WithReplayWindow overrides the configured maximum event age
+func WithReplayWindow(window time.Duration) RouterOption {
+	return func(o *routerOptions) {
+		o.replayWindow = window
+	}
+}
+
+// WithLogger sets the logger used for publishing and audit logs
+func WithLogger(logger *log.Logger) RouterOption {
+	return func(o *routerOptions) {
+		o.logger = logger
+	}
+}
+
 // NewRouter creates a new HTTP router for the webhooks module
-func NewRouter(publisher message.Publisher) chi.Router {
+func NewRouter(publisher message.Publisher, opts ...RouterOption) chi.Router {
 	r := chi.NewRouter()
 
+	options := routerOptions{
+		replayWindow: config.Get().Webhooks.ReplayWindow,
+		logger:       log.Default(),
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.deduplicator == nil {
+		options.deduplicator = webhookInfra.NewMemoryDeduplicator(options.replayWindow)
+	}
+
 	// Initialize dependencies
 	validator := webhookInfra.NewHMACSHA256Validator()
 	classifier := webhookInfra.NewPayloadClassifier()
-	eventPublisher := webhookInfra.NewWatermillEventPublisher(publisher, log.Default())
+	eventPublisher := webhookInfra.NewWatermillEventPublisher(publisher, options.logger)
 	idGenerator := shared.NewUUIDGenerator()
+	clock := shared.NewSystemClock()
 
 	// Initialize application service
 	webhookService := application.NewWebhookService(
 		validator,
 		classifier,
 		eventPublisher,
+		options.deduplicator,
+		clock,
+		options.replayWindow,
 		idGenerator,
 	)
 
 	// Initialize middleware
-	middleware := webhookInfra.NewWebhookMiddleware(validator)
+	middleware := webhookInfra.NewWebhookMiddleware(validator, options.resolver, options.logger)
 
 	// Initialize handler
 	handler := NewWebhookHandler(*webhookService)
 
+	// The delivery log wraps signature validation so rejected requests are recorded too
+	middlewares := chi.Middlewares{middleware.Handler}
+	if options.deliveries != nil {
+		recorder := webhookInfra.NewDeliveryRecorder(options.deliveries, options.logger)
+		middlewares = chi.Middlewares{recorder.Handler, middleware.Handler}
+	}
+
 	// Setup routes with middleware
-	r.With(middleware.Handler).Post("/notion", handler.HandleWebhook)
+	r.With(middlewares...).Post("/notion", handler.HandleWebhook)
+	r.With(middlewares...).Post("/notion/{"+webhookInfra.ProjectPublicIDParam+"}", handler.HandleWebhook)
 
 	return r
 }
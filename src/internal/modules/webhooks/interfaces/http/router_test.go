@@ -7,6 +7,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"time"
@@ -19,9 +21,32 @@ import (
 
 	"src/internal/config"
 	sharedEvents "src/internal/modules/shared/domain/events"
+	"src/internal/modules/webhooks/domain"
 	webhooksHTTP "src/internal/modules/webhooks/interfaces/http"
 )
 
+// fakeSecretResolver resolves a single project by public ID or Notion database ID
+type fakeSecretResolver struct {
+	target     domain.WebhookTarget
+	databaseID string
+}
+
+func (f *fakeSecretResolver) ResolveByProjectPublicID(ctx context.Context, publicID string) (*domain.WebhookTarget, error) {
+	if publicID != f.target.ProjectPublicID {
+		return nil, domain.ErrProjectNotFound
+	}
+	target := f.target
+	return &target, nil
+}
+
+func (f *fakeSecretResolver) ResolveByPayload(ctx context.Context, payload domain.WebhookPayload) (*domain.WebhookTarget, error) {
+	if !bytes.Contains(payload, []byte(f.databaseID)) {
+		return nil, domain.ErrProjectNotFound
+	}
+	target := f.target
+	return &target, nil
+}
+
 func testWebhookConfig() *config.Config {
 	return &config.Config{
 		Notion: struct {
@@ -201,4 +226,168 @@ var _ = Describe("Webhook Router", func() {
 			}
 		})
 	})
+
+	Context("Per-project secrets", func() {
+		var (
+			pubSub       *gochannel.GoChannel
+			resolver     *fakeSecretResolver
+			auditLog     *bytes.Buffer
+			projectRoute http.Handler
+		)
+
+		BeforeEach(func() {
+			pubSub = gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+			resolver = &fakeSecretResolver{
+				target: domain.WebhookTarget{
+					ProjectID:       "7f9c2ba4-e88f-4f2a-8a5e-3c7a0f2f6b1d",
+					ProjectPublicID: "project_abc",
+					Secret:          "project-secret",
+					Source:          domain.WebhookSecretSourceProject,
+				},
+				databaseID: "db-123",
+			}
+			auditLog = &bytes.Buffer{}
+			projectRoute = webhooksHTTP.NewRouter(
+				pubSub,
+				webhooksHTTP.WithSecretResolver(resolver),
+				webhooksHTTP.WithLogger(log.New(io.MultiWriter(auditLog, GinkgoWriter), "", 0)),
+			)
+		})
+
+		eventPayload := []byte(`{"id": "evt-1", "type": "page.created", "entity": {"id": "page-1", "type": "page"}, "data": {"parent": {"id": "db-123", "type": "database"}}}`)
+
+		It("should validate per-project URLs with the project secret and tag the event", func() {
+			messages, err := pubSub.Subscribe(context.Background(), sharedEvents.NotionWebhookReceivedTopic)
+			Expect(err).ToNot(HaveOccurred())
+
+			req := httptest.NewRequest("POST", "/notion/project_abc", bytes.NewReader(eventPayload))
+			req.Header.Set("X-Notion-Signature", computeSignature(eventPayload, "project-secret"))
+			rec := httptest.NewRecorder()
+
+			projectRoute.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+
+			var msg *message.Message
+			Eventually(messages).Should(Receive(&msg))
+			msg.Ack()
+
+			var publishedEvent sharedEvents.NotionWebhookReceived
+			Expect(json.Unmarshal(msg.Payload, &publishedEvent)).To(Succeed())
+			Expect(publishedEvent.ProjectID).To(Equal("7f9c2ba4-e88f-4f2a-8a5e-3c7a0f2f6b1d"))
+		})
+
+		It("should reject per-project URLs signed with the global secret and audit the failure", func() {
+			req := httptest.NewRequest("POST", "/notion/project_abc", bytes.NewReader(eventPayload))
+			req.Header.Set("X-Notion-Signature", computeSignature(eventPayload, secret))
+			rec := httptest.NewRecorder()
+
+			projectRoute.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+			Expect(auditLog.String()).To(ContainSubstring("webhook rejected"))
+			Expect(auditLog.String()).To(ContainSubstring(`project="project_abc"`))
+			Expect(auditLog.String()).To(ContainSubstring("secret_source=project"))
+			Expect(auditLog.String()).ToNot(ContainSubstring("project-secret"))
+		})
+
+		It("should return 404 for unknown projects", func() {
+			req := httptest.NewRequest("POST", "/notion/project_unknown", bytes.NewReader(eventPayload))
+			req.Header.Set("X-Notion-Signature", computeSignature(eventPayload, "project-secret"))
+			rec := httptest.NewRecorder()
+
+			projectRoute.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+			Expect(auditLog.String()).To(ContainSubstring("PROJECT_NOT_FOUND"))
+		})
+
+		It("should resolve the project from the payload database", func() {
+			req := httptest.NewRequest("POST", "/notion", bytes.NewReader(eventPayload))
+			req.Header.Set("X-Notion-Signature", computeSignature(eventPayload, "project-secret"))
+			rec := httptest.NewRecorder()
+
+			projectRoute.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should fall back to the global secret when no project matches the payload", func() {
+			payload := []byte(`{"id": "evt-2", "type": "page.created", "data": {"parent": {"id": "db-other", "type": "database"}}}`)
+			req := httptest.NewRequest("POST", "/notion", bytes.NewReader(payload))
+			req.Header.Set("X-Notion-Signature", computeSignature(payload, secret))
+			rec := httptest.NewRecorder()
+
+			projectRoute.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Replay protection", func() {
+		var (
+			pubSub *gochannel.GoChannel
+			guard  http.Handler
+		)
+
+		BeforeEach(func() {
+			pubSub = gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+			guard = webhooksHTTP.NewRouter(
+				pubSub,
+				webhooksHTTP.WithReplayWindow(time.Hour),
+				webhooksHTTP.WithLogger(log.New(GinkgoWriter, "", 0)),
+			)
+		})
+
+		deliver := func(payload []byte) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/notion", bytes.NewReader(payload))
+			req.Header.Set("X-Notion-Signature", computeSignature(payload, secret))
+			rec := httptest.NewRecorder()
+			guard.ServeHTTP(rec, req)
+			return rec
+		}
+
+		eventAt := func(id string, timestamp time.Time) []byte {
+			payload, err := json.Marshal(map[string]any{
+				"id":        id,
+				"type":      "page.created",
+				"timestamp": timestamp.UTC().Format(time.RFC3339Nano),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			return payload
+		}
+
+		It("should acknowledge duplicate deliveries without republishing them", func() {
+			messages, err := pubSub.Subscribe(context.Background(), sharedEvents.NotionWebhookReceivedTopic)
+			Expect(err).ToNot(HaveOccurred())
+
+			payload := eventAt("evt-dup", time.Now())
+
+			first := deliver(payload)
+			Expect(first.Code).To(Equal(http.StatusOK))
+
+			var msg *message.Message
+			Eventually(messages).Should(Receive(&msg))
+			msg.Ack()
+
+			second := deliver(payload)
+			Expect(second.Code).To(Equal(http.StatusOK))
+			Expect(second.Body.String()).To(ContainSubstring("Duplicate webhook event ignored"))
+
+			Consistently(messages, 100*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("should reject events older than the replay window", func() {
+			rec := deliver(eventAt("evt-old", time.Now().Add(-2*time.Hour)))
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			Expect(rec.Body.String()).To(ContainSubstring("STALE_TIMESTAMP"))
+		})
+
+		It("should reject events timestamped in the future", func() {
+			rec := deliver(eventAt("evt-future", time.Now().Add(time.Hour)))
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
 })
@@ -1,15 +1,79 @@
 package eventbus
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill-redisstream/pkg/redisstream"
+	watermillSQL "github.com/ThreeDotsLabs/watermill-sql/v3/pkg/sql"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/redis/go-redis/v9"
+
+	"src/internal/config"
+	"src/internal/database"
+)
+
+// Supported event bus backends
+const (
+	BackendGoChannel = "gochannel"
+	BackendRedis     = "redis"
+	BackendPostgres  = "postgres"
+)
+
+var (
+	goChannelOnce sync.Once
+	goChannel     *gochannel.GoChannel
 )
 
-// NewPublisher creates a new message publisher using GoChannel for local development
+// NewPublisher creates a new message publisher for the backend selected in config.
+// Redis Streams and PostgreSQL backends are durable and shared between processes,
+// GoChannel only delivers messages inside the current process.
 func NewPublisher(logger watermill.LoggerAdapter) (message.Publisher, error) {
-	pub := gochannel.NewGoChannel(gochannel.Config{}, logger)
-	return pub, nil
+	cfg := config.Get()
+
+	switch backend(cfg) {
+	case BackendGoChannel:
+		return sharedGoChannel(logger), nil
+	case BackendRedis:
+		return redisstream.NewPublisher(redisstream.PublisherConfig{
+			Client: newRedisClient(cfg),
+		}, logger)
+	case BackendPostgres:
+		return watermillSQL.NewPublisher(database.SQLDB(), watermillSQL.PublisherConfig{
+			SchemaAdapter:        watermillSQL.DefaultPostgreSQLSchema{},
+			AutoInitializeSchema: true,
+		}, logger)
+	default:
+		return nil, fmt.Errorf("unsupported event bus backend: %q", cfg.EventBus.Backend)
+	}
+}
+
+// NewSubscriber creates a new message subscriber for the backend selected in config.
+// Subscribers sharing a consumer group split the messages of a topic between them,
+// and every message has to be acknowledged before it is considered delivered.
+func NewSubscriber(logger watermill.LoggerAdapter, consumerGroup string) (message.Subscriber, error) {
+	cfg := config.Get()
+
+	switch backend(cfg) {
+	case BackendGoChannel:
+		return sharedGoChannel(logger), nil
+	case BackendRedis:
+		return redisstream.NewSubscriber(redisstream.SubscriberConfig{
+			Client:        newRedisClient(cfg),
+			ConsumerGroup: consumerGroup,
+		}, logger)
+	case BackendPostgres:
+		return watermillSQL.NewSubscriber(database.SQLDB(), watermillSQL.SubscriberConfig{
+			ConsumerGroup:    consumerGroup,
+			SchemaAdapter:    watermillSQL.DefaultPostgreSQLSchema{},
+			OffsetsAdapter:   watermillSQL.DefaultPostgreSQLOffsetsAdapter{},
+			InitializeSchema: true,
+		}, logger)
+	default:
+		return nil, fmt.Errorf("unsupported event bus backend: %q", cfg.EventBus.Backend)
+	}
 }
 
 // NewRouter creates a new message router
@@ -20,3 +84,29 @@ func NewRouter(logger watermill.LoggerAdapter) (*message.Router, error) {
 	}
 	return router, nil
 }
+
+// backend returns the configured backend, falling back to GoChannel when none is set
+func backend(cfg *config.Config) string {
+	if cfg.EventBus.Backend == "" {
+		return BackendGoChannel
+	}
+	return cfg.EventBus.Backend
+}
+
+// sharedGoChannel returns a process-wide GoChannel so that in-memory publishers
+// and subscribers see the same topics
+func sharedGoChannel(logger watermill.LoggerAdapter) *gochannel.GoChannel {
+	goChannelOnce.Do(func() {
+		goChannel = gochannel.NewGoChannel(gochannel.Config{}, logger)
+	})
+	return goChannel
+}
+
+// newRedisClient creates a Redis client for the stream backend
+func newRedisClient(cfg *config.Config) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisURL(),
+		Password: cfg.Redis.Password,
+		DB:       0,
+	})
+}
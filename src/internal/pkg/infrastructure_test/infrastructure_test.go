@@ -11,13 +11,16 @@ import (
 	"github.com/hibiken/asynq"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/redis"
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	"src/internal/config"
 	"src/internal/modules/shared/domain/events"
+	webhookInfra "src/internal/modules/webhooks/infrastructure/http"
 	"src/internal/pkg/eventbus"
+	"src/internal/pkg/notion"
 	"src/internal/pkg/taskqueue"
 )
 
@@ -153,6 +156,42 @@ var _ = Describe("Infrastructure", func() {
 		}
 	})
 
+	It("should deliver events across processes through Redis Streams", func() {
+		testConfig.EventBus.Backend = eventbus.BackendRedis
+		DeferCleanup(func() { testConfig.EventBus.Backend = "" })
+
+		logger := watermill.NewStdLogger(false, false)
+
+		// Publisher and subscriber do not share any in-memory state,
+		// just like the API and the event worker
+		publisher, err := eventbus.NewPublisher(logger)
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(publisher.Close)
+
+		subscriber, err := eventbus.NewSubscriber(logger, "test_group")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(subscriber.Close)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		messages, err := subscriber.Subscribe(ctx, events.NotionWebhookReceivedTopic)
+		Expect(err).ToNot(HaveOccurred())
+
+		testPayload := []byte(`{"test": "redis"}`)
+		msg := message.NewMessage(watermill.NewUUID(), testPayload)
+		Expect(publisher.Publish(events.NotionWebhookReceivedTopic, msg)).To(Succeed())
+
+		select {
+		case receivedMsg := <-messages:
+			Expect(receivedMsg.UUID).To(Equal(msg.UUID))
+			Expect(string(receivedMsg.Payload)).To(Equal(string(testPayload)))
+			receivedMsg.Ack()
+		case <-time.After(10 * time.Second):
+			Fail("Event was not delivered through Redis Streams within timeout")
+		}
+	})
+
 	It("should enqueue and process jobs successfully", func() {
 		// Create Redis client for Asynq
 		redisOpt := asynq.RedisClientOpt{
@@ -215,4 +254,48 @@ var _ = Describe("Infrastructure", func() {
 		// Clean up
 		server.Shutdown()
 	})
+
+	It("should deduplicate webhook deliveries in Redis", func() {
+		ctx := context.Background()
+		client := goredis.NewClient(&goredis.Options{Addr: config.Get().RedisURL()})
+		DeferCleanup(client.Close)
+
+		deduplicator := webhookInfra.NewRedisDeduplicator(client, time.Minute)
+		key := watermill.NewUUID()
+
+		duplicate, err := deduplicator.Claim(ctx, key)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(duplicate).To(BeFalse())
+
+		duplicate, err = deduplicator.Claim(ctx, key)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(duplicate).To(BeTrue())
+
+		// A released key can be claimed again
+		Expect(deduplicator.Release(ctx, key)).To(Succeed())
+		duplicate, err = deduplicator.Claim(ctx, key)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(duplicate).To(BeFalse())
+	})
+
+	It("should share the Notion rate limit between limiters through Redis", func() {
+		ctx := context.Background()
+		client := goredis.NewClient(&goredis.Options{Addr: config.Get().RedisURL()})
+		DeferCleanup(client.Close)
+
+		// Two limiters stand in for two processes using the same token
+		first := notion.NewRedisRateLimiter(client, 10, 1)
+		second := notion.NewRedisRateLimiter(client, 10, 1)
+		token := watermill.NewUUID()
+
+		start := time.Now()
+		Expect(first.Wait(ctx, token)).To(Succeed())
+		Expect(second.Wait(ctx, token)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically(">=", 80*time.Millisecond))
+
+		// Other tokens have their own bucket
+		start = time.Now()
+		Expect(second.Wait(ctx, watermill.NewUUID())).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", 50*time.Millisecond))
+	})
 })
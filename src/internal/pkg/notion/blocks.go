@@ -0,0 +1,216 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// MaxBlocksPerRequest is the most children Notion accepts in one append request
+const MaxBlocksPerRequest = 100
+
+// Blocks provides methods for working with Notion blocks
+type Blocks struct {
+	client *Client
+}
+
+// NewBlocks creates a new Blocks client
+func NewBlocks(opts ...ClientOption) *Blocks {
+	return &Blocks{
+		client: NewClient(opts...),
+	}
+}
+
+// Retrieve retrieves a block by ID
+func (b *Blocks) Retrieve(ctx context.Context, accessToken, blockID string) (*Block, error) {
+	endpoint := fmt.Sprintf("/blocks/%s", blockID)
+
+	resp, err := b.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve block: %w", err)
+	}
+
+	var block Block
+	if err := b.client.handleResponse(resp, &block); err != nil {
+		return nil, fmt.Errorf("failed to parse block response: %w", err)
+	}
+
+	return &block, nil
+}
+
+// ListChildren retrieves one page of a block's children. Pages are blocks too, so
+// pageID can be passed to read a page's content.
+func (b *Blocks) ListChildren(ctx context.Context, accessToken, blockID string, startCursor string, pageSize int) (*BlockListResponse, error) {
+	endpoint := withQuery(fmt.Sprintf("/blocks/%s/children", blockID), paginationParams(startCursor, pageSize))
+
+	resp, err := b.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list block children: %w", err)
+	}
+
+	var listResp BlockListResponse
+	if err := b.client.handleResponse(resp, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to parse block children response: %w", err)
+	}
+
+	return &listResp, nil
+}
+
+// Children iterates over all direct children of a block, following cursors
+func (b *Blocks) Children(ctx context.Context, accessToken, blockID string) iter.Seq2[Block, error] {
+	return paginate(ctx, "", func(ctx context.Context, cursor string) ([]Block, string, bool, error) {
+		resp, err := b.ListChildren(ctx, accessToken, blockID, cursor, MaxBlocksPerRequest)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.Results, resp.NextCursor, resp.HasMore, nil
+	})
+}
+
+// RetrieveTree retrieves the children of a block and, recursively, their children
+// into Block.Children. Child pages and databases are not descended into.
+// maxDepth limits how many levels are fetched; maxDepth <= 0 fetches the whole tree.
+func (b *Blocks) RetrieveTree(ctx context.Context, accessToken, blockID string, maxDepth int) ([]Block, error) {
+	return b.retrieveTree(ctx, accessToken, blockID, 1, maxDepth)
+}
+
+func (b *Blocks) retrieveTree(ctx context.Context, accessToken, blockID string, depth, maxDepth int) ([]Block, error) {
+	var blocks []Block
+	for block, err := range b.Children(ctx, accessToken, blockID) {
+		if err != nil {
+			return nil, err
+		}
+
+		descend := block.HasChildren && block.Type != BlockTypeChildPage && block.Type != BlockTypeChildDatabase
+		if descend && (maxDepth <= 0 || depth < maxDepth) {
+			children, err := b.retrieveTree(ctx, accessToken, block.ID, depth+1, maxDepth)
+			if err != nil {
+				return nil, err
+			}
+			block.Children = children
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// Append appends blocks to a block or page. Requests are split into chunks of
+// MaxBlocksPerRequest, and Block.Children are appended to the created blocks level
+// by level, so trees of any size and depth can be written. It returns the created
+// top-level blocks; on failure it returns the blocks created so far with the error.
+func (b *Blocks) Append(ctx context.Context, accessToken, blockID string, blocks []Block) ([]Block, error) {
+	endpoint := fmt.Sprintf("/blocks/%s/children", blockID)
+
+	var created []Block
+	for start := 0; start < len(blocks); start += MaxBlocksPerRequest {
+		chunk := blocks[start:min(start+MaxBlocksPerRequest, len(blocks))]
+
+		children := make([]Block, len(chunk))
+		for i, block := range chunk {
+			children[i] = blockForWrite(block)
+		}
+
+		resp, err := b.client.makeRequest(ctx, "PATCH", endpoint, map[string][]Block{"children": children}, accessToken, authTypeBearer)
+		if err != nil {
+			return created, fmt.Errorf("failed to append block children: %w", err)
+		}
+
+		var listResp BlockListResponse
+		if err := b.client.handleResponse(resp, &listResp); err != nil {
+			return created, fmt.Errorf("failed to parse append block children response: %w", err)
+		}
+		if len(listResp.Results) != len(chunk) {
+			return created, fmt.Errorf("failed to append block children: sent %d blocks, notion created %d", len(chunk), len(listResp.Results))
+		}
+
+		for i, result := range listResp.Results {
+			if len(chunk[i].Children) > 0 && chunk[i].Type != BlockTypeTable {
+				nested, err := b.Append(ctx, accessToken, result.ID, chunk[i].Children)
+				result.Children = nested
+				if err != nil {
+					return append(created, result), err
+				}
+			}
+			created = append(created, result)
+		}
+	}
+
+	return created, nil
+}
+
+// Update updates the content of a block. Only the type-specific field of block is
+// sent; it must match the existing block's type.
+func (b *Blocks) Update(ctx context.Context, accessToken, blockID string, block *Block) (*Block, error) {
+	endpoint := fmt.Sprintf("/blocks/%s", blockID)
+
+	request := blockForWrite(*block)
+	request.Type = ""
+
+	resp, err := b.client.makeRequest(ctx, "PATCH", endpoint, request, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update block: %w", err)
+	}
+
+	var updated Block
+	if err := b.client.handleResponse(resp, &updated); err != nil {
+		return nil, fmt.Errorf("failed to parse update block response: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// Delete archives a block
+func (b *Blocks) Delete(ctx context.Context, accessToken, blockID string) (*Block, error) {
+	endpoint := fmt.Sprintf("/blocks/%s", blockID)
+
+	resp, err := b.client.makeRequest(ctx, "DELETE", endpoint, nil, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete block: %w", err)
+	}
+
+	var deleted Block
+	if err := b.client.handleResponse(resp, &deleted); err != nil {
+		return nil, fmt.Errorf("failed to parse delete block response: %w", err)
+	}
+
+	return &deleted, nil
+}
+
+// blockForWrite strips the read-only fields of a block. Table rows are moved inline
+// because Notion cannot create a table without them.
+func blockForWrite(block Block) Block {
+	write := Block{
+		Type:             block.Type,
+		Paragraph:        block.Paragraph,
+		Heading1:         block.Heading1,
+		Heading2:         block.Heading2,
+		Heading3:         block.Heading3,
+		ToDo:             block.ToDo,
+		BulletedListItem: block.BulletedListItem,
+		NumberedListItem: block.NumberedListItem,
+		Toggle:           block.Toggle,
+		Quote:            block.Quote,
+		Code:             block.Code,
+		Callout:          block.Callout,
+		Divider:          block.Divider,
+		ChildPage:        block.ChildPage,
+		ChildDatabase:    block.ChildDatabase,
+		Table:            block.Table,
+		TableRow:         block.TableRow,
+		SyncedBlock:      block.SyncedBlock,
+		Equation:         block.Equation,
+	}
+
+	if block.Table != nil && len(block.Children) > 0 {
+		table := *block.Table
+		table.Children = make([]Block, len(block.Children))
+		for i, row := range block.Children {
+			table.Children[i] = blockForWrite(row)
+		}
+		write.Table = &table
+	}
+
+	return write
+}
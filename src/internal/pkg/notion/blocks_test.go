@@ -0,0 +1,243 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+// blockStore is a minimal in-memory stand-in for the blocks endpoints
+type blockStore struct {
+	mu         sync.Mutex
+	children   map[string][]notion.Block
+	appends    []int // number of blocks per append request
+	lastUpdate map[string]any
+	nextID     int
+}
+
+func (s *blockStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	switch {
+	case r.Method == "GET" && r.PathValue("rest") == "children":
+		children := s.children[id]
+		start, _ := strconv.Atoi(r.URL.Query().Get("start_cursor"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		if size == 0 || size > 2 {
+			size = 2 // small pages to exercise pagination
+		}
+		end := min(start+size, len(children))
+		resp := notion.BlockListResponse{Object: "list", Results: children[start:end], HasMore: end < len(children)}
+		if resp.HasMore {
+			resp.NextCursor = strconv.Itoa(end)
+		}
+		json.NewEncoder(w).Encode(resp)
+
+	case r.Method == "PATCH" && r.PathValue("rest") == "children":
+		var body struct {
+			Children []notion.Block `json:"children"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.appends = append(s.appends, len(body.Children))
+
+		var created []notion.Block
+		for _, block := range body.Children {
+			s.nextID++
+			block.Object = "block"
+			block.ID = fmt.Sprintf("block-%d", s.nextID)
+			created = append(created, block)
+			s.children[id] = append(s.children[id], block)
+		}
+		for i := range s.children {
+			for j := range s.children[i] {
+				s.children[i][j].HasChildren = len(s.children[s.children[i][j].ID]) > 0
+			}
+		}
+		json.NewEncoder(w).Encode(notion.BlockListResponse{Object: "list", Results: created})
+
+	case r.Method == "PATCH":
+		body, _ := io.ReadAll(r.Body)
+		s.lastUpdate = map[string]any{}
+		json.Unmarshal(body, &s.lastUpdate)
+		w.Write([]byte(`{"object": "block", "id": "` + id + `", "type": "to_do", "to_do": {"rich_text": [], "checked": true}}`))
+
+	case r.Method == "DELETE":
+		w.Write([]byte(`{"object": "block", "id": "` + id + `", "type": "paragraph", "archived": true, "paragraph": {"rich_text": []}}`))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func paragraph(text string) notion.Block {
+	return notion.Block{
+		Type:      notion.BlockTypeParagraph,
+		Paragraph: &notion.TextBlock{RichText: []notion.RichText{{Type: "text", PlainText: text}}},
+	}
+}
+
+var _ = Describe("Blocks", func() {
+	var (
+		server *httptest.Server
+		store  *blockStore
+		blocks *notion.Blocks
+		ctx    context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		store = &blockStore{children: map[string][]notion.Block{}}
+
+		mux := http.NewServeMux()
+		mux.Handle("/v1/blocks/{id}", store)
+		mux.Handle("/v1/blocks/{id}/{rest}", store)
+		server = httptest.NewServer(mux)
+
+		blocks = notion.NewBlocks(
+			notion.WithBaseURL(server.URL+"/v1"),
+			notion.WithRateLimiter(nil),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should append in chunks of at most 100 blocks", func() {
+		var content []notion.Block
+		for i := range 250 {
+			content = append(content, paragraph(fmt.Sprintf("line %d", i)))
+		}
+
+		created, err := blocks.Append(ctx, "token", "page-1", content)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(HaveLen(250))
+		Expect(store.appends).To(Equal([]int{100, 100, 50}))
+		Expect(store.children["page-1"][249].Paragraph.RichText[0].PlainText).To(Equal("line 249"))
+	})
+
+	It("should append nested children to the created blocks", func() {
+		toggle := notion.Block{
+			Type:     notion.BlockTypeToggle,
+			Toggle:   &notion.TextBlock{RichText: []notion.RichText{{Type: "text", PlainText: "Details"}}},
+			Children: []notion.Block{paragraph("inside")},
+		}
+
+		created, err := blocks.Append(ctx, "token", "page-1", []notion.Block{toggle})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created[0].Children).To(HaveLen(1))
+		Expect(store.children[created[0].ID]).To(HaveLen(1))
+		Expect(store.children[created[0].ID][0].Paragraph.RichText[0].PlainText).To(Equal("inside"))
+	})
+
+	It("should create tables with their rows inline", func() {
+		table := notion.Block{
+			Type:  notion.BlockTypeTable,
+			Table: &notion.TableBlock{TableWidth: 2, HasColumnHeader: true},
+			Children: []notion.Block{
+				{Type: notion.BlockTypeTableRow, TableRow: &notion.TableRowBlock{Cells: [][]notion.RichText{{{PlainText: "Task"}}, {{PlainText: "Due"}}}}},
+			},
+		}
+
+		_, err := blocks.Append(ctx, "token", "page-1", []notion.Block{table})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.appends).To(Equal([]int{1}))
+		Expect(store.children["page-1"][0].Table.Children).To(HaveLen(1))
+	})
+
+	It("should retrieve a block tree across pages of children", func() {
+		list := func(text string, children ...notion.Block) notion.Block {
+			return notion.Block{
+				Type:             notion.BlockTypeBulletedListItem,
+				BulletedListItem: &notion.TextBlock{RichText: []notion.RichText{{Type: "text", PlainText: text}}},
+				Children:         children,
+			}
+		}
+		_, err := blocks.Append(ctx, "token", "page-1", []notion.Block{
+			list("one", list("one.a"), list("one.b", list("one.b.i"))),
+			list("two"),
+			list("three"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		tree, err := blocks.RetrieveTree(ctx, "token", "page-1", 0)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tree).To(HaveLen(3))
+		Expect(tree[0].Children).To(HaveLen(2))
+		Expect(tree[0].Children[1].Children[0].BulletedListItem.RichText[0].PlainText).To(Equal("one.b.i"))
+
+		shallow, err := blocks.RetrieveTree(ctx, "token", "page-1", 2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(shallow[0].Children).To(HaveLen(2))
+		Expect(shallow[0].Children[1].Children).To(BeEmpty())
+	})
+
+	It("should send only the type-specific content on update", func() {
+		updated, err := blocks.Update(ctx, "token", "block-9", &notion.Block{
+			ID:   "block-9",
+			Type: notion.BlockTypeToDo,
+			ToDo: &notion.ToDoBlock{Checked: true},
+		})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updated.ToDo.Checked).To(BeTrue())
+		Expect(store.lastUpdate).To(HaveKey("to_do"))
+		Expect(store.lastUpdate).ToNot(HaveKey("id"))
+		Expect(store.lastUpdate).ToNot(HaveKey("type"))
+	})
+
+	It("should archive deleted blocks", func() {
+		deleted, err := blocks.Delete(ctx, "token", "block-9")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deleted.Archived).To(BeTrue())
+	})
+
+	It("should decode every supported block type", func() {
+		payload := `[
+			{"type": "heading_1", "heading_1": {"rich_text": [{"plain_text": "Title"}], "is_toggleable": true}},
+			{"type": "to_do", "to_do": {"rich_text": [], "checked": true}},
+			{"type": "numbered_list_item", "numbered_list_item": {"rich_text": []}},
+			{"type": "quote", "quote": {"rich_text": [], "color": "gray"}},
+			{"type": "code", "code": {"rich_text": [{"plain_text": "go test"}], "language": "shell"}},
+			{"type": "callout", "callout": {"rich_text": [], "icon": {"type": "emoji", "emoji": "⚠️"}}},
+			{"type": "divider", "divider": {}},
+			{"type": "child_page", "child_page": {"title": "Notes"}},
+			{"type": "child_database", "child_database": {"title": "Tasks"}},
+			{"type": "synced_block", "synced_block": {"synced_from": {"type": "block_id", "block_id": "block-1"}}}
+		]`
+
+		var decoded []notion.Block
+		Expect(json.Unmarshal([]byte(payload), &decoded)).To(Succeed())
+
+		Expect(decoded[0].Heading1.IsToggleable).To(BeTrue())
+		Expect(decoded[1].ToDo.Checked).To(BeTrue())
+		Expect(decoded[2].NumberedListItem).ToNot(BeNil())
+		Expect(decoded[3].Quote.Color).To(Equal("gray"))
+		Expect(decoded[4].Code.Language).To(Equal("shell"))
+		Expect(decoded[5].Callout.Icon.Emoji).To(Equal("⚠️"))
+		Expect(decoded[6].Divider).ToNot(BeNil())
+		Expect(decoded[7].ChildPage.Title).To(Equal("Notes"))
+		Expect(decoded[8].ChildDatabase.Title).To(Equal("Tasks"))
+		Expect(decoded[9].SyncedBlock.SyncedFrom.BlockID).To(Equal("block-1"))
+	})
+})
@@ -0,0 +1,307 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrCassetteMiss is returned when a replaying cassette has no recorded response for a request
+var ErrCassetteMiss = errors.New("no recorded interaction matches the request")
+
+// CassetteMode tells a cassette whether to call the API or serve recorded responses
+type CassetteMode string
+
+const (
+	// CassetteReplay serves recorded responses and never touches the network
+	CassetteReplay CassetteMode = "replay"
+	// CassetteRecord sends requests to the API and rewrites the cassette with the responses
+	CassetteRecord CassetteMode = "record"
+	// CassetteAuto replays if the cassette file exists and records otherwise
+	CassetteAuto CassetteMode = "auto"
+)
+
+// redacted replaces secrets in recorded interactions
+const redacted = "[REDACTED]"
+
+// recordedResponseHeaders are kept in cassettes; the rest vary between runs
+var recordedResponseHeaders = []string{"Content-Type", "Retry-After"}
+
+// secretRequestFields are request JSON fields whose values never reach a cassette.
+// OAuth codes and client secrets only appear in requests; responses use "code"
+// for error codes, which cassettes must keep.
+var (
+	secretRequestFields  = regexp.MustCompile(`"(access_token|refresh_token|code|client_secret)"(\s*):(\s*)"[^"]*"`)
+	secretResponseFields = regexp.MustCompile(`"(access_token|refresh_token)"(\s*):(\s*)"[^"]*"`)
+)
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is what a request is matched on: method, path with query, and body.
+// The body is canonical JSON, so key order and whitespace do not matter.
+type CassetteRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// CassetteResponse is a recorded response
+type CassetteResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+}
+
+// Cassette is an http.RoundTripper that records Notion API interactions to a JSON
+// file and replays them. Tokens and OAuth secrets are redacted before saving;
+// request headers are not recorded at all. Identical requests are replayed in the
+// order they were recorded, and the last match is repeated once they run out.
+type Cassette struct {
+	// Transport sends requests while recording; http.DefaultTransport if nil
+	Transport http.RoundTripper
+
+	path         string
+	mode         CassetteMode
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewCassette opens the cassette at path, usually testdata/cassettes/<name>.json.
+// Replaying requires the file to exist; recording starts from an empty cassette.
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	if mode == CassetteAuto {
+		mode = CassetteReplay
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			mode = CassetteRecord
+		}
+	}
+
+	cassette := &Cassette{path: path, mode: mode}
+	if mode == CassetteRecord {
+		return cassette, nil
+	}
+	if mode != CassetteReplay {
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &cassette.interactions); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	// Saved cassettes are indented and may be edited by hand
+	for i := range cassette.interactions {
+		cassette.interactions[i].Request.Body = canonicalJSON(cassette.interactions[i].Request.Body)
+	}
+	cassette.used = make([]bool, len(cassette.interactions))
+	return cassette, nil
+}
+
+// WithCassette sends the client's requests through cassette. It keeps the timeout of
+// the current HTTP client, so pass it after WithHTTPClient.
+func WithCassette(cassette *Cassette) ClientOption {
+	return func(c *Client) {
+		c.httpClient = &http.Client{
+			Transport: cassette,
+			Timeout:   c.httpClient.Timeout,
+		}
+	}
+}
+
+// Mode reports whether the cassette is recording or replaying
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+// Interactions returns the recorded interactions
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := CassetteRequest{
+		Method: req.Method,
+		Path:   requestPath(req.URL),
+		Body:   redact(canonicalJSON(body), bearerToken(req), secretRequestFields),
+	}
+
+	if c.mode == CassetteReplay {
+		return c.replay(req, recorded)
+	}
+	return c.record(req, recorded)
+}
+
+func (c *Cassette) replay(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, interaction := range c.interactions {
+		if !interaction.Request.matches(recorded) {
+			continue
+		}
+		last = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("%w: %s %s %s", ErrCassetteMiss, recorded.Method, recorded.Path, recorded.Body)
+	}
+	c.used[last] = true
+
+	recordedResp := c.interactions[last].Response
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResp.StatusCode, http.StatusText(recordedResp.StatusCode)),
+		StatusCode:    recordedResp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          io.NopCloser(bytes.NewReader(recordedResp.Body)),
+		ContentLength: int64(len(recordedResp.Body)),
+		Request:       req,
+	}
+	for name, value := range recordedResp.Headers {
+		resp.Header.Set(name, value)
+	}
+	return resp, nil
+}
+
+func (c *Cassette) record(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recordedResp := CassetteResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    make(map[string]string),
+		Body:       redact(canonicalJSON(body), bearerToken(req), secretResponseFields),
+	}
+	for _, name := range recordedResponseHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			recordedResp.Headers[name] = value
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, Interaction{Request: recorded, Response: recordedResp})
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes all interactions to the cassette file
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+func (r CassetteRequest) matches(other CassetteRequest) bool {
+	return r.Method == other.Method && r.Path == other.Path && bytes.Equal(r.Body, other.Body)
+}
+
+// requestPath is the URL path relative to the API version prefix, with sorted query
+// parameters, so cassettes do not depend on the base URL they were recorded against
+func requestPath(u *url.URL) string {
+	path := u.Path
+	if _, rest, found := strings.Cut(path, "/v1/"); found {
+		path = "/" + rest
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.Query().Encode()
+	}
+	return path
+}
+
+// canonicalJSON re-encodes body with sorted keys and no whitespace; non-JSON bodies are
+// recorded as JSON strings
+func canonicalJSON(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		value = string(body)
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return canonical
+}
+
+// redact removes the request's token and the values of secret fields from a recorded body
+func redact(body json.RawMessage, token string, secretFields *regexp.Regexp) json.RawMessage {
+	if body == nil {
+		return nil
+	}
+	if token != "" {
+		body = bytes.ReplaceAll(body, []byte(token), []byte(redacted))
+	}
+	return secretFields.ReplaceAll(body, []byte(`"$1"$2:$3"`+redacted+`"`))
+}
+
+func bearerToken(req *http.Request) string {
+	scheme, token, _ := strings.Cut(req.Header.Get("Authorization"), " ")
+	if scheme != authTypeBearer {
+		return ""
+	}
+	return token
+}
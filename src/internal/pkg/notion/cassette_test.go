@@ -0,0 +1,158 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+	"src/internal/pkg/notion/notiontest"
+)
+
+var _ = Describe("Cassette", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("replays captured database queries", func() {
+		cassette, err := notion.NewCassette(filepath.Join("testdata", "cassettes", "databases_query.json"), notion.CassetteReplay)
+		Expect(err).ToNot(HaveOccurred())
+		databases := notion.NewDatabases(notion.WithCassette(cassette), notion.WithRateLimiter(nil))
+
+		filter := notion.Where("Status").Status().DoesNotEqual("Done")
+		pages, err := notion.CollectAll(databases.QueryAll(ctx, "secret_any", "8f1c2e4a-5b6d-4e7f-9a0b-1c2d3e4f5a6b", &notion.DatabaseQueryRequest{
+			Filter:   &filter,
+			PageSize: 1,
+		}), 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pages).To(HaveLen(2))
+
+		launch := pages[0].Properties
+		Expect(launch["Name"].Title[0].PlainText).To(Equal("Launch beta"))
+		Expect(launch["Notes"].RichText).To(HaveLen(2))
+		Expect(launch["Notes"].RichText[1].Mention).ToNot(BeNil())
+		Expect(launch["Status"].Status.Name).To(Equal("In progress"))
+		Expect(launch["Priority"].Select.Name).To(Equal("High"))
+		Expect(launch["Tags"].MultiSelect).To(HaveLen(2))
+		Expect(*launch["Estimate"].Number).To(Equal(5.0))
+		Expect(launch["Dates"].Date.Start).To(Equal("2025-10-20"))
+		Expect(*launch["Dates"].Date.End).To(Equal("2025-10-24"))
+		Expect(launch["Assignee"].People[0].Person.Email).To(Equal("ada@example.com"))
+		Expect(launch["Blocked by"].Relation[0].ID).To(Equal(pages[1].ID))
+		Expect(*launch["Critical"].Checkbox).To(BeTrue())
+		Expect(*launch["Spec"].URL).To(Equal("https://example.com/spec"))
+		Expect(*launch["Days left"].Formula.Number).To(Equal(4.0))
+		Expect(*launch["Blocker count"].Rollup.Number).To(Equal(1.0))
+		Expect(launch["Created"].CreatedTime).ToNot(BeNil())
+
+		freeze := pages[1].Properties
+		Expect(freeze["Priority"].Select).To(BeNil())
+		Expect(freeze["Estimate"].Number).To(BeNil())
+		Expect(freeze["Dates"].Date.End).To(BeNil())
+		Expect(freeze["Spec"].URL).To(BeNil())
+		Expect(*freeze["Critical"].Checkbox).To(BeFalse())
+	})
+
+	It("fails requests it has no recording for", func() {
+		cassette, err := notion.NewCassette(filepath.Join("testdata", "cassettes", "databases_query.json"), notion.CassetteReplay)
+		Expect(err).ToNot(HaveOccurred())
+		databases := notion.NewDatabases(notion.WithCassette(cassette), notion.WithRateLimiter(nil))
+
+		_, err = databases.Query(ctx, "secret_any", "8f1c2e4a-5b6d-4e7f-9a0b-1c2d3e4f5a6b", &notion.DatabaseQueryRequest{PageSize: 1})
+		Expect(errors.Is(err, notion.ErrCassetteMiss)).To(BeTrue())
+	})
+
+	It("records redacted interactions that replay without the API", func() {
+		server := notiontest.NewServer()
+		DeferCleanup(server.Close)
+		server.SetOAuthClient("client-id", "client-secret")
+		server.AddOAuthCode("code-1", notion.OAuthTokenResponse{AccessToken: "secret_recorded", Owner: notion.User{ID: "user-1", Name: "Ada"}})
+		database := server.AddDatabase(notion.Database{Properties: map[string]notion.Property{"Name": {Type: "title", Title: &struct{}{}}}})
+		server.AddPage(notion.Page{
+			Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+			Properties: map[string]notion.PropertyValue{"Name": {Title: []notion.RichText{{PlainText: "Recorded"}}}},
+		})
+
+		path := filepath.Join(GinkgoT().TempDir(), "cassettes", "oauth_and_query.json")
+		run := func(mode notion.CassetteMode) (*notion.OAuthTokenResponse, *notion.DatabaseQueryResponse) {
+			cassette, err := notion.NewCassette(path, mode)
+			Expect(err).ToNot(HaveOccurred())
+			service := notion.NewService(notion.ServiceConfig{ClientID: "client-id", ClientSecret: "client-secret"},
+				append(server.ClientOptions(), notion.WithCassette(cassette))...)
+
+			token, err := service.ExchangeCodeForToken(ctx, "code-1")
+			Expect(err).ToNot(HaveOccurred())
+			result, err := service.QueryDatabase(ctx, token.AccessToken, database.ID, &notion.DatabaseQueryRequest{PageSize: 10})
+			Expect(err).ToNot(HaveOccurred())
+			return token, result
+		}
+
+		recordedToken, recordedResult := run(notion.CassetteAuto)
+
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).ToNot(ContainSubstring("secret_recorded"))
+		Expect(string(data)).ToNot(ContainSubstring("code-1"))
+		Expect(string(data)).ToNot(ContainSubstring("client-secret"))
+
+		// The server forgets the code after one exchange, so this only passes when replaying
+		requests := len(server.Requests())
+		replayedToken, replayedResult := run(notion.CassetteAuto)
+		Expect(server.Requests()).To(HaveLen(requests))
+		Expect(replayedToken.AccessToken).To(Equal("[REDACTED]"))
+		Expect(replayedToken.Owner.Name).To(Equal(recordedToken.Owner.Name))
+		Expect(replayedResult.Results).To(HaveLen(1))
+		Expect(replayedResult.Results[0].ID).To(Equal(recordedResult.Results[0].ID))
+	})
+
+	It("records error responses with their error code", func() {
+		server := notiontest.NewServer()
+		DeferCleanup(server.Close)
+
+		path := filepath.Join(GinkgoT().TempDir(), "cassettes", "page_not_found.json")
+		run := func() error {
+			cassette, err := notion.NewCassette(path, notion.CassetteAuto)
+			Expect(err).ToNot(HaveOccurred())
+			pages := notion.NewPages(append(server.ClientOptions(), notion.WithCassette(cassette))...)
+
+			_, err = pages.Retrieve(ctx, notiontest.DefaultToken, "missing-page")
+			return err
+		}
+
+		for _, err := range []error{run(), run()} {
+			var apiErr *notion.APIError
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.Status).To(Equal(http.StatusNotFound))
+			Expect(apiErr.Code).To(Equal("object_not_found"))
+		}
+		Expect(server.Requests()).To(HaveLen(1))
+	})
+
+	It("matches bodies regardless of key order and whitespace", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "search.json")
+		Expect(os.WriteFile(path, []byte(`[{
+			"request": {"method": "POST", "path": "/search", "body": {"query": "tasks", "page_size": 10}},
+			"response": {"status_code": 200, "body": {"object": "list", "results": [], "has_more": false}}
+		}]`), 0o644)).To(Succeed())
+
+		cassette, err := notion.NewCassette(path, notion.CassetteReplay)
+		Expect(err).ToNot(HaveOccurred())
+		client := &http.Client{Transport: cassette}
+
+		req, err := http.NewRequest(http.MethodPost, "https://api.notion.com/v1/search", strings.NewReader(`{ "page_size": 10,
+			"query": "tasks" }`))
+		Expect(err).ToNot(HaveOccurred())
+		resp, err := client.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+})
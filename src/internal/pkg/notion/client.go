@@ -2,10 +2,12 @@ package notion
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -19,6 +21,8 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	apiVersion string
+	limiter    RateLimiter
+	retry      RetryPolicy
 }
 
 // ClientOption represents a configuration option for the Client
@@ -31,6 +35,14 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithBaseURL points the client at another API root, e.g. a notiontest.Server.
+// The URL includes the version prefix, like the default "https://api.notion.com/v1".
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
 // WithAPIVersion sets a custom API version
 func WithAPIVersion(version string) ClientOption {
 	return func(c *Client) {
@@ -38,11 +50,28 @@ func WithAPIVersion(version string) ClientOption {
 	}
 }
 
+// WithRateLimiter sets the limiter requests wait on; nil disables rate limiting.
+// By default all clients in the process share one in-memory limiter.
+func WithRateLimiter(limiter RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithRetryPolicy sets how rate limited and unavailable responses are retried
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
 // NewClient creates a new Notion API client
 func NewClient(opts ...ClientOption) *Client {
 	client := &Client{
 		baseURL:    "https://api.notion.com/v1",
 		apiVersion: "2022-06-28",
+		limiter:    defaultRateLimiter,
+		retry:      DefaultRetryPolicy,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 			Jar:     nil,
@@ -56,53 +85,78 @@ func NewClient(opts ...ClientOption) *Client {
 	return client
 }
 
-// makeRequest performs an HTTP request to the Notion API
-func (c *Client) makeRequest(method, path string, body interface{}, token string, authType string) (*http.Response, error) {
-	var reqBody io.Reader
+// makeRequest performs an HTTP request to the Notion API.
+// The request, rate limiter waits and retry backoff are all bound to ctx.
+// Requests wait on the rate limiter, and 429 responses, as well as 502, 503 and
+// 504 responses to GET, PATCH and DELETE requests, are retried according to the
+// retry policy; once retries are exhausted a *RetryError is returned.
+func (c *Client) makeRequest(ctx context.Context, method, path string, body interface{}, token string, authType string) (*http.Response, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequest(method, url, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set required headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Notion-Version", c.apiVersion)
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, token); err != nil {
+				return nil, fmt.Errorf("failed to wait for rate limiter: %w", err)
+			}
+		}
 
-	if token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("%s %s", authType, token))
-	}
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return resp, fmt.Errorf("failed to execute request: %w", err)
-	}
+		var reqBody io.Reader
+		if jsonBody != nil {
+			reqBody = bytes.NewReader(jsonBody)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	return resp, nil
-}
+		// Set required headers
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Notion-Version", c.apiVersion)
 
-// handleResponse handles the API response
-func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
-		var apiErr APIError
-		bodyBytes, err := io.ReadAll(resp.Body)
+		if token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("%s %s", authType, token))
+		}
+		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			return fmt.Errorf("failed to read error response body: %w", err)
+			return resp, fmt.Errorf("failed to execute request: %w", err)
 		}
 
-		defer resp.Body.Close()
+		if !isRetryable(method, resp.StatusCode) {
+			return resp, nil
+		}
 
-		if err := json.Unmarshal(bodyBytes, &apiErr); err != nil {
-			return fmt.Errorf("failed to unmarshal error response (status: %s): %s", resp.Status, string(bodyBytes))
+		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+		if attempt > c.retry.MaxRetries {
+			return nil, &RetryError{
+				Attempts:   attempt,
+				StatusCode: resp.StatusCode,
+				RetryAfter: retryAfter,
+				Err:        readAPIError(resp),
+			}
 		}
-		return &apiErr
+
+		// Drain the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleepContext(ctx, c.retry.delay(attempt, retryAfter)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// handleResponse handles the API response
+func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return readAPIError(resp)
 	}
 
 	defer resp.Body.Close()
@@ -114,3 +168,19 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 
 	return nil
 }
+
+// readAPIError reads and closes an error response, returning the *APIError Notion sent
+func readAPIError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	var apiErr APIError
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read error response body: %w", err)
+	}
+
+	if err := json.Unmarshal(bodyBytes, &apiErr); err != nil {
+		return fmt.Errorf("failed to unmarshal error response (status: %s): %s", resp.Status, string(bodyBytes))
+	}
+	return &apiErr
+}
@@ -0,0 +1,246 @@
+package notion_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+// testClientOptions points a client at server without rate limiting
+func testClientOptions(server *httptest.Server) []notion.ClientOption {
+
+	return []notion.ClientOption{
+		notion.WithBaseURL(server.URL + "/v1"),
+		notion.WithRateLimiter(nil),
+	}
+}
+
+// countingLimiter records which keys waited
+type countingLimiter struct {
+	waits atomic.Int32
+	key   atomic.Value
+}
+
+func (l *countingLimiter) Wait(ctx context.Context, key string) error {
+	l.waits.Add(1)
+	l.key.Store(key)
+	return nil
+}
+
+var _ = Describe("Client", func() {
+	var (
+		server   *httptest.Server
+		handler  http.HandlerFunc
+		requests atomic.Int32
+		limiter  *countingLimiter
+		pages    *notion.Pages
+		ctx      context.Context
+	)
+
+	fastRetries := notion.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		requests.Store(0)
+		limiter = &countingLimiter{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			handler(w, r)
+		}))
+
+		pages = notion.NewPages(
+			notion.WithBaseURL(server.URL+"/v1"),
+			notion.WithRateLimiter(limiter),
+			notion.WithRetryPolicy(fastRetries),
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	rateLimited := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"object": "error", "status": 429, "code": "rate_limited", "message": "Slow down"}`))
+	}
+
+	It("should retry rate limited requests until they succeed", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if requests.Load() < 3 {
+				rateLimited(w)
+				return
+			}
+			w.Write([]byte(`{"object": "page", "id": "page-1"}`))
+		}
+
+		page, err := pages.Retrieve(ctx, "token-1", "page-1")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(page.ID).To(Equal("page-1"))
+		Expect(requests.Load()).To(Equal(int32(3)))
+		Expect(limiter.waits.Load()).To(Equal(int32(3)))
+		Expect(limiter.key.Load()).To(Equal("token-1"))
+	})
+
+	It("should resend the request body on retries", func() {
+		var bodies []string
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			buf := make([]byte, r.ContentLength)
+			r.Body.Read(buf)
+			bodies = append(bodies, string(buf))
+			if requests.Load() == 1 {
+				rateLimited(w)
+				return
+			}
+			w.Write([]byte(`{"object": "page", "id": "page-2"}`))
+		}
+
+		_, err := pages.Create(ctx, "token-1", &notion.CreatePageRequest{})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bodies).To(HaveLen(2))
+		Expect(bodies[1]).To(Equal(bodies[0]))
+		Expect(bodies[0]).ToNot(BeEmpty())
+	})
+
+	It("should retry updates answered with a gateway error", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if requests.Load() == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"object": "page", "id": "page-1"}`))
+		}
+
+		_, err := pages.Update(ctx, "token-1", "page-1", &notion.UpdatePageRequest{})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests.Load()).To(Equal(int32(2)))
+	})
+
+	It("should not repeat a POST answered with a gateway error", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+
+		_, err := pages.Create(ctx, "token-1", &notion.CreatePageRequest{})
+
+		Expect(err).To(HaveOccurred())
+		var retryErr *notion.RetryError
+		Expect(errors.As(err, &retryErr)).To(BeFalse())
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("should return a RetryError once retries are exhausted", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			rateLimited(w)
+		}
+
+		_, err := pages.Retrieve(ctx, "token-1", "page-1")
+
+		var retryErr *notion.RetryError
+		Expect(errors.As(err, &retryErr)).To(BeTrue())
+		Expect(retryErr.Attempts).To(Equal(4))
+		Expect(retryErr.StatusCode).To(Equal(http.StatusTooManyRequests))
+
+		var apiErr *notion.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Code).To(Equal("rate_limited"))
+		Expect(requests.Load()).To(Equal(int32(4)))
+	})
+
+	It("should wait at least as long as Retry-After", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if requests.Load() == 1 {
+				w.Header().Set("Retry-After", "1")
+				rateLimited(w)
+				return
+			}
+			w.Write([]byte(`{"object": "page", "id": "page-1"}`))
+		}
+
+		start := time.Now()
+		_, err := pages.Retrieve(ctx, "token-1", "page-1")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+	})
+
+	It("should not retry client errors", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"object": "error", "status": 404, "code": "object_not_found", "message": "Not found"}`))
+		}
+
+		_, err := pages.Retrieve(ctx, "token-1", "page-1")
+
+		var apiErr *notion.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Code).To(Equal("object_not_found"))
+
+		var retryErr *notion.RetryError
+		Expect(errors.As(err, &retryErr)).To(BeFalse())
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("should abort in-flight requests when the context is cancelled", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := pages.Retrieve(ctx, "token-1", "page-1")
+
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	It("should stop retrying when the context is cancelled during backoff", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			rateLimited(w)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := pages.Retrieve(ctx, "token-1", "page-1")
+
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+})
+
+var _ = Describe("TokenBucketLimiter", func() {
+	It("should throttle each token separately", func() {
+		limiter := notion.NewTokenBucketLimiter(10, 1)
+		ctx := context.Background()
+
+		start := time.Now()
+		Expect(limiter.Wait(ctx, "token-a")).To(Succeed())
+		Expect(limiter.Wait(ctx, "token-b")).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", 50*time.Millisecond))
+
+		Expect(limiter.Wait(ctx, "token-a")).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically(">=", 80*time.Millisecond))
+	})
+
+	It("should stop waiting when the context is cancelled", func() {
+		limiter := notion.NewTokenBucketLimiter(0.1, 1)
+		Expect(limiter.Wait(context.Background(), "token-a")).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		Expect(limiter.Wait(ctx, "token-a")).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,464 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Struct fields are mapped to page properties with `notion` tags:
+//
+//	type Task struct {
+//		ID        string     `notion:",id"`
+//		Name      string     `notion:"Name,title"`
+//		Status    string     `notion:"Status,status"`
+//		Due       *time.Time `notion:"Due,date"`
+//		Estimate  *float64   `notion:"Estimate,number,omitempty"`
+//		Assignees []string   `notion:"Assignee,people"`
+//		BlockedBy []string   `notion:"Blocked by,relation"`
+//	}
+//
+// The first tag value is the property name and the second its type. The type is
+// checked when decoding and required when encoding. A field tagged ",id" receives
+// the page ID. Untagged fields and fields tagged "-" are ignored.
+//
+// Supported field types are string, bool, integer and float kinds, []string (option
+// names, user IDs or page IDs), []User, time.Time, DateRange, UniqueIDValue,
+// Verification and PropertyValue, and pointers to them. Empty properties decode to
+// nil pointers, and nil pointers encode as empty values that clear the property.
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	dateRangeType     = reflect.TypeFor[DateRange]()
+	uniqueIDType      = reflect.TypeFor[UniqueIDValue]()
+	verificationType  = reflect.TypeFor[Verification]()
+	propertyValueType = reflect.TypeFor[PropertyValue]()
+	userSliceType     = reflect.TypeFor[[]User]()
+	stringSliceType   = reflect.TypeFor[[]string]()
+)
+
+// readOnlyPropertyTypes are computed by Notion and skipped when encoding
+var readOnlyPropertyTypes = map[string]bool{
+	"formula":          true,
+	"rollup":           true,
+	"unique_id":        true,
+	"verification":     true,
+	"created_time":     true,
+	"created_by":       true,
+	"last_edited_time": true,
+	"last_edited_by":   true,
+}
+
+type fieldTag struct {
+	name      string
+	typ       string
+	omitempty bool
+}
+
+// DecodePage copies page properties into the struct dst points to
+func DecodePage(page *Page, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("notion: DecodePage needs a pointer to a struct, got %T", dst)
+	}
+
+	return eachTaggedField(v.Elem(), func(field reflect.Value, tag fieldTag) error {
+		if tag.typ == "id" {
+			return setString(field, page.ID)
+		}
+
+		value, err := page.Property(tag.name)
+		if err != nil {
+			return err
+		}
+		if tag.typ != "" && tag.typ != value.Type {
+			return &PropertyTypeError{Property: tag.name, Type: value.Type, Expected: []string{tag.typ}}
+		}
+		if err := decodeField(page, tag.name, value, field); err != nil {
+			return fmt.Errorf("notion: cannot decode property %q into %s: %w", tag.name, field.Type(), err)
+		}
+		return nil
+	})
+}
+
+// EncodeProperties builds property values from the tagged fields of src, a struct or
+// a pointer to one. Read-only property types such as formulas are skipped.
+func EncodeProperties(src any) (map[string]PropertyValue, error) {
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("notion: EncodeProperties needs a struct, got %T", src)
+	}
+
+	properties := make(map[string]PropertyValue)
+	err := eachTaggedField(v, func(field reflect.Value, tag fieldTag) error {
+		if tag.typ == "id" || readOnlyPropertyTypes[tag.typ] || (tag.omitempty && field.IsZero()) {
+			return nil
+		}
+		if tag.typ == "" {
+			return fmt.Errorf("notion: property %q needs a type to be encoded", tag.name)
+		}
+
+		value, err := encodeField(tag.typ, field)
+		if err != nil {
+			return fmt.Errorf("notion: cannot encode %s as %s property %q: %w", field.Type(), tag.typ, tag.name, err)
+		}
+		properties[tag.name] = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// EncodePage builds a request updating the properties tagged in src
+func EncodePage(src any) (*UpdatePageRequest, error) {
+	properties, err := EncodeProperties(src)
+	if err != nil {
+		return nil, err
+	}
+	return &UpdatePageRequest{Properties: properties}, nil
+}
+
+func eachTaggedField(v reflect.Value, fn func(field reflect.Value, tag fieldTag) error) error {
+	for i := range v.NumField() {
+		structField := v.Type().Field(i)
+		raw, ok := structField.Tag.Lookup("notion")
+		if !ok || raw == "-" || !structField.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(raw, ",")
+		tag := fieldTag{name: parts[0]}
+		if len(parts) > 1 {
+			tag.typ = parts[1]
+		}
+		for _, option := range parts[min(2, len(parts)):] {
+			if option == "omitempty" {
+				tag.omitempty = true
+			}
+		}
+		if tag.name == "" && tag.typ != "id" {
+			tag.name = structField.Name
+		}
+
+		if err := fn(v.Field(i), tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errUnsupportedField = errors.New("unsupported field type")
+
+// decodeField sets field from value; pointers are left nil when the value is empty
+func decodeField(page *Page, name string, value PropertyValue, field reflect.Value) error {
+	if field.Kind() != reflect.Pointer {
+		_, err := decodeValue(page, name, value, field)
+		return err
+	}
+
+	target := reflect.New(field.Type().Elem())
+	empty, err := decodeValue(page, name, value, target.Elem())
+	if err != nil {
+		return err
+	}
+	if empty {
+		field.SetZero()
+	} else {
+		field.Set(target)
+	}
+	return nil
+}
+
+func decodeValue(page *Page, name string, value PropertyValue, field reflect.Value) (empty bool, err error) {
+	switch field.Type() {
+	case propertyValueType:
+		field.Set(reflect.ValueOf(value))
+		return false, nil
+	case timeType, dateRangeType:
+		date, err := page.Date(name)
+		if err != nil || date == nil {
+			return true, err
+		}
+		if field.Type() == timeType {
+			field.Set(reflect.ValueOf(date.Start))
+		} else {
+			field.Set(reflect.ValueOf(*date))
+		}
+		return false, nil
+	case uniqueIDType:
+		id, err := page.UniqueID(name)
+		if err != nil || id == nil {
+			return true, err
+		}
+		field.Set(reflect.ValueOf(*id))
+		return false, nil
+	case verificationType:
+		verification, err := page.Verification(name)
+		if err != nil || verification == nil {
+			return true, err
+		}
+		field.Set(reflect.ValueOf(*verification))
+		return false, nil
+	case userSliceType:
+		users, err := page.People(name)
+		if err != nil {
+			return true, err
+		}
+		field.Set(reflect.ValueOf(users))
+		return len(users) == 0, nil
+	case stringSliceType:
+		values, err := stringsOf(page, name, value)
+		if err != nil {
+			return true, err
+		}
+		field.Set(reflect.ValueOf(values))
+		return len(values) == 0, nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		text, err := textOf(page, name, value)
+		if err != nil {
+			return true, err
+		}
+		field.SetString(text)
+		return text == "", nil
+	case reflect.Bool:
+		checked, err := page.Checkbox(name)
+		if err != nil {
+			return true, err
+		}
+		field.SetBool(checked)
+		return false, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		number, err := numberOf(page, name, value)
+		if err != nil || number == nil {
+			return true, err
+		}
+		return false, setNumber(field, *number)
+	}
+	return true, errUnsupportedField
+}
+
+// textOf reads any property with a natural string form
+func textOf(page *Page, name string, value PropertyValue) (string, error) {
+	switch value.Type {
+	case "select":
+		return page.Select(name)
+	case "status":
+		return page.Status(name)
+	case "unique_id":
+		if value.UniqueID == nil {
+			return "", nil
+		}
+		return value.UniqueID.String(), nil
+	default:
+		return page.Text(name)
+	}
+}
+
+func numberOf(page *Page, name string, value PropertyValue) (*float64, error) {
+	if value.Type == "unique_id" {
+		if value.UniqueID == nil {
+			return nil, nil
+		}
+		number := float64(value.UniqueID.Number)
+		return &number, nil
+	}
+	return page.Number(name)
+}
+
+// stringsOf reads option names, user IDs or related page IDs
+func stringsOf(page *Page, name string, value PropertyValue) ([]string, error) {
+	switch value.Type {
+	case "multi_select":
+		return page.MultiSelect(name)
+	case "relation":
+		return page.Relations(name)
+	case "people", "created_by", "last_edited_by":
+		users, err := page.People(name)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, len(users))
+		for i, user := range users {
+			ids[i] = user.ID
+		}
+		return ids, nil
+	default:
+		return nil, &PropertyTypeError{Property: name, Type: value.Type, Expected: []string{"multi_select", "relation", "people"}}
+	}
+}
+
+func setString(field reflect.Value, s string) error {
+	if field.Kind() == reflect.Pointer {
+		field.Set(reflect.ValueOf(&s))
+		return nil
+	}
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("notion: page ID needs a string field, got %s", field.Type())
+	}
+	field.SetString(s)
+	return nil
+}
+
+func setNumber(field reflect.Value, number float64) error {
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(number)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.OverflowInt(int64(number)) {
+			return fmt.Errorf("%v overflows %s", number, field.Type())
+		}
+		field.SetInt(int64(number))
+	default:
+		if number < 0 || field.OverflowUint(uint64(number)) {
+			return fmt.Errorf("%v overflows %s", number, field.Type())
+		}
+		field.SetUint(uint64(number))
+	}
+	return nil
+}
+
+// encodeField builds a property value of type typ; nil pointers clear the property
+func encodeField(typ string, field reflect.Value) (PropertyValue, error) {
+	value := PropertyValue{Type: typ}
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return value, nil
+		}
+		field = field.Elem()
+	}
+
+	switch typ {
+	case "title", "rich_text":
+		if field.Kind() != reflect.String {
+			return value, errUnsupportedField
+		}
+		var text []RichText
+		if field.String() != "" {
+			text = []RichText{NewText(field.String())}
+		}
+		if typ == "title" {
+			value.Title = text
+		} else {
+			value.RichText = text
+		}
+	case "url", "email", "phone_number":
+		if field.Kind() != reflect.String {
+			return value, errUnsupportedField
+		}
+		if s := field.String(); s != "" {
+			switch typ {
+			case "url":
+				value.URL = &s
+			case "email":
+				value.Email = &s
+			default:
+				value.PhoneNumber = &s
+			}
+		}
+	case "select", "status":
+		if field.Kind() != reflect.String {
+			return value, errUnsupportedField
+		}
+		if s := field.String(); s != "" {
+			if typ == "select" {
+				value.Select = &SelectOption{Name: s}
+			} else {
+				value.Status = &SelectOption{Name: s}
+			}
+		}
+	case "multi_select":
+		names, ok := field.Interface().([]string)
+		if !ok {
+			return value, errUnsupportedField
+		}
+		for _, name := range names {
+			value.MultiSelect = append(value.MultiSelect, SelectOption{Name: name})
+		}
+	case "number":
+		switch {
+		case field.CanFloat():
+			number := field.Float()
+			value.Number = &number
+		case field.CanInt():
+			number := float64(field.Int())
+			value.Number = &number
+		case field.CanUint():
+			number := float64(field.Uint())
+			value.Number = &number
+		default:
+			return value, errUnsupportedField
+		}
+	case "checkbox":
+		if field.Kind() != reflect.Bool {
+			return value, errUnsupportedField
+		}
+		checked := field.Bool()
+		value.Checkbox = &checked
+	case "date":
+		switch date := field.Interface().(type) {
+		case time.Time:
+			if !date.IsZero() {
+				value.Date = &DateValue{Start: formatDate(date)}
+			}
+		case DateRange:
+			value.Date = encodeDateRange(date)
+		default:
+			return value, errUnsupportedField
+		}
+	case "people":
+		switch people := field.Interface().(type) {
+		case []string:
+			for _, id := range people {
+				value.People = append(value.People, User{Object: "user", ID: id})
+			}
+		case []User:
+			for _, user := range people {
+				value.People = append(value.People, User{Object: "user", ID: user.ID})
+			}
+		default:
+			return value, errUnsupportedField
+		}
+	case "relation":
+		ids, ok := field.Interface().([]string)
+		if !ok {
+			return value, errUnsupportedField
+		}
+		for _, id := range ids {
+			value.Relation = append(value.Relation, Relation{ID: id})
+		}
+	default:
+		return value, fmt.Errorf("property type %s cannot be encoded", typ)
+	}
+	return value, nil
+}
+
+func encodeDateRange(date DateRange) *DateValue {
+	if date.Start.IsZero() {
+		return nil
+	}
+	format := func(t time.Time) string {
+		if !date.HasTime {
+			return t.Format(time.DateOnly)
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	value := &DateValue{Start: format(date.Start)}
+	if date.End != nil {
+		end := format(*date.End)
+		value.End = &end
+	}
+	return value
+}
@@ -0,0 +1,79 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// Comments provides methods for working with comments
+type Comments struct {
+	client *Client
+}
+
+// NewComments creates a new Comments client
+func NewComments(opts ...ClientOption) *Comments {
+	return &Comments{
+		client: NewClient(opts...),
+	}
+}
+
+// NewPageComment builds a request starting a new discussion on a page
+func NewPageComment(pageID string, richText ...RichText) *CreateCommentRequest {
+	return &CreateCommentRequest{
+		Parent:   &Parent{Type: "page_id", PageID: pageID},
+		RichText: richText,
+	}
+}
+
+// NewDiscussionReply builds a request replying to an existing discussion
+func NewDiscussionReply(discussionID string, richText ...RichText) *CreateCommentRequest {
+	return &CreateCommentRequest{
+		DiscussionID: discussionID,
+		RichText:     richText,
+	}
+}
+
+// Create creates a comment on a page or in a discussion
+func (c *Comments) Create(ctx context.Context, accessToken string, request *CreateCommentRequest) (*Comment, error) {
+	resp, err := c.client.makeRequest(ctx, "POST", "/comments", request, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	var comment Comment
+	if err := c.client.handleResponse(resp, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse create comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// List lists one page of the unresolved comments on a page or block
+func (c *Comments) List(ctx context.Context, accessToken, blockID string, startCursor string, pageSize int) (*CommentListResponse, error) {
+	params := paginationParams(startCursor, pageSize)
+	params.Set("block_id", blockID)
+
+	resp, err := c.client.makeRequest(ctx, "GET", withQuery("/comments", params), nil, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	var listResp CommentListResponse
+	if err := c.client.handleResponse(resp, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to parse comment list response: %w", err)
+	}
+
+	return &listResp, nil
+}
+
+// ListAll iterates over all unresolved comments on a page or block, following cursors
+func (c *Comments) ListAll(ctx context.Context, accessToken, blockID string) iter.Seq2[Comment, error] {
+	return paginate(ctx, "", func(ctx context.Context, cursor string) ([]Comment, string, bool, error) {
+		resp, err := c.List(ctx, accessToken, blockID, cursor, 100)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.Results, resp.NextCursor, resp.HasMore, nil
+	})
+}
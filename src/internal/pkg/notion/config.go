@@ -0,0 +1,27 @@
+package notion
+
+import (
+	"github.com/redis/go-redis/v9"
+
+	"src/internal/config"
+)
+
+// ClientOptionsFromConfig configures Notion API throttling and retries from config.
+// redisClient is used when NOTION_RATE_LIMIT_BACKEND=redis so that every process
+// shares the same per-token budget. Every call creates a new limiter, so a process
+// calls it once and shares the options between its clients.
+func ClientOptionsFromConfig(redisClient *redis.Client) []ClientOption {
+	cfg := config.Get().NotionClient
+
+	policy := DefaultRetryPolicy
+	policy.MaxRetries = cfg.MaxRetries
+	opts := []ClientOption{WithRetryPolicy(policy)}
+
+	if cfg.RateLimitBackend == "redis" {
+		opts = append(opts, WithRateLimiter(NewRedisRateLimiter(redisClient, cfg.RequestsPerSecond, cfg.Burst)))
+	} else {
+		opts = append(opts, WithRateLimiter(NewTokenBucketLimiter(cfg.RequestsPerSecond, cfg.Burst)))
+	}
+
+	return opts
+}
@@ -1,7 +1,9 @@
 package notion
 
 import (
+	"context"
 	"fmt"
+	"iter"
 )
 
 // Databases provides methods for working with Notion databases
@@ -17,10 +19,10 @@ func NewDatabases(opts ...ClientOption) *Databases {
 }
 
 // Query queries a database and returns matching pages
-func (d *Databases) Query(accessToken, databaseID string, request *DatabaseQueryRequest) (*DatabaseQueryResponse, error) {
+func (d *Databases) Query(ctx context.Context, accessToken, databaseID string, request *DatabaseQueryRequest) (*DatabaseQueryResponse, error) {
 	endpoint := fmt.Sprintf("/databases/%s/query", databaseID)
 
-	resp, err := d.client.makeRequest("POST", endpoint, request, accessToken, authTypeBearer)
+	resp, err := d.client.makeRequest(ctx, "POST", endpoint, request, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query database: %w", err)
 	}
@@ -33,11 +35,83 @@ func (d *Databases) Query(accessToken, databaseID string, request *DatabaseQuery
 	return &queryResp, nil
 }
 
+// QueryAll iterates over every page matching the query, following cursors.
+// request.StartCursor, if set, is where iteration starts.
+func (d *Databases) QueryAll(ctx context.Context, accessToken, databaseID string, request *DatabaseQueryRequest) iter.Seq2[Page, error] {
+	var query DatabaseQueryRequest
+	if request != nil {
+		query = *request
+	}
+
+	return paginate(ctx, query.StartCursor, func(ctx context.Context, cursor string) ([]Page, string, bool, error) {
+		query.StartCursor = cursor
+		resp, err := d.Query(ctx, accessToken, databaseID, &query)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.Results, resp.NextCursor, resp.HasMore, nil
+	})
+}
+
+// Create creates a database as a child of a page
+func (d *Databases) Create(ctx context.Context, accessToken string, request *CreateDatabaseRequest) (*Database, error) {
+	resp, err := d.client.makeRequest(ctx, "POST", "/databases", request, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+
+	var database Database
+	if err := d.client.handleResponse(resp, &database); err != nil {
+		return nil, fmt.Errorf("failed to parse create database response: %w", err)
+	}
+
+	return &database, nil
+}
+
+// Update updates a database's title, description or properties
+func (d *Databases) Update(ctx context.Context, accessToken, databaseID string, request *UpdateDatabaseRequest) (*Database, error) {
+	endpoint := fmt.Sprintf("/databases/%s", databaseID)
+
+	resp, err := d.client.makeRequest(ctx, "PATCH", endpoint, request, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update database: %w", err)
+	}
+
+	var database Database
+	if err := d.client.handleResponse(resp, &database); err != nil {
+		return nil, fmt.Errorf("failed to parse update database response: %w", err)
+	}
+
+	return &database, nil
+}
+
+// EnsureSchema adds the desired properties and select options the database is
+// missing, in a single update, and returns what it changed. Existing properties are
+// never renamed, retyped or deleted; see PlanSchema. No request is made when the
+// schema already matches.
+func (d *Databases) EnsureSchema(ctx context.Context, accessToken, databaseID string, desired map[string]*PropertySchema) ([]SchemaChange, error) {
+	database, err := d.Retrieve(ctx, accessToken, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, update, err := PlanSchema(database, desired)
+	if err != nil || update == nil {
+		return nil, err
+	}
+
+	if _, err := d.Update(ctx, accessToken, databaseID, update); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
 // Retrieve retrieves a database by ID
-func (d *Databases) Retrieve(accessToken, databaseID string) (*Database, error) {
+func (d *Databases) Retrieve(ctx context.Context, accessToken, databaseID string) (*Database, error) {
 	endpoint := fmt.Sprintf("/databases/%s", databaseID)
 
-	resp, err := d.client.makeRequest("GET", endpoint, nil, accessToken, authTypeBearer)
+	resp, err := d.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve database: %w", err)
 	}
@@ -51,7 +125,7 @@ func (d *Databases) Retrieve(accessToken, databaseID string) (*Database, error)
 }
 
 // List lists all databases accessible to the integration
-func (d *Databases) List(accessToken string, startCursor string, pageSize int) (*DatabaseQueryResponse, error) {
+func (d *Databases) List(ctx context.Context, accessToken string, startCursor string, pageSize int) (*DatabaseListResponse, error) {
 	endpoint := "/search"
 
 	request := map[string]interface{}{
@@ -69,15 +143,26 @@ func (d *Databases) List(accessToken string, startCursor string, pageSize int) (
 		request["page_size"] = pageSize
 	}
 
-	resp, err := d.client.makeRequest("POST", endpoint, request, accessToken, authTypeBearer)
+	resp, err := d.client.makeRequest(ctx, "POST", endpoint, request, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list databases: %w", err)
 	}
 
-	var listResp DatabaseQueryResponse
+	var listResp DatabaseListResponse
 	if err := d.client.handleResponse(resp, &listResp); err != nil {
 		return nil, fmt.Errorf("failed to parse database list response: %w", err)
 	}
 
 	return &listResp, nil
 }
+
+// ListAll iterates over every database accessible to the integration, following cursors
+func (d *Databases) ListAll(ctx context.Context, accessToken string, pageSize int) iter.Seq2[Database, error] {
+	return paginate(ctx, "", func(ctx context.Context, cursor string) ([]Database, string, bool, error) {
+		resp, err := d.List(ctx, accessToken, cursor, pageSize)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.Results, resp.NextCursor, resp.HasMore, nil
+	})
+}
@@ -0,0 +1,596 @@
+package notion
+
+import "time"
+
+// Filter represents a database query filter. Exactly one of the condition fields
+// should be set, together with Property (or Timestamp for timestamp filters), or
+// And/Or for compound filters. Build filters with Where, CreatedTime, LastEditedTime,
+// And and Or rather than by hand.
+type Filter struct {
+	Property  string `json:"property,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+
+	Title          *TextFilter        `json:"title,omitempty"`
+	RichText       *TextFilter        `json:"rich_text,omitempty"`
+	URL            *TextFilter        `json:"url,omitempty"`
+	Email          *TextFilter        `json:"email,omitempty"`
+	PhoneNumber    *TextFilter        `json:"phone_number,omitempty"`
+	Number         *NumberFilter      `json:"number,omitempty"`
+	Checkbox       *CheckboxFilter    `json:"checkbox,omitempty"`
+	Select         *SelectFilter      `json:"select,omitempty"`
+	MultiSelect    *MultiSelectFilter `json:"multi_select,omitempty"`
+	Status         *SelectFilter      `json:"status,omitempty"`
+	Date           *DateFilter        `json:"date,omitempty"`
+	People         *PeopleFilter      `json:"people,omitempty"`
+	CreatedBy      *PeopleFilter      `json:"created_by,omitempty"`
+	LastEditedBy   *PeopleFilter      `json:"last_edited_by,omitempty"`
+	Files          *EmptyFilter       `json:"files,omitempty"`
+	Relation       *RelationFilter    `json:"relation,omitempty"`
+	Formula        *FormulaFilter     `json:"formula,omitempty"`
+	Rollup         *RollupFilter      `json:"rollup,omitempty"`
+	UniqueID       *NumberFilter      `json:"unique_id,omitempty"`
+	CreatedTime    *DateFilter        `json:"created_time,omitempty"`
+	LastEditedTime *DateFilter        `json:"last_edited_time,omitempty"`
+
+	And []Filter `json:"and,omitempty"`
+	Or  []Filter `json:"or,omitempty"`
+}
+
+// TextFilter filters title, rich_text, url, email and phone_number properties
+type TextFilter struct {
+	Equals         string `json:"equals,omitempty"`
+	DoesNotEqual   string `json:"does_not_equal,omitempty"`
+	Contains       string `json:"contains,omitempty"`
+	DoesNotContain string `json:"does_not_contain,omitempty"`
+	StartsWith     string `json:"starts_with,omitempty"`
+	EndsWith       string `json:"ends_with,omitempty"`
+	IsEmpty        bool   `json:"is_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+}
+
+// NumberFilter filters number and unique_id properties
+type NumberFilter struct {
+	Equals               *float64 `json:"equals,omitempty"`
+	DoesNotEqual         *float64 `json:"does_not_equal,omitempty"`
+	GreaterThan          *float64 `json:"greater_than,omitempty"`
+	LessThan             *float64 `json:"less_than,omitempty"`
+	GreaterThanOrEqualTo *float64 `json:"greater_than_or_equal_to,omitempty"`
+	LessThanOrEqualTo    *float64 `json:"less_than_or_equal_to,omitempty"`
+	IsEmpty              bool     `json:"is_empty,omitempty"`
+	IsNotEmpty           bool     `json:"is_not_empty,omitempty"`
+}
+
+// CheckboxFilter filters checkbox properties
+type CheckboxFilter struct {
+	Equals       *bool `json:"equals,omitempty"`
+	DoesNotEqual *bool `json:"does_not_equal,omitempty"`
+}
+
+// SelectFilter filters select and status properties
+type SelectFilter struct {
+	Equals       string `json:"equals,omitempty"`
+	DoesNotEqual string `json:"does_not_equal,omitempty"`
+	IsEmpty      bool   `json:"is_empty,omitempty"`
+	IsNotEmpty   bool   `json:"is_not_empty,omitempty"`
+}
+
+// MultiSelectFilter filters multi_select properties
+type MultiSelectFilter struct {
+	Contains       string `json:"contains,omitempty"`
+	DoesNotContain string `json:"does_not_contain,omitempty"`
+	IsEmpty        bool   `json:"is_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+}
+
+// DateFilter filters date properties and created/last edited timestamps.
+// The relative conditions (PastWeek, NextMonth, ...) serialize as empty objects.
+type DateFilter struct {
+	Equals     string    `json:"equals,omitempty"`
+	Before     string    `json:"before,omitempty"`
+	After      string    `json:"after,omitempty"`
+	OnOrBefore string    `json:"on_or_before,omitempty"`
+	OnOrAfter  string    `json:"on_or_after,omitempty"`
+	IsEmpty    bool      `json:"is_empty,omitempty"`
+	IsNotEmpty bool      `json:"is_not_empty,omitempty"`
+	PastWeek   *struct{} `json:"past_week,omitempty"`
+	PastMonth  *struct{} `json:"past_month,omitempty"`
+	PastYear   *struct{} `json:"past_year,omitempty"`
+	ThisWeek   *struct{} `json:"this_week,omitempty"`
+	NextWeek   *struct{} `json:"next_week,omitempty"`
+	NextMonth  *struct{} `json:"next_month,omitempty"`
+	NextYear   *struct{} `json:"next_year,omitempty"`
+}
+
+// PeopleFilter filters people, created_by and last_edited_by properties by user ID
+type PeopleFilter struct {
+	Contains       string `json:"contains,omitempty"`
+	DoesNotContain string `json:"does_not_contain,omitempty"`
+	IsEmpty        bool   `json:"is_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+}
+
+// RelationFilter filters relation properties by page ID
+type RelationFilter struct {
+	Contains       string `json:"contains,omitempty"`
+	DoesNotContain string `json:"does_not_contain,omitempty"`
+	IsEmpty        bool   `json:"is_empty,omitempty"`
+	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
+}
+
+// EmptyFilter filters properties that only support emptiness checks, such as files
+type EmptyFilter struct {
+	IsEmpty    bool `json:"is_empty,omitempty"`
+	IsNotEmpty bool `json:"is_not_empty,omitempty"`
+}
+
+// FormulaFilter filters formula properties by the type of their result
+type FormulaFilter struct {
+	String   *TextFilter     `json:"string,omitempty"`
+	Checkbox *CheckboxFilter `json:"checkbox,omitempty"`
+	Number   *NumberFilter   `json:"number,omitempty"`
+	Date     *DateFilter     `json:"date,omitempty"`
+}
+
+// RollupFilter filters rollup properties. Any, Every and None hold a condition
+// without a property name that is applied to each rolled up value.
+type RollupFilter struct {
+	Any    *Filter       `json:"any,omitempty"`
+	Every  *Filter       `json:"every,omitempty"`
+	None   *Filter       `json:"none,omitempty"`
+	Number *NumberFilter `json:"number,omitempty"`
+	Date   *DateFilter   `json:"date,omitempty"`
+}
+
+// And matches pages matching all filters
+func And(filters ...Filter) Filter {
+	return Filter{And: filters}
+}
+
+// Or matches pages matching any of the filters
+func Or(filters ...Filter) Filter {
+	return Filter{Or: filters}
+}
+
+// FilterBuilder picks the property type of a filter condition, e.g.
+//
+//	notion.And(
+//		notion.Where("Status").Status().DoesNotEqual("Done"),
+//		notion.Where("Due").Date().Before(nextFriday),
+//	)
+type FilterBuilder struct {
+	wrap func(Filter) Filter
+}
+
+// Where starts a filter on the named property
+func Where(property string) FilterBuilder {
+	return FilterBuilder{wrap: func(f Filter) Filter {
+		f.Property = property
+		return f
+	}}
+}
+
+// CreatedTime starts a filter on the page's creation time
+func CreatedTime() DateCondition {
+	return DateCondition{wrap: func(c *DateFilter) Filter {
+		return Filter{Timestamp: "created_time", CreatedTime: c}
+	}}
+}
+
+// LastEditedTime starts a filter on the page's last edit time
+func LastEditedTime() DateCondition {
+	return DateCondition{wrap: func(c *DateFilter) Filter {
+		return Filter{Timestamp: "last_edited_time", LastEditedTime: c}
+	}}
+}
+
+func (b FilterBuilder) text(set func(*Filter, *TextFilter)) TextCondition {
+	return TextCondition{wrap: func(c *TextFilter) Filter {
+		var f Filter
+		set(&f, c)
+		return b.wrap(f)
+	}}
+}
+
+// Title filters a title property
+func (b FilterBuilder) Title() TextCondition {
+	return b.text(func(f *Filter, c *TextFilter) { f.Title = c })
+}
+
+// RichText filters a rich_text property
+func (b FilterBuilder) RichText() TextCondition {
+	return b.text(func(f *Filter, c *TextFilter) { f.RichText = c })
+}
+
+// URL filters a url property
+func (b FilterBuilder) URL() TextCondition {
+	return b.text(func(f *Filter, c *TextFilter) { f.URL = c })
+}
+
+// Email filters an email property
+func (b FilterBuilder) Email() TextCondition {
+	return b.text(func(f *Filter, c *TextFilter) { f.Email = c })
+}
+
+// PhoneNumber filters a phone_number property
+func (b FilterBuilder) PhoneNumber() TextCondition {
+	return b.text(func(f *Filter, c *TextFilter) { f.PhoneNumber = c })
+}
+
+// Number filters a number property
+func (b FilterBuilder) Number() NumberCondition {
+	return NumberCondition{wrap: func(c *NumberFilter) Filter { return b.wrap(Filter{Number: c}) }}
+}
+
+// UniqueID filters a unique_id property by its number
+func (b FilterBuilder) UniqueID() NumberCondition {
+	return NumberCondition{wrap: func(c *NumberFilter) Filter { return b.wrap(Filter{UniqueID: c}) }}
+}
+
+// Checkbox filters a checkbox property
+func (b FilterBuilder) Checkbox() CheckboxCondition {
+	return CheckboxCondition{wrap: func(c *CheckboxFilter) Filter { return b.wrap(Filter{Checkbox: c}) }}
+}
+
+// Select filters a select property
+func (b FilterBuilder) Select() SelectCondition {
+	return SelectCondition{wrap: func(c *SelectFilter) Filter { return b.wrap(Filter{Select: c}) }}
+}
+
+// Status filters a status property
+func (b FilterBuilder) Status() SelectCondition {
+	return SelectCondition{wrap: func(c *SelectFilter) Filter { return b.wrap(Filter{Status: c}) }}
+}
+
+// MultiSelect filters a multi_select property
+func (b FilterBuilder) MultiSelect() MultiSelectCondition {
+	return MultiSelectCondition{wrap: func(c *MultiSelectFilter) Filter { return b.wrap(Filter{MultiSelect: c}) }}
+}
+
+// Date filters a date property
+func (b FilterBuilder) Date() DateCondition {
+	return DateCondition{wrap: func(c *DateFilter) Filter { return b.wrap(Filter{Date: c}) }}
+}
+
+// People filters a people property
+func (b FilterBuilder) People() PeopleCondition {
+	return PeopleCondition{wrap: func(c *PeopleFilter) Filter { return b.wrap(Filter{People: c}) }}
+}
+
+// CreatedBy filters a created_by property
+func (b FilterBuilder) CreatedBy() PeopleCondition {
+	return PeopleCondition{wrap: func(c *PeopleFilter) Filter { return b.wrap(Filter{CreatedBy: c}) }}
+}
+
+// LastEditedBy filters a last_edited_by property
+func (b FilterBuilder) LastEditedBy() PeopleCondition {
+	return PeopleCondition{wrap: func(c *PeopleFilter) Filter { return b.wrap(Filter{LastEditedBy: c}) }}
+}
+
+// Files filters a files property
+func (b FilterBuilder) Files() EmptyCondition {
+	return EmptyCondition{wrap: func(c *EmptyFilter) Filter { return b.wrap(Filter{Files: c}) }}
+}
+
+// Relation filters a relation property
+func (b FilterBuilder) Relation() RelationCondition {
+	return RelationCondition{wrap: func(c *RelationFilter) Filter { return b.wrap(Filter{Relation: c}) }}
+}
+
+// Formula filters a formula property
+func (b FilterBuilder) Formula() FormulaCondition {
+	return FormulaCondition{wrap: func(c *FormulaFilter) Filter { return b.wrap(Filter{Formula: c}) }}
+}
+
+// Rollup filters a rollup property
+func (b FilterBuilder) Rollup() RollupCondition {
+	return RollupCondition{wrap: func(c *RollupFilter) Filter { return b.wrap(Filter{Rollup: c}) }}
+}
+
+// TextCondition builds conditions on text properties
+type TextCondition struct {
+	wrap func(*TextFilter) Filter
+}
+
+func (c TextCondition) Equals(value string) Filter {
+	return c.wrap(&TextFilter{Equals: value})
+}
+
+func (c TextCondition) DoesNotEqual(value string) Filter {
+	return c.wrap(&TextFilter{DoesNotEqual: value})
+}
+
+func (c TextCondition) Contains(value string) Filter {
+	return c.wrap(&TextFilter{Contains: value})
+}
+
+func (c TextCondition) DoesNotContain(value string) Filter {
+	return c.wrap(&TextFilter{DoesNotContain: value})
+}
+
+func (c TextCondition) StartsWith(value string) Filter {
+	return c.wrap(&TextFilter{StartsWith: value})
+}
+
+func (c TextCondition) EndsWith(value string) Filter {
+	return c.wrap(&TextFilter{EndsWith: value})
+}
+
+func (c TextCondition) IsEmpty() Filter {
+	return c.wrap(&TextFilter{IsEmpty: true})
+}
+
+func (c TextCondition) IsNotEmpty() Filter {
+	return c.wrap(&TextFilter{IsNotEmpty: true})
+}
+
+// NumberCondition builds conditions on number properties
+type NumberCondition struct {
+	wrap func(*NumberFilter) Filter
+}
+
+func (c NumberCondition) Equals(value float64) Filter {
+	return c.wrap(&NumberFilter{Equals: &value})
+}
+
+func (c NumberCondition) DoesNotEqual(value float64) Filter {
+	return c.wrap(&NumberFilter{DoesNotEqual: &value})
+}
+
+func (c NumberCondition) GreaterThan(value float64) Filter {
+	return c.wrap(&NumberFilter{GreaterThan: &value})
+}
+
+func (c NumberCondition) LessThan(value float64) Filter {
+	return c.wrap(&NumberFilter{LessThan: &value})
+}
+
+func (c NumberCondition) GreaterThanOrEqualTo(value float64) Filter {
+	return c.wrap(&NumberFilter{GreaterThanOrEqualTo: &value})
+}
+
+func (c NumberCondition) LessThanOrEqualTo(value float64) Filter {
+	return c.wrap(&NumberFilter{LessThanOrEqualTo: &value})
+}
+
+func (c NumberCondition) IsEmpty() Filter {
+	return c.wrap(&NumberFilter{IsEmpty: true})
+}
+
+func (c NumberCondition) IsNotEmpty() Filter {
+	return c.wrap(&NumberFilter{IsNotEmpty: true})
+}
+
+// CheckboxCondition builds conditions on checkbox properties
+type CheckboxCondition struct {
+	wrap func(*CheckboxFilter) Filter
+}
+
+func (c CheckboxCondition) Equals(value bool) Filter {
+	return c.wrap(&CheckboxFilter{Equals: &value})
+}
+
+func (c CheckboxCondition) DoesNotEqual(value bool) Filter {
+	return c.wrap(&CheckboxFilter{DoesNotEqual: &value})
+}
+
+// SelectCondition builds conditions on select and status properties by option name
+type SelectCondition struct {
+	wrap func(*SelectFilter) Filter
+}
+
+func (c SelectCondition) Equals(option string) Filter {
+	return c.wrap(&SelectFilter{Equals: option})
+}
+
+func (c SelectCondition) DoesNotEqual(option string) Filter {
+	return c.wrap(&SelectFilter{DoesNotEqual: option})
+}
+
+func (c SelectCondition) IsEmpty() Filter {
+	return c.wrap(&SelectFilter{IsEmpty: true})
+}
+
+func (c SelectCondition) IsNotEmpty() Filter {
+	return c.wrap(&SelectFilter{IsNotEmpty: true})
+}
+
+// MultiSelectCondition builds conditions on multi_select properties by option name
+type MultiSelectCondition struct {
+	wrap func(*MultiSelectFilter) Filter
+}
+
+func (c MultiSelectCondition) Contains(option string) Filter {
+	return c.wrap(&MultiSelectFilter{Contains: option})
+}
+
+func (c MultiSelectCondition) DoesNotContain(option string) Filter {
+	return c.wrap(&MultiSelectFilter{DoesNotContain: option})
+}
+
+func (c MultiSelectCondition) IsEmpty() Filter {
+	return c.wrap(&MultiSelectFilter{IsEmpty: true})
+}
+
+func (c MultiSelectCondition) IsNotEmpty() Filter {
+	return c.wrap(&MultiSelectFilter{IsNotEmpty: true})
+}
+
+// DateCondition builds conditions on dates. Times at midnight are sent as plain
+// dates (2006-01-02), anything else as RFC 3339 timestamps.
+type DateCondition struct {
+	wrap func(*DateFilter) Filter
+}
+
+func (c DateCondition) Equals(value time.Time) Filter {
+	return c.wrap(&DateFilter{Equals: formatDate(value)})
+}
+
+func (c DateCondition) Before(value time.Time) Filter {
+	return c.wrap(&DateFilter{Before: formatDate(value)})
+}
+
+func (c DateCondition) After(value time.Time) Filter {
+	return c.wrap(&DateFilter{After: formatDate(value)})
+}
+
+func (c DateCondition) OnOrBefore(value time.Time) Filter {
+	return c.wrap(&DateFilter{OnOrBefore: formatDate(value)})
+}
+
+func (c DateCondition) OnOrAfter(value time.Time) Filter {
+	return c.wrap(&DateFilter{OnOrAfter: formatDate(value)})
+}
+
+func (c DateCondition) IsEmpty() Filter {
+	return c.wrap(&DateFilter{IsEmpty: true})
+}
+
+func (c DateCondition) IsNotEmpty() Filter {
+	return c.wrap(&DateFilter{IsNotEmpty: true})
+}
+
+func (c DateCondition) PastWeek() Filter {
+	return c.wrap(&DateFilter{PastWeek: &struct{}{}})
+}
+
+func (c DateCondition) PastMonth() Filter {
+	return c.wrap(&DateFilter{PastMonth: &struct{}{}})
+}
+
+func (c DateCondition) PastYear() Filter {
+	return c.wrap(&DateFilter{PastYear: &struct{}{}})
+}
+
+func (c DateCondition) ThisWeek() Filter {
+	return c.wrap(&DateFilter{ThisWeek: &struct{}{}})
+}
+
+func (c DateCondition) NextWeek() Filter {
+	return c.wrap(&DateFilter{NextWeek: &struct{}{}})
+}
+
+func (c DateCondition) NextMonth() Filter {
+	return c.wrap(&DateFilter{NextMonth: &struct{}{}})
+}
+
+func (c DateCondition) NextYear() Filter {
+	return c.wrap(&DateFilter{NextYear: &struct{}{}})
+}
+
+// PeopleCondition builds conditions on people properties by user ID
+type PeopleCondition struct {
+	wrap func(*PeopleFilter) Filter
+}
+
+func (c PeopleCondition) Contains(userID string) Filter {
+	return c.wrap(&PeopleFilter{Contains: userID})
+}
+
+func (c PeopleCondition) DoesNotContain(userID string) Filter {
+	return c.wrap(&PeopleFilter{DoesNotContain: userID})
+}
+
+func (c PeopleCondition) IsEmpty() Filter {
+	return c.wrap(&PeopleFilter{IsEmpty: true})
+}
+
+func (c PeopleCondition) IsNotEmpty() Filter {
+	return c.wrap(&PeopleFilter{IsNotEmpty: true})
+}
+
+// RelationCondition builds conditions on relation properties by page ID
+type RelationCondition struct {
+	wrap func(*RelationFilter) Filter
+}
+
+func (c RelationCondition) Contains(pageID string) Filter {
+	return c.wrap(&RelationFilter{Contains: pageID})
+}
+
+func (c RelationCondition) DoesNotContain(pageID string) Filter {
+	return c.wrap(&RelationFilter{DoesNotContain: pageID})
+}
+
+func (c RelationCondition) IsEmpty() Filter {
+	return c.wrap(&RelationFilter{IsEmpty: true})
+}
+
+func (c RelationCondition) IsNotEmpty() Filter {
+	return c.wrap(&RelationFilter{IsNotEmpty: true})
+}
+
+// EmptyCondition builds emptiness conditions
+type EmptyCondition struct {
+	wrap func(*EmptyFilter) Filter
+}
+
+func (c EmptyCondition) IsEmpty() Filter {
+	return c.wrap(&EmptyFilter{IsEmpty: true})
+}
+
+func (c EmptyCondition) IsNotEmpty() Filter {
+	return c.wrap(&EmptyFilter{IsNotEmpty: true})
+}
+
+// FormulaCondition picks the result type of a formula condition
+type FormulaCondition struct {
+	wrap func(*FormulaFilter) Filter
+}
+
+// String filters formulas returning text
+func (c FormulaCondition) String() TextCondition {
+	return TextCondition{wrap: func(f *TextFilter) Filter { return c.wrap(&FormulaFilter{String: f}) }}
+}
+
+// Checkbox filters formulas returning a boolean
+func (c FormulaCondition) Checkbox() CheckboxCondition {
+	return CheckboxCondition{wrap: func(f *CheckboxFilter) Filter { return c.wrap(&FormulaFilter{Checkbox: f}) }}
+}
+
+// Number filters formulas returning a number
+func (c FormulaCondition) Number() NumberCondition {
+	return NumberCondition{wrap: func(f *NumberFilter) Filter { return c.wrap(&FormulaFilter{Number: f}) }}
+}
+
+// Date filters formulas returning a date
+func (c FormulaCondition) Date() DateCondition {
+	return DateCondition{wrap: func(f *DateFilter) Filter { return c.wrap(&FormulaFilter{Date: f}) }}
+}
+
+// RollupCondition builds conditions on rollup properties
+type RollupCondition struct {
+	wrap func(*RollupFilter) Filter
+}
+
+// Any matches when at least one rolled up value matches the condition
+func (c RollupCondition) Any() FilterBuilder {
+	return FilterBuilder{wrap: func(f Filter) Filter { return c.wrap(&RollupFilter{Any: &f}) }}
+}
+
+// Every matches when all rolled up values match the condition
+func (c RollupCondition) Every() FilterBuilder {
+	return FilterBuilder{wrap: func(f Filter) Filter { return c.wrap(&RollupFilter{Every: &f}) }}
+}
+
+// None matches when no rolled up value matches the condition
+func (c RollupCondition) None() FilterBuilder {
+	return FilterBuilder{wrap: func(f Filter) Filter { return c.wrap(&RollupFilter{None: &f}) }}
+}
+
+// Number filters rollups computing a number, e.g. sum or count
+func (c RollupCondition) Number() NumberCondition {
+	return NumberCondition{wrap: func(f *NumberFilter) Filter { return c.wrap(&RollupFilter{Number: f}) }}
+}
+
+// Date filters rollups computing a date, e.g. earliest or latest date
+func (c RollupCondition) Date() DateCondition {
+	return DateCondition{wrap: func(f *DateFilter) Filter { return c.wrap(&RollupFilter{Date: f}) }}
+}
+
+// formatDate formats midnight as a date and other times as RFC 3339
+func formatDate(value time.Time) string {
+	if value.Hour() == 0 && value.Minute() == 0 && value.Second() == 0 && value.Nanosecond() == 0 {
+		return value.Format(time.DateOnly)
+	}
+	return value.Format(time.RFC3339)
+}
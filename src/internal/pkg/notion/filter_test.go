@@ -0,0 +1,96 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+var _ = Describe("Filter", func() {
+	nextFriday := time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)
+
+	DescribeTable("serializes to Notion's JSON shape",
+		func(name string, filter notion.Filter) {
+			actual, err := json.MarshalIndent(notion.DatabaseQueryRequest{Filter: &filter}, "", "  ")
+			Expect(err).ToNot(HaveOccurred())
+			actual = append(actual, '\n')
+
+			path := filepath.Join("testdata", "filters", name+".golden.json")
+			if *updateGolden {
+				Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+				Expect(os.WriteFile(path, actual, 0o644)).To(Succeed())
+			}
+
+			expected, err := os.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred(), "run go test with -update to create %s", path)
+			Expect(string(actual)).To(Equal(string(expected)))
+		},
+		Entry("text", "text", notion.Or(
+			notion.Where("Name").Title().Contains("launch"),
+			notion.Where("Notes").RichText().IsEmpty(),
+			notion.Where("Site").URL().StartsWith("https://"),
+			notion.Where("Contact").Email().EndsWith("@example.com"),
+			notion.Where("Phone").PhoneNumber().DoesNotEqual("555"),
+		)),
+		Entry("number", "number", notion.And(
+			notion.Where("Estimate").Number().GreaterThanOrEqualTo(0),
+			notion.Where("Estimate").Number().LessThan(8),
+			notion.Where("ID").UniqueID().Equals(42),
+		)),
+		Entry("checkbox", "checkbox", notion.Where("Critical path").Checkbox().Equals(false)),
+		Entry("select, multi_select and status", "select", notion.And(
+			notion.Where("Priority").Select().Equals("High"),
+			notion.Where("Tags").MultiSelect().DoesNotContain("Backlog"),
+			notion.Where("Status").Status().IsNotEmpty(),
+		)),
+		Entry("status not done and due before next Friday", "status_and_due", notion.And(
+			notion.Where("Status").Status().DoesNotEqual("Done"),
+			notion.Where("Due").Date().Before(nextFriday),
+		)),
+		Entry("date", "date", notion.Or(
+			notion.Where("Due").Date().NextWeek(),
+			notion.Where("Due").Date().PastMonth(),
+			notion.Where("Start").Date().OnOrAfter(time.Date(2025, 10, 20, 9, 30, 0, 0, time.UTC)),
+			notion.Where("Start").Date().IsEmpty(),
+		)),
+		Entry("people, files and relation", "people_files_relation", notion.And(
+			notion.Where("Assignee").People().Contains("user-1"),
+			notion.Where("Owner").CreatedBy().DoesNotContain("user-2"),
+			notion.Where("Attachments").Files().IsNotEmpty(),
+			notion.Where("Blocked by").Relation().IsEmpty(),
+		)),
+		Entry("formula", "formula", notion.Or(
+			notion.Where("Label").Formula().String().Contains("late"),
+			notion.Where("Overdue").Formula().Checkbox().Equals(true),
+			notion.Where("Slack").Formula().Number().LessThan(0),
+			notion.Where("Finish").Formula().Date().After(nextFriday),
+		)),
+		Entry("rollup", "rollup", notion.Or(
+			notion.Where("Subtask status").Rollup().Every().Status().Equals("Done"),
+			notion.Where("Subtask owners").Rollup().Any().People().Contains("user-1"),
+			notion.Where("Blockers").Rollup().None().Checkbox().Equals(true),
+			notion.Where("Total estimate").Rollup().Number().GreaterThan(10),
+			notion.Where("Latest due").Rollup().Date().ThisWeek(),
+		)),
+		Entry("timestamps", "timestamps", notion.And(
+			notion.CreatedTime().PastWeek(),
+			notion.LastEditedTime().OnOrBefore(nextFriday),
+		)),
+		Entry("nested compound", "nested", notion.Or(
+			notion.And(
+				notion.Where("Status").Status().DoesNotEqual("Done"),
+				notion.Where("Due").Date().Before(nextFriday),
+			),
+			notion.Where("Critical path").Checkbox().Equals(true),
+		)),
+	)
+})
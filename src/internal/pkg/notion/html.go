@@ -0,0 +1,231 @@
+package notion
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// RichTextToHTML renders rich text as inline HTML. Text is escaped, links are limited
+// to http, https and mailto URLs, and colors become notion-color-* classes.
+func RichTextToHTML(richText []RichText) string {
+	var b strings.Builder
+	for _, rt := range mergeRichText(richText) {
+		writeRichTextHTML(&b, rt)
+	}
+	return b.String()
+}
+
+func writeRichTextHTML(b *strings.Builder, rt RichText) {
+	var out string
+	switch rt.Type {
+	case "equation":
+		if rt.Equation == nil {
+			return
+		}
+		out = `<span class="equation">` + html.EscapeString(rt.Equation.Expression) + `</span>`
+	case "mention":
+		out = `<span class="mention">` + html.EscapeString(mentionText(rt)) + `</span>`
+		if href := safeURL(mentionHref(rt)); href != "" {
+			out = `<a class="mention" href="` + html.EscapeString(href) + `">` + html.EscapeString(mentionText(rt)) + `</a>`
+		}
+	default:
+		out = strings.ReplaceAll(html.EscapeString(richTextContent(rt)), "\n", "<br>")
+	}
+
+	a := rt.Annotations
+	if a.Code {
+		out = "<code>" + out + "</code>"
+	}
+	if a.Strikethrough {
+		out = "<s>" + out + "</s>"
+	}
+	if a.Underline {
+		out = "<u>" + out + "</u>"
+	}
+	if a.Italic {
+		out = "<em>" + out + "</em>"
+	}
+	if a.Bold {
+		out = "<strong>" + out + "</strong>"
+	}
+	if a.Color != "" && a.Color != "default" {
+		out = `<span class="notion-color-` + html.EscapeString(a.Color) + `">` + out + `</span>`
+	}
+	if href := safeURL(richTextLink(rt)); href != "" && rt.Type != "mention" {
+		out = `<a href="` + html.EscapeString(href) + `">` + out + `</a>`
+	}
+	b.WriteString(out)
+}
+
+// BlocksToHTML renders blocks and their children as an HTML fragment. Consecutive
+// list items are grouped into lists and toggles become details elements.
+func BlocksToHTML(blocks []Block) string {
+	var b strings.Builder
+	writeHTMLBlocks(&b, blocks)
+	return b.String()
+}
+
+func writeHTMLBlocks(b *strings.Builder, blocks []Block) {
+	for i := 0; i < len(blocks); {
+		block := blocks[i]
+		if list := htmlListTag(block.Type); list != "" {
+			b.WriteString(list)
+			for ; i < len(blocks) && blocks[i].Type == block.Type; i++ {
+				writeHTMLListItem(b, blocks[i])
+			}
+			b.WriteString("</" + list[1:3] + ">\n")
+			continue
+		}
+		writeHTMLBlock(b, block)
+		i++
+	}
+}
+
+func htmlListTag(blockType string) string {
+	switch blockType {
+	case BlockTypeBulletedListItem:
+		return "<ul>\n"
+	case BlockTypeNumberedListItem:
+		return "<ol>\n"
+	case BlockTypeToDo:
+		return `<ul class="to-do">` + "\n"
+	}
+	return ""
+}
+
+func writeHTMLListItem(b *strings.Builder, block Block) {
+	b.WriteString("<li>")
+	if block.ToDo != nil {
+		b.WriteString(`<input type="checkbox" disabled`)
+		if block.ToDo.Checked {
+			b.WriteString(" checked")
+		}
+		b.WriteString("> ")
+	}
+	b.WriteString(RichTextToHTML(blockRichText(block)))
+	if len(block.Children) > 0 {
+		b.WriteString("\n")
+		writeHTMLBlocks(b, block.Children)
+	}
+	b.WriteString("</li>\n")
+}
+
+func writeHTMLBlock(b *strings.Builder, block Block) {
+	text := RichTextToHTML(blockRichText(block))
+
+	switch block.Type {
+	case BlockTypeParagraph:
+		b.WriteString("<p>" + text + "</p>\n")
+	case BlockTypeHeading1, BlockTypeHeading2, BlockTypeHeading3:
+		tag := "h" + block.Type[len(block.Type)-1:]
+		b.WriteString("<" + tag + ">" + text + "</" + tag + ">\n")
+	case BlockTypeToggle:
+		b.WriteString("<details>\n<summary>" + text + "</summary>\n")
+		writeHTMLBlocks(b, block.Children)
+		b.WriteString("</details>\n")
+		return
+	case BlockTypeQuote:
+		b.WriteString("<blockquote>\n<p>" + text + "</p>\n")
+		writeHTMLBlocks(b, block.Children)
+		b.WriteString("</blockquote>\n")
+		return
+	case BlockTypeCallout:
+		b.WriteString(`<aside class="callout">` + "\n<p>")
+		if block.Callout.Icon != nil && block.Callout.Icon.Emoji != "" {
+			b.WriteString(`<span class="icon">` + html.EscapeString(block.Callout.Icon.Emoji) + "</span> ")
+		}
+		b.WriteString(text + "</p>\n")
+		writeHTMLBlocks(b, block.Children)
+		b.WriteString("</aside>\n")
+		return
+	case BlockTypeCode:
+		b.WriteString("<pre><code")
+		if language := markdownLanguage(block.Code.Language); language != "" {
+			b.WriteString(` class="language-` + html.EscapeString(language) + `"`)
+		}
+		b.WriteString(">" + html.EscapeString(PlainText(block.Code.RichText)) + "</code></pre>\n")
+		return
+	case BlockTypeEquation:
+		b.WriteString(`<div class="equation">` + html.EscapeString(block.Equation.Expression) + "</div>\n")
+		return
+	case BlockTypeDivider:
+		b.WriteString("<hr>\n")
+		return
+	case BlockTypeChildPage, BlockTypeChildDatabase:
+		title := ""
+		if block.ChildPage != nil {
+			title = block.ChildPage.Title
+		} else if block.ChildDatabase != nil {
+			title = block.ChildDatabase.Title
+		}
+		href := notionURL + strings.ReplaceAll(block.ID, "-", "")
+		b.WriteString(`<p><a href="` + html.EscapeString(href) + `">` + html.EscapeString(title) + "</a></p>\n")
+		return
+	case BlockTypeTable:
+		writeHTMLTable(b, block)
+		return
+	case BlockTypeSyncedBlock:
+		writeHTMLBlocks(b, block.Children)
+		return
+	default:
+		if text != "" {
+			b.WriteString("<p>" + text + "</p>\n")
+		}
+	}
+
+	if len(block.Children) > 0 {
+		b.WriteString(`<div class="children">` + "\n")
+		writeHTMLBlocks(b, block.Children)
+		b.WriteString("</div>\n")
+	}
+}
+
+func writeHTMLTable(b *strings.Builder, block Block) {
+	b.WriteString("<table>\n")
+	for i, row := range block.Children {
+		if row.TableRow == nil {
+			continue
+		}
+		header := i == 0 && block.Table.HasColumnHeader
+		if header {
+			b.WriteString("<thead>\n")
+		} else if i == 0 || (i == 1 && block.Table.HasColumnHeader) {
+			b.WriteString("<tbody>\n")
+		}
+
+		b.WriteString("<tr>")
+		for j, cell := range row.TableRow.Cells {
+			tag := "td"
+			if header || (j == 0 && block.Table.HasRowHeader) {
+				tag = "th"
+			}
+			b.WriteString("<" + tag + ">" + RichTextToHTML(cell) + "</" + tag + ">")
+		}
+		b.WriteString("</tr>\n")
+
+		if header {
+			b.WriteString("</thead>\n")
+		}
+	}
+	if len(block.Children) > 1 || (len(block.Children) == 1 && !block.Table.HasColumnHeader) {
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+}
+
+// safeURL returns rawURL if it is safe to put in an href, and "" otherwise
+func safeURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https", "mailto", "":
+		return rawURL
+	}
+	return ""
+}
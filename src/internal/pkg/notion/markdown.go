@@ -0,0 +1,965 @@
+package notion
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Markdown conversion covers CommonMark plus GitHub's strikethrough, task lists and
+// tables, and $inline$ / $$block$$ math for equations. Notion features without a
+// Markdown equivalent degrade to their text: underline and colors are dropped,
+// user and date mentions become plain text, page mentions become links, toggles
+// render as list items and callouts as block quotes.
+
+// MaxRichTextLength is the longest content Notion accepts in one rich text object
+const MaxRichTextLength = 2000
+
+// notionURL is where pages and databases referenced by ID are linked to
+const notionURL = "https://www.notion.so/"
+
+// RichTextToMarkdown renders rich text as inline Markdown
+func RichTextToMarkdown(richText []RichText) string {
+	var b strings.Builder
+	for _, rt := range mergeRichText(richText) {
+		b.WriteString(richTextSegmentToMarkdown(rt))
+	}
+	return b.String()
+}
+
+func richTextSegmentToMarkdown(rt RichText) string {
+	switch rt.Type {
+	case "equation":
+		if rt.Equation != nil {
+			return "$" + rt.Equation.Expression + "$"
+		}
+	case "mention":
+		if href := mentionHref(rt); href != "" {
+			return "[" + escapeMarkdown(mentionText(rt)) + "](" + href + ")"
+		}
+		return escapeMarkdown(mentionText(rt))
+	}
+
+	text := richTextContent(rt)
+	if text == "" {
+		return ""
+	}
+
+	// Delimiters cannot be next to whitespace, so keep it outside of them
+	trimmed := strings.TrimFunc(text, unicode.IsSpace)
+	if trimmed == "" {
+		return text
+	}
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+
+	var out string
+	if rt.Annotations.Code {
+		out = codeSpan(trimmed)
+	} else {
+		out = escapeMarkdown(trimmed)
+	}
+	if rt.Annotations.Strikethrough {
+		out = "~~" + out + "~~"
+	}
+	if rt.Annotations.Italic {
+		out = "*" + out + "*"
+	}
+	if rt.Annotations.Bold {
+		out = "**" + out + "**"
+	}
+	if url := richTextLink(rt); url != "" {
+		out = "[" + out + "](" + escapeLinkDestination(url) + ")"
+	}
+	return leading + out + trailing
+}
+
+// BlocksToMarkdown renders blocks and their children as a Markdown document
+func BlocksToMarkdown(blocks []Block) string {
+	var b strings.Builder
+	writeMarkdownBlocks(&b, blocks, "")
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func writeMarkdownBlocks(b *strings.Builder, blocks []Block, indent string) {
+	number := 0
+	for i, block := range blocks {
+		if block.Type == BlockTypeNumberedListItem {
+			number++
+		} else {
+			number = 0
+		}
+
+		writeMarkdownBlock(b, block, indent, number)
+
+		// List items of the same kind form one tight list; everything else is
+		// separated by a blank line
+		if i+1 < len(blocks) && !(isListBlock(block) && blocks[i+1].Type == block.Type) {
+			b.WriteString("\n")
+		}
+	}
+}
+
+func writeMarkdownBlock(b *strings.Builder, block Block, indent string, number int) {
+	line := func(s string) {
+		b.WriteString(indent)
+		b.WriteString(s)
+		b.WriteString("\n")
+	}
+	text := RichTextToMarkdown(blockRichText(block))
+
+	switch block.Type {
+	case BlockTypeHeading1:
+		line("# " + text)
+	case BlockTypeHeading2:
+		line("## " + text)
+	case BlockTypeHeading3:
+		line("### " + text)
+	case BlockTypeBulletedListItem, BlockTypeToggle:
+		writeMarkdownListItem(b, "- ", text, block.Children, indent)
+		return
+	case BlockTypeNumberedListItem:
+		writeMarkdownListItem(b, strconv.Itoa(number)+". ", text, block.Children, indent)
+		return
+	case BlockTypeToDo:
+		marker := "- [ ] "
+		if block.ToDo.Checked {
+			marker = "- [x] "
+		}
+		writeMarkdownListItem(b, marker, text, block.Children, indent)
+		return
+	case BlockTypeQuote, BlockTypeCallout:
+		if block.Callout != nil && block.Callout.Icon != nil && block.Callout.Icon.Emoji != "" {
+			text = block.Callout.Icon.Emoji + " " + text
+		}
+		var inner strings.Builder
+		inner.WriteString(text + "\n")
+		if len(block.Children) > 0 {
+			inner.WriteString("\n")
+			writeMarkdownBlocks(&inner, block.Children, "")
+		}
+		for _, quoted := range strings.Split(strings.TrimRight(inner.String(), "\n"), "\n") {
+			line(strings.TrimRight("> "+quoted, " "))
+		}
+		return
+	case BlockTypeCode:
+		code := PlainText(block.Code.RichText)
+		fence := codeFence(code)
+		line(fence + markdownLanguage(block.Code.Language))
+		for _, codeLine := range strings.Split(code, "\n") {
+			line(codeLine)
+		}
+		line(fence)
+		return
+	case BlockTypeEquation:
+		line("$$")
+		line(block.Equation.Expression)
+		line("$$")
+		return
+	case BlockTypeDivider:
+		line("---")
+		return
+	case BlockTypeChildPage, BlockTypeChildDatabase:
+		title := ""
+		if block.ChildPage != nil {
+			title = block.ChildPage.Title
+		} else if block.ChildDatabase != nil {
+			title = block.ChildDatabase.Title
+		}
+		line("[" + escapeMarkdown(title) + "](" + notionURL + strings.ReplaceAll(block.ID, "-", "") + ")")
+		return
+	case BlockTypeTable:
+		writeMarkdownTable(b, block, indent)
+		return
+	case BlockTypeSyncedBlock:
+		writeMarkdownBlocks(b, block.Children, indent)
+		return
+	default:
+		for i, paragraphLine := range strings.Split(text, "\n") {
+			if i > 0 {
+				// A backslash before the newline is a hard line break
+				b.WriteString("\\\n")
+			}
+			b.WriteString(indent + paragraphLine)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(block.Children) > 0 {
+		b.WriteString("\n")
+		writeMarkdownBlocks(b, block.Children, indent)
+	}
+}
+
+func writeMarkdownListItem(b *strings.Builder, marker, text string, children []Block, indent string) {
+	continuation := indent + strings.Repeat(" ", len(marker))
+	for i, itemLine := range strings.Split(text, "\n") {
+		if i == 0 {
+			b.WriteString(indent + marker + itemLine)
+		} else {
+			b.WriteString("\\\n" + continuation + itemLine)
+		}
+	}
+	b.WriteString("\n")
+
+	if len(children) > 0 {
+		// Nested lists stay tight; other children need a blank line to belong to the item
+		if !isListBlock(children[0]) {
+			b.WriteString("\n")
+		}
+		writeMarkdownBlocks(b, children, continuation)
+	}
+}
+
+func writeMarkdownTable(b *strings.Builder, block Block, indent string) {
+	if len(block.Children) == 0 {
+		return
+	}
+
+	width := block.Table.TableWidth
+	row := func(cells [][]RichText) string {
+		out := make([]string, width)
+		for i := range width {
+			if i < len(cells) {
+				out[i] = strings.ReplaceAll(RichTextToMarkdown(cells[i]), "|", `\|`)
+			}
+		}
+		return indent + "| " + strings.Join(out, " | ") + " |\n"
+	}
+
+	// GitHub tables need a header row, so tables without one get an empty header
+	rows := block.Children
+	if block.Table.HasColumnHeader {
+		b.WriteString(row(rows[0].TableRow.Cells))
+		rows = rows[1:]
+	} else {
+		b.WriteString(row(nil))
+	}
+	b.WriteString(indent + "|" + strings.Repeat(" --- |", width) + "\n")
+	for _, r := range rows {
+		b.WriteString(row(r.TableRow.Cells))
+	}
+}
+
+// MarkdownToRichText parses inline Markdown into rich text
+func MarkdownToRichText(markdown string) []RichText {
+	return splitLongRichText(mergeRichText(parseInline(markdown, Annotations{}, "")))
+}
+
+// MarkdownToBlocks parses a Markdown document into blocks, with nested list items
+// and quoted content as children. Raw HTML is kept as text.
+func MarkdownToBlocks(markdown string) []Block {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	markdown = strings.ReplaceAll(markdown, "\t", "    ")
+	return parseMarkdownBlocks(strings.Split(markdown, "\n"))
+}
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	thematicPattern    = regexp.MustCompile(`^(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	listItemPattern    = regexp.MustCompile(`^([-*+]|\d{1,9}[.)])(?:[ \t]+(.*)|$)`)
+	taskPattern        = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+(.*)|$)`)
+	fencePattern       = regexp.MustCompile("^(`{3,}|~{3,})[ \t]*([^`]*)$")
+	tableDelimiterLine = regexp.MustCompile(`^\|?[ \t]*:?-+:?[ \t]*(\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+)
+
+func parseMarkdownBlocks(lines []string) []Block {
+	var blocks []Block
+	for i := 0; i < len(lines); {
+		line := strings.TrimRight(lines[i], " ")
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case indent >= 4:
+			// Indented code block
+			var code []string
+			for ; i < len(lines) && (strings.TrimSpace(lines[i]) == "" || leadingSpaces(lines[i]) >= 4); i++ {
+				code = append(code, strings.TrimPrefix(lines[i], "    "))
+			}
+			blocks = append(blocks, newCodeBlock(strings.TrimRight(strings.Join(code, "\n"), "\n"), ""))
+
+		case fencePattern.MatchString(trimmed):
+			match := fencePattern.FindStringSubmatch(trimmed)
+			fence, language := match[1], ""
+			if info := strings.Fields(match[2]); len(info) > 0 {
+				language = info[0]
+			}
+			var code []string
+			for i++; i < len(lines); i++ {
+				if closing := strings.TrimSpace(lines[i]); strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+					i++
+					break
+				}
+				code = append(code, strings.TrimPrefix(lines[i], strings.Repeat(" ", indent)))
+			}
+			blocks = append(blocks, newCodeBlock(strings.Join(code, "\n"), language))
+
+		case trimmed == "$$":
+			var expression []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "$$"; i++ {
+				expression = append(expression, strings.TrimSpace(lines[i]))
+			}
+			i++
+			blocks = append(blocks, Block{Type: BlockTypeEquation, Equation: &Equation{Expression: strings.Join(expression, "\n")}})
+
+		case headingPattern.MatchString(trimmed):
+			match := headingPattern.FindStringSubmatch(trimmed)
+			heading := &HeadingBlock{RichText: MarkdownToRichText(match[2])}
+			switch len(match[1]) {
+			case 1:
+				blocks = append(blocks, Block{Type: BlockTypeHeading1, Heading1: heading})
+			case 2:
+				blocks = append(blocks, Block{Type: BlockTypeHeading2, Heading2: heading})
+			default:
+				blocks = append(blocks, Block{Type: BlockTypeHeading3, Heading3: heading})
+			}
+			i++
+
+		case thematicPattern.MatchString(trimmed):
+			blocks = append(blocks, Block{Type: BlockTypeDivider, Divider: &struct{}{}})
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimLeft(lines[i], " "), ">"); i++ {
+				content := strings.TrimPrefix(strings.TrimLeft(lines[i], " "), ">")
+				quoted = append(quoted, strings.TrimPrefix(content, " "))
+			}
+			blocks = append(blocks, newQuoteBlock(parseMarkdownBlocks(quoted)))
+
+		case listItemPattern.MatchString(trimmed):
+			var block Block
+			block, i = parseListItem(lines, i, indent)
+			blocks = append(blocks, block)
+
+		case i+1 < len(lines) && strings.Contains(trimmed, "|") && tableDelimiterLine.MatchString(strings.TrimSpace(lines[i+1])):
+			var block Block
+			block, i = parseTable(lines, i)
+			blocks = append(blocks, block)
+
+		default:
+			var paragraph []string
+			for ; i < len(lines) && isParagraphContinuation(lines[i]); i++ {
+				paragraph = append(paragraph, strings.TrimLeft(lines[i], " "))
+			}
+			blocks = append(blocks, Block{Type: BlockTypeParagraph, Paragraph: &TextBlock{RichText: MarkdownToRichText(joinParagraphLines(paragraph))}})
+		}
+	}
+	return blocks
+}
+
+// parseListItem parses the list item starting at lines[start]. Lines indented past the
+// marker belong to the item and become its children.
+func parseListItem(lines []string, start, indent int) (Block, int) {
+	trimmed := strings.TrimSpace(lines[start])
+	match := listItemPattern.FindStringSubmatch(trimmed)
+	marker, content := match[1], match[2]
+	contentIndent := indent + len(marker) + 1
+
+	// Lazy continuation lines extend the item's first paragraph
+	text := []string{content}
+	i := start + 1
+	for ; i < len(lines) && leadingSpaces(lines[i]) < contentIndent && isParagraphContinuation(lines[i]) &&
+		!listItemPattern.MatchString(strings.TrimSpace(lines[i])); i++ {
+		text = append(text, strings.TrimSpace(lines[i]))
+	}
+
+	var childLines []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			// A blank line only continues the item if indented content follows
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next == len(lines) || leadingSpaces(lines[next]) < contentIndent {
+				break
+			}
+			childLines = append(childLines, "")
+			continue
+		}
+		if leadingSpaces(line) < contentIndent {
+			break
+		}
+		childLines = append(childLines, line[contentIndent:])
+	}
+	children := parseMarkdownBlocks(childLines)
+
+	richText := func(s string) []RichText { return MarkdownToRichText(joinParagraphLines(strings.Split(s, "\n"))) }
+	joined := strings.Join(text, "\n")
+	switch {
+	case taskPattern.MatchString(joined) && (marker == "-" || marker == "*" || marker == "+"):
+		task := taskPattern.FindStringSubmatch(joined)
+		return Block{Type: BlockTypeToDo, ToDo: &ToDoBlock{RichText: richText(task[2]), Checked: task[1] != " "}, Children: children}, i
+	case marker == "-" || marker == "*" || marker == "+":
+		return Block{Type: BlockTypeBulletedListItem, BulletedListItem: &TextBlock{RichText: richText(joined)}, Children: children}, i
+	default:
+		return Block{Type: BlockTypeNumberedListItem, NumberedListItem: &TextBlock{RichText: richText(joined)}, Children: children}, i
+	}
+}
+
+// parseTable parses a GitHub table whose header row is lines[start]
+func parseTable(lines []string, start int) (Block, int) {
+	header := splitTableRow(lines[start])
+	rows := []Block{newTableRow(header)}
+
+	i := start + 2
+	for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+		rows = append(rows, newTableRow(splitTableRow(lines[i])))
+	}
+
+	width := len(header)
+	for _, row := range rows {
+		width = max(width, len(row.TableRow.Cells))
+	}
+	for r := range rows {
+		for len(rows[r].TableRow.Cells) < width {
+			rows[r].TableRow.Cells = append(rows[r].TableRow.Cells, []RichText{})
+		}
+	}
+
+	return Block{
+		Type:     BlockTypeTable,
+		Table:    &TableBlock{TableWidth: width, HasColumnHeader: true},
+		Children: rows,
+	}, i
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func newTableRow(cells []string) Block {
+	row := &TableRowBlock{Cells: make([][]RichText, len(cells))}
+	for i, cell := range cells {
+		row.Cells[i] = MarkdownToRichText(cell)
+	}
+	return Block{Type: BlockTypeTableRow, TableRow: row}
+}
+
+func newCodeBlock(code, language string) Block {
+	if language == "" {
+		language = "plain text"
+	}
+	return Block{Type: BlockTypeCode, Code: &CodeBlock{RichText: splitLongRichText([]RichText{NewText(code)}), Language: language}}
+}
+
+// newQuoteBlock makes the first quoted paragraph the quote's text and the rest its children
+func newQuoteBlock(children []Block) Block {
+	quote := &TextBlock{RichText: []RichText{}}
+	if len(children) > 0 && children[0].Type == BlockTypeParagraph {
+		quote.RichText = children[0].Paragraph.RichText
+		children = children[1:]
+	}
+	return Block{Type: BlockTypeQuote, Quote: quote, Children: children}
+}
+
+// isParagraphContinuation reports whether line continues a paragraph rather than
+// starting another block
+func isParagraphContinuation(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" &&
+		!headingPattern.MatchString(trimmed) &&
+		!thematicPattern.MatchString(trimmed) &&
+		!fencePattern.MatchString(trimmed) &&
+		!strings.HasPrefix(trimmed, ">") &&
+		trimmed != "$$" &&
+		!(listItemPattern.MatchString(trimmed) && !startsWithNumber(trimmed))
+}
+
+// startsWithNumber is true for ordered list markers, which only interrupt a paragraph
+// in CommonMark when they start at 1
+func startsWithNumber(line string) bool {
+	return line[0] >= '0' && line[0] <= '9' && !strings.HasPrefix(line, "1.") && !strings.HasPrefix(line, "1)")
+}
+
+// joinParagraphLines joins soft line breaks with a space and keeps hard breaks
+// (a trailing backslash or two trailing spaces) as newlines
+func joinParagraphLines(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i == len(lines)-1 {
+			b.WriteString(strings.TrimRight(line, " "))
+			break
+		}
+		switch {
+		case strings.HasSuffix(line, "\\") && !strings.HasSuffix(line, `\\`):
+			b.WriteString(strings.TrimSuffix(line, "\\") + "\n")
+		case strings.HasSuffix(line, "  "):
+			b.WriteString(strings.TrimRight(line, " ") + "\n")
+		default:
+			b.WriteString(strings.TrimRight(line, " ") + " ")
+		}
+	}
+	return b.String()
+}
+
+// parseInline parses inline Markdown with the annotations and link of the enclosing span
+func parseInline(s string, annotations Annotations, link string) []RichText {
+	var (
+		out  []RichText
+		text strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			out = append(out, newAnnotatedText(text.String(), annotations, link))
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			run := delimiterRun(s, i, '`')
+			if end := strings.Index(s[i+run:], s[i:i+run]); end >= 0 && delimiterRun(s, i+run+end, '`') == run {
+				flush()
+				code := s[i+run : i+run+end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				codeAnnotations := annotations
+				codeAnnotations.Code = true
+				out = append(out, newAnnotatedText(code, codeAnnotations, link))
+				i += run + end + run
+				continue
+			}
+			text.WriteString(s[i : i+run])
+			i += run
+			continue
+
+		case c == '$' && i+1 < len(s) && s[i+1] != ' ' && s[i+1] != '$':
+			if end := findClosing(s, i+1, "$"); end > i+1 && s[end-1] != ' ' {
+				flush()
+				out = append(out, RichText{Type: "equation", Equation: &Equation{Expression: s[i+1 : end]}, Annotations: annotations, PlainText: s[i+1 : end]})
+				i = end + 1
+				continue
+			}
+
+		case c == '[' && link == "":
+			if label, url, next, ok := parseLink(s, i); ok {
+				flush()
+				out = append(out, parseInline(label, annotations, url)...)
+				i = next
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 && isAutolink(s[i+1:i+end]) {
+				flush()
+				url := s[i+1 : i+end]
+				target := url
+				if strings.Contains(url, "@") && !strings.Contains(url, ":") {
+					target = "mailto:" + url
+				}
+				out = append(out, newAnnotatedText(url, annotations, target))
+				i += end + 1
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if spans, next, ok := parseEmphasis(s, i, annotations, link); ok {
+				flush()
+				out = append(out, spans...)
+				i = next
+				continue
+			}
+			run := delimiterRun(s, i, c)
+			text.WriteString(s[i : i+run])
+			i += run
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		text.WriteString(s[i : i+size])
+		i += size
+	}
+	flush()
+	return out
+}
+
+// parseEmphasis parses **bold**, *italic*, ***both*** and ~~strikethrough~~ at s[i]
+func parseEmphasis(s string, i int, annotations Annotations, link string) ([]RichText, int, bool) {
+	c := s[i]
+	run := delimiterRun(s, i, c)
+	if c == '~' && run != 2 {
+		return nil, 0, false
+	}
+	run = min(run, 3)
+	delimiter := strings.Repeat(string(c), run)
+
+	// Opening delimiters must be followed by non-whitespace, and underscores only
+	// open at word boundaries
+	if i+run >= len(s) || isSpaceByte(s[i+run]) || (c == '_' && i > 0 && isWordByte(s[i-1])) {
+		return nil, 0, false
+	}
+	end := findClosing(s, i+run, delimiter)
+	if end < 0 || isSpaceByte(s[end-1]) || (c == '_' && end+run < len(s) && isWordByte(s[end+run])) {
+		return nil, 0, false
+	}
+
+	inner := annotations
+	switch {
+	case c == '~':
+		inner.Strikethrough = true
+	case run == 1:
+		inner.Italic = true
+	case run == 2:
+		inner.Bold = true
+	default:
+		inner.Bold, inner.Italic = true, true
+	}
+	return parseInline(s[i+run:end], inner, link), end + run, true
+}
+
+// findClosing returns the index of the delimiter closing a span that starts at from,
+// skipping escapes, code spans and longer runs of the same character
+func findClosing(s string, from int, delimiter string) int {
+	c := delimiter[0]
+	for i := from; i < len(s); {
+		switch {
+		case s[i] == '\\':
+			i += 2
+		case s[i] == '`' && c != '`':
+			run := delimiterRun(s, i, '`')
+			if end := strings.Index(s[i+run:], s[i:i+run]); end >= 0 {
+				i += run + end + run
+			} else {
+				i += run
+			}
+		case s[i] == c:
+			run := delimiterRun(s, i, c)
+			if c == '$' {
+				return i
+			}
+			// Runs after whitespace open spans rather than close them
+			if i > from && !isSpaceByte(s[i-1]) {
+				if run == len(delimiter) {
+					return i
+				}
+				if run > len(delimiter) && (i+run == len(s) || !isWordByte(s[i+run])) {
+					// "*a **b***": the last characters close the outer span
+					return i + run - len(delimiter)
+				}
+			}
+			i += run
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// parseLink parses [label](destination "title") at s[i]
+func parseLink(s string, i int) (label, url string, next int, ok bool) {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if j+1 >= len(s) || s[j+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(s[j+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			destination := strings.TrimSpace(s[j+2 : j+2+end])
+			if space := strings.IndexAny(destination, " \t"); space >= 0 {
+				destination = destination[:space] // drop the title
+			}
+			destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+			return s[i+1 : j], destination, j + 3 + end, destination != ""
+		}
+	}
+	return "", "", 0, false
+}
+
+func newAnnotatedText(content string, annotations Annotations, link string) RichText {
+	rt := NewText(content)
+	rt.Annotations = annotations
+	if link != "" {
+		rt.Text.Link = &Link{URL: link}
+		rt.Href = link
+	}
+	return rt
+}
+
+// mergeRichText joins neighbouring text segments with the same formatting
+func mergeRichText(richText []RichText) []RichText {
+	var merged []RichText
+	for _, rt := range richText {
+		if n := len(merged); n > 0 && rt.Type == "text" && merged[n-1].Type == "text" &&
+			merged[n-1].Annotations == rt.Annotations && richTextLink(merged[n-1]) == richTextLink(rt) {
+			last := &merged[n-1]
+			content := richTextContent(*last) + richTextContent(rt)
+			text := *last.Text
+			text.Content = content
+			last.Text = &text
+			last.PlainText = content
+			continue
+		}
+		merged = append(merged, rt)
+	}
+	return merged
+}
+
+// splitLongRichText splits text segments longer than Notion accepts
+func splitLongRichText(richText []RichText) []RichText {
+	var out []RichText
+	for _, rt := range richText {
+		if rt.Text == nil || utf8.RuneCountInString(rt.Text.Content) <= MaxRichTextLength {
+			out = append(out, rt)
+			continue
+		}
+		runes := []rune(rt.Text.Content)
+		for start := 0; start < len(runes); start += MaxRichTextLength {
+			part := rt
+			text := *rt.Text
+			text.Content = string(runes[start:min(start+MaxRichTextLength, len(runes))])
+			part.Text = &text
+			part.PlainText = text.Content
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// blockRichText returns the text content of a block, if its type has one
+func blockRichText(block Block) []RichText {
+	switch {
+	case block.Paragraph != nil:
+		return block.Paragraph.RichText
+	case block.Heading1 != nil:
+		return block.Heading1.RichText
+	case block.Heading2 != nil:
+		return block.Heading2.RichText
+	case block.Heading3 != nil:
+		return block.Heading3.RichText
+	case block.ToDo != nil:
+		return block.ToDo.RichText
+	case block.BulletedListItem != nil:
+		return block.BulletedListItem.RichText
+	case block.NumberedListItem != nil:
+		return block.NumberedListItem.RichText
+	case block.Toggle != nil:
+		return block.Toggle.RichText
+	case block.Quote != nil:
+		return block.Quote.RichText
+	case block.Code != nil:
+		return block.Code.RichText
+	case block.Callout != nil:
+		return block.Callout.RichText
+	}
+	return nil
+}
+
+func isListBlock(block Block) bool {
+	switch block.Type {
+	case BlockTypeBulletedListItem, BlockTypeNumberedListItem, BlockTypeToDo, BlockTypeToggle:
+		return true
+	}
+	return false
+}
+
+func richTextContent(rt RichText) string {
+	if rt.Text != nil {
+		return rt.Text.Content
+	}
+	return rt.PlainText
+}
+
+func richTextLink(rt RichText) string {
+	if rt.Text != nil && rt.Text.Link != nil {
+		return rt.Text.Link.URL
+	}
+	if rt.Type == "text" {
+		return rt.Href
+	}
+	return ""
+}
+
+// mentionText is how a mention reads outside Notion
+func mentionText(rt RichText) string {
+	if rt.PlainText != "" {
+		return rt.PlainText
+	}
+	if rt.Mention == nil {
+		return ""
+	}
+	switch {
+	case rt.Mention.User != nil:
+		return "@" + rt.Mention.User.Name
+	case rt.Mention.Date != nil:
+		return "@" + rt.Mention.Date.Start
+	case rt.Mention.Page != nil:
+		return rt.Mention.Page.ID
+	case rt.Mention.Database != nil:
+		return rt.Mention.Database.ID
+	}
+	return ""
+}
+
+// mentionHref links page and database mentions to Notion
+func mentionHref(rt RichText) string {
+	if rt.Mention == nil {
+		return ""
+	}
+	switch {
+	case rt.Mention.Page != nil:
+		if rt.Href != "" {
+			return rt.Href
+		}
+		return notionURL + strings.ReplaceAll(rt.Mention.Page.ID, "-", "")
+	case rt.Mention.Database != nil:
+		if rt.Href != "" {
+			return rt.Href
+		}
+		return notionURL + strings.ReplaceAll(rt.Mention.Database.ID, "-", "")
+	}
+	return ""
+}
+
+// markdownEscaper escapes characters that would otherwise start Markdown syntax
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`~`, `\~`, `$`, `\$`, `<`, `\<`,
+)
+
+func escapeMarkdown(s string) string {
+	escaped := markdownEscaper.Replace(s)
+	// Characters that only matter at the start of a line
+	if trimmed := strings.TrimLeft(escaped, " "); trimmed != "" {
+		prefix := escaped[:len(escaped)-len(trimmed)]
+		switch {
+		case strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, ">"),
+			strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "+ "):
+			escaped = prefix + `\` + trimmed
+		case listItemPattern.MatchString(trimmed) && trimmed[0] >= '0' && trimmed[0] <= '9':
+			dot := strings.IndexAny(trimmed, ".)")
+			escaped = prefix + trimmed[:dot] + `\` + trimmed[dot:]
+		}
+	}
+	return escaped
+}
+
+func escapeLinkDestination(url string) string {
+	if strings.ContainsAny(url, " ()") {
+		return "<" + url + ">"
+	}
+	return url
+}
+
+// codeSpan wraps code in enough backticks that the code cannot close the span
+func codeSpan(code string) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		return fence + " " + code + " " + fence
+	}
+	return fence + code + fence
+}
+
+// codeFence returns a fence longer than any backtick run in code
+func codeFence(code string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence
+}
+
+// markdownLanguage maps Notion's code block languages to info strings
+func markdownLanguage(language string) string {
+	switch language {
+	case "plain text", "":
+		return ""
+	case "c++":
+		return "cpp"
+	case "c#":
+		return "csharp"
+	case "f#":
+		return "fsharp"
+	default:
+		return strings.ReplaceAll(language, " ", "-")
+	}
+}
+
+func delimiterRun(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isAutolink(s string) bool {
+	if strings.ContainsAny(s, " <>") {
+		return false
+	}
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "mailto:") ||
+		(strings.Count(s, "@") == 1 && strings.Contains(s[strings.Index(s, "@"):], "."))
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
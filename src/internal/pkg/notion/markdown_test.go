@@ -0,0 +1,265 @@
+package notion_test
+
+import (
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+func styled(content string, annotate func(*notion.Annotations)) notion.RichText {
+	rt := notion.NewText(content)
+	annotate(&rt.Annotations)
+	return rt
+}
+
+func linked(content, url string) notion.RichText {
+	rt := notion.NewText(content)
+	rt.Text.Link = &notion.Link{URL: url}
+	return rt
+}
+
+func numbered(text string, children ...notion.Block) notion.Block {
+	return notion.Block{Type: notion.BlockTypeNumberedListItem, NumberedListItem: &notion.TextBlock{RichText: []notion.RichText{notion.NewText(text)}}, Children: children}
+}
+
+// richTextSummary describes rich text compactly for comparisons
+func richTextSummary(richText []notion.RichText) []string {
+	var out []string
+	for _, rt := range richText {
+		var flags []string
+		a := rt.Annotations
+		for flag, set := range map[string]bool{"bold": a.Bold, "italic": a.Italic, "code": a.Code, "strike": a.Strikethrough} {
+			if set {
+				flags = append(flags, flag)
+			}
+		}
+		summary := rt.Type + ":" + rt.PlainText
+		if rt.Equation != nil {
+			summary = "equation:" + rt.Equation.Expression
+		}
+		if rt.Text != nil && rt.Text.Link != nil {
+			summary += "->" + rt.Text.Link.URL
+		}
+		if len(flags) > 0 {
+			slices.Sort(flags)
+			summary += "[" + strings.Join(flags, ",") + "]"
+		}
+		out = append(out, summary)
+	}
+	return out
+}
+
+var _ = Describe("Markdown", func() {
+	Describe("rendering rich text", func() {
+		It("renders annotations, links, mentions and equations", func() {
+			userMention := notion.RichText{Type: "mention", PlainText: "@Ada", Mention: &notion.Mention{Type: "user", User: &notion.User{ID: "user-1", Name: "Ada"}}}
+			pageMention := notion.RichText{Type: "mention", PlainText: "Roadmap", Mention: &notion.Mention{Type: "page", Page: &struct {
+				ID string `json:"id"`
+			}{ID: "1a2b-3c4d"}}}
+			equation := notion.RichText{Type: "equation", Equation: &notion.Equation{Expression: "E = mc^2"}}
+
+			markdown := notion.RichTextToMarkdown([]notion.RichText{
+				notion.NewText("Ship "),
+				styled("the beta ", func(a *notion.Annotations) { a.Bold = true }),
+				styled("today", func(a *notion.Annotations) { a.Italic = true }),
+				notion.NewText(" with "),
+				styled("go test", func(a *notion.Annotations) { a.Code = true }),
+				notion.NewText(", see "),
+				linked("the spec", "https://example.com/spec"),
+				notion.NewText(", ask "),
+				userMention,
+				notion.NewText(", read "),
+				pageMention,
+				notion.NewText(" and check "),
+				equation,
+				notion.NewText(". Costs $5 * 2_000"),
+			})
+			Expect(markdown).To(Equal("Ship **the beta** *today* with `go test`, see [the spec](https://example.com/spec), ask @Ada, " +
+				"read [Roadmap](https://www.notion.so/1a2b3c4d) and check $E = mc^2$. Costs \\$5 \\* 2\\_000"))
+		})
+
+		It("merges neighbouring segments with the same formatting", func() {
+			bold := func(a *notion.Annotations) { a.Bold = true }
+			Expect(notion.RichTextToMarkdown([]notion.RichText{styled("a", bold), styled("b", bold)})).To(Equal("**ab**"))
+		})
+
+		It("picks a code span delimiter the code cannot close", func() {
+			code := func(a *notion.Annotations) { a.Code = true }
+			Expect(notion.RichTextToMarkdown([]notion.RichText{styled("a`b", code)})).To(Equal("``a`b``"))
+		})
+	})
+
+	Describe("parsing inline Markdown", func() {
+		It("parses emphasis, code, links and equations", func() {
+			richText := notion.MarkdownToRichText("Ship **the *beta*** with `go test`, see [the **spec**](https://example.com/spec) and $x^2$ ~~later~~ snake_case \\*literal\\*")
+			Expect(richTextSummary(richText)).To(Equal([]string{
+				"text:Ship ",
+				"text:the [bold]",
+				"text:beta[bold,italic]",
+				"text: with ",
+				"text:go test[code]",
+				"text:, see ",
+				"text:the ->https://example.com/spec",
+				"text:spec->https://example.com/spec[bold]",
+				"text: and ",
+				"equation:x^2",
+				"text: ",
+				"text:later[strike]",
+				"text: snake_case *literal*",
+			}))
+		})
+
+		It("leaves unmatched delimiters as text", func() {
+			Expect(richTextSummary(notion.MarkdownToRichText("2 * 3 = 6 and [not a link] and **open"))).To(Equal([]string{
+				"text:2 * 3 = 6 and [not a link] and **open",
+			}))
+		})
+
+		It("splits text longer than Notion accepts", func() {
+			richText := notion.MarkdownToRichText(strings.Repeat("a", notion.MaxRichTextLength+1))
+			Expect(richText).To(HaveLen(2))
+			Expect(richText[1].PlainText).To(Equal("a"))
+		})
+	})
+
+	Describe("documents", func() {
+		const document = `# Release plan
+
+Ship the **beta** this week.\
+Then collect feedback.
+
+- Backend
+  - API freeze
+  - Migrations
+- Frontend
+
+1. Write notes
+2. Publish
+
+   Share the link in the channel.
+
+- [x] Tag release
+- [ ] Announce
+
+> Keep scope small.
+>
+> - No new features
+
+` + "```go" + `
+fmt.Println("hi")
+` + "```" + `
+
+$$
+E = mc^2
+$$
+
+---
+
+| Task | Owner |
+| --- | --- |
+| Freeze API | Ada |
+| Write \| docs | Bob |
+`
+
+		It("parses nested lists, quotes, code, equations and tables", func() {
+			blocks := notion.MarkdownToBlocks(document)
+
+			var types []string
+			for _, block := range blocks {
+				types = append(types, block.Type)
+			}
+			Expect(types).To(Equal([]string{
+				"heading_1", "paragraph",
+				"bulleted_list_item", "bulleted_list_item",
+				"numbered_list_item", "numbered_list_item",
+				"to_do", "to_do",
+				"quote", "code", "equation", "divider", "table",
+			}))
+
+			Expect(notion.PlainText(blocks[1].Paragraph.RichText)).To(Equal("Ship the beta this week.\nThen collect feedback."))
+			Expect(blocks[2].Children).To(HaveLen(2))
+			Expect(notion.PlainText(blocks[2].Children[1].BulletedListItem.RichText)).To(Equal("Migrations"))
+			Expect(blocks[5].Children).To(HaveLen(1))
+			Expect(blocks[5].Children[0].Type).To(Equal("paragraph"))
+			Expect(blocks[6].ToDo.Checked).To(BeTrue())
+			Expect(blocks[7].ToDo.Checked).To(BeFalse())
+			Expect(notion.PlainText(blocks[8].Quote.RichText)).To(Equal("Keep scope small."))
+			Expect(blocks[8].Children[0].Type).To(Equal("bulleted_list_item"))
+			Expect(blocks[9].Code.Language).To(Equal("go"))
+			Expect(notion.PlainText(blocks[9].Code.RichText)).To(Equal(`fmt.Println("hi")`))
+			Expect(blocks[10].Equation.Expression).To(Equal("E = mc^2"))
+			Expect(blocks[12].Table.TableWidth).To(Equal(2))
+			Expect(blocks[12].Table.HasColumnHeader).To(BeTrue())
+			Expect(blocks[12].Children).To(HaveLen(3))
+			Expect(notion.PlainText(blocks[12].Children[2].TableRow.Cells[0])).To(Equal("Write | docs"))
+		})
+
+		It("renders blocks back to the same Markdown", func() {
+			Expect(notion.BlocksToMarkdown(notion.MarkdownToBlocks(document))).To(Equal(document))
+		})
+
+		It("renders Notion-only blocks as their closest Markdown", func() {
+			blocks := []notion.Block{
+				{Type: notion.BlockTypeToggle, Toggle: &notion.TextBlock{RichText: []notion.RichText{notion.NewText("Details")}}, Children: []notion.Block{paragraph("Hidden")}},
+				{Type: notion.BlockTypeCallout, Callout: &notion.CalloutBlock{RichText: []notion.RichText{notion.NewText("Heads up")}, Icon: &notion.Icon{Type: "emoji", Emoji: "⚠️"}}},
+				{Type: notion.BlockTypeChildPage, ID: "ab-cd", ChildPage: &notion.ChildPageBlock{Title: "Sub page"}},
+				numbered("One", numbered("Nested")),
+			}
+			Expect(notion.BlocksToMarkdown(blocks)).To(Equal(`- Details
+
+  Hidden
+
+> ⚠️ Heads up
+
+[Sub page](https://www.notion.so/abcd)
+
+1. One
+   1. Nested
+`))
+		})
+	})
+})
+
+var _ = Describe("HTML", func() {
+	It("renders escaped inline HTML and drops unsafe links", func() {
+		bold := func(a *notion.Annotations) { a.Bold = true }
+		html := notion.RichTextToHTML([]notion.RichText{
+			styled("<b>", bold),
+			notion.NewText(" & "),
+			linked("click", "javascript:alert(1)"),
+			notion.NewText(" "),
+			linked("docs", "https://example.com/?a=1&b=2"),
+			notion.NewText("\nnext"),
+		})
+		Expect(html).To(Equal(`<strong>&lt;b&gt;</strong> &amp; click <a href="https://example.com/?a=1&amp;b=2">docs</a><br>next`))
+	})
+
+	It("groups list items and renders nested content", func() {
+		blocks := notion.MarkdownToBlocks("## Plan\n\n- One\n  - Nested\n- Two\n\n- [x] Done\n\n| A | B |\n| --- | --- |\n| 1 | 2 |\n")
+		Expect(notion.BlocksToHTML(blocks)).To(Equal(`<h2>Plan</h2>
+<ul>
+<li>One
+<ul>
+<li>Nested</li>
+</ul>
+</li>
+<li>Two</li>
+</ul>
+<ul class="to-do">
+<li><input type="checkbox" disabled checked> Done</li>
+</ul>
+<table>
+<thead>
+<tr><th>A</th><th>B</th></tr>
+</thead>
+<tbody>
+<tr><td>1</td><td>2</td></tr>
+</tbody>
+</table>
+`))
+	})
+})
@@ -0,0 +1,688 @@
+// Package notiontest provides an in-process fake of the Notion API for tests.
+//
+// The fake keeps databases, pages and users in memory and serves the endpoints
+// used by package notion: OAuth token exchange, users, databases (retrieve, query,
+// update), pages (create, retrieve, update, property items) and search. List
+// endpoints paginate with cursors. Query filters and sorts are not evaluated;
+// pages are returned in creation order. Writes to pages can be delivered as signed
+// webhooks to a subscribed endpoint.
+package notiontest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"src/internal/pkg/notion"
+)
+
+// DefaultToken is accepted by every new server
+const DefaultToken = "secret_notiontest"
+
+// Request is a request the server received
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+	Token  string
+}
+
+// Server is a fake Notion API. Create it with NewServer and close it when done.
+type Server struct {
+	*httptest.Server
+
+	// PageSize caps the results of list endpoints; Notion's maximum is 100
+	PageSize int
+
+	mu         sync.Mutex
+	tokens     map[string]notion.User
+	oauthCodes map[string]notion.OAuthTokenResponse
+	clientID   string
+	secret     string
+	users      []notion.User
+	databases  map[string]*notion.Database
+	pages      map[string]*notion.Page
+	pageOrder  []string
+	requests   []Request
+	limited    int
+	retryAfter int
+	webhook    *webhookSubscription
+	now        func() time.Time
+}
+
+// NewServer starts a fake Notion API accepting DefaultToken
+func NewServer() *Server {
+	bot := notion.User{Object: "user", ID: uuid.NewString(), Type: "bot", Name: "notiontest", Bot: &notion.Bot{}}
+
+	s := &Server{
+		PageSize:   100,
+		tokens:     map[string]notion.User{DefaultToken: bot},
+		oauthCodes: map[string]notion.OAuthTokenResponse{},
+		users:      []notion.User{bot},
+		databases:  map[string]*notion.Database{},
+		pages:      map[string]*notion.Page{},
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// BaseURL is the API root to pass to notion.WithBaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + "/v1"
+}
+
+// ClientOptions configures a notion client for this server: no client-side rate
+// limiting and fast retries, so simulated 429s do not slow tests down
+func (s *Server) ClientOptions() []notion.ClientOption {
+	return []notion.ClientOption{
+		notion.WithBaseURL(s.BaseURL()),
+		notion.WithRateLimiter(nil),
+		notion.WithRetryPolicy(notion.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}),
+	}
+}
+
+// SetOAuthClient sets the client credentials the token endpoint accepts
+func (s *Server) SetOAuthClient(clientID, clientSecret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientID, s.secret = clientID, clientSecret
+}
+
+// AddOAuthCode registers an authorization code. Exchanging it returns resp and makes
+// resp.AccessToken valid; resp.Owner is what /users/me returns.
+func (s *Server) AddOAuthCode(code string, resp notion.OAuthTokenResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if resp.TokenType == "" {
+		resp.TokenType = "bearer"
+	}
+	s.oauthCodes[code] = resp
+}
+
+// AddUser adds a workspace user
+func (s *Server) AddUser(user notion.User) notion.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if user.ID == "" {
+		user.ID = uuid.NewString()
+	}
+	user.Object = "user"
+	s.users = append(s.users, user)
+	return user
+}
+
+// AddDatabase adds a database. Missing IDs, including property IDs, are generated.
+func (s *Server) AddDatabase(database notion.Database) notion.Database {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if database.ID == "" {
+		database.ID = uuid.NewString()
+	}
+	database.Object = "database"
+	if database.CreatedTime.IsZero() {
+		database.CreatedTime = s.now()
+		database.LastEditedTime = database.CreatedTime
+	}
+	properties := make(map[string]notion.Property, len(database.Properties))
+	for name, property := range database.Properties {
+		property.Name = name
+		if property.ID == "" {
+			property.ID = shortID()
+		}
+		properties[name] = property
+	}
+	database.Properties = properties
+
+	s.databases[database.ID] = &database
+	return database
+}
+
+// AddPage adds a page. Property IDs and types are filled in from the parent database.
+func (s *Server) AddPage(page notion.Page) notion.Page {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.storePage(page)
+}
+
+// Page returns the current state of a page
+func (s *Server) Page(id string) (notion.Page, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page, ok := s.pages[id]
+	if !ok {
+		return notion.Page{}, false
+	}
+	return *page, true
+}
+
+// Database returns the current state of a database
+func (s *Server) Database(id string) (notion.Database, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	database, ok := s.databases[id]
+	if !ok {
+		return notion.Database{}, false
+	}
+	return *database, true
+}
+
+// RateLimit makes the next n requests fail with 429 and the given Retry-After seconds
+func (s *Server) RateLimit(n int, retryAfterSeconds int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limited, s.retryAfter = n, retryAfterSeconds
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (s *Server) storePage(page notion.Page) notion.Page {
+	if page.ID == "" {
+		page.ID = uuid.NewString()
+	}
+	page.Object = "page"
+	if page.CreatedTime.IsZero() {
+		page.CreatedTime = s.now()
+	}
+	page.LastEditedTime = s.now()
+	if page.URL == "" {
+		page.URL = "https://www.notion.so/" + strings.ReplaceAll(page.ID, "-", "")
+	}
+	page.Properties = s.normalizeProperties(page.Parent.DatabaseID, page.Properties)
+
+	if _, exists := s.pages[page.ID]; !exists {
+		s.pageOrder = append(s.pageOrder, page.ID)
+	}
+	s.pages[page.ID] = &page
+	return page
+}
+
+// normalizeProperties fills in property IDs and types from the database schema
+func (s *Server) normalizeProperties(databaseID string, properties map[string]notion.PropertyValue) map[string]notion.PropertyValue {
+	database := s.databases[databaseID]
+	normalized := make(map[string]notion.PropertyValue, len(properties))
+	for name, value := range properties {
+		if database != nil {
+			if schema, ok := database.Properties[name]; ok {
+				value.ID = schema.ID
+				value.Type = schema.Type
+			}
+		}
+		if value.ID == "" {
+			value.ID = shortID()
+		}
+		normalized[name] = value
+	}
+	return normalized
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/oauth/token", s.handleOAuthToken)
+	mux.HandleFunc("GET /v1/users/me", s.authorized(s.handleMe))
+	mux.HandleFunc("GET /v1/users", s.authorized(s.handleListUsers))
+	mux.HandleFunc("GET /v1/users/{id}", s.authorized(s.handleRetrieveUser))
+	mux.HandleFunc("GET /v1/databases/{id}", s.authorized(s.handleRetrieveDatabase))
+	mux.HandleFunc("PATCH /v1/databases/{id}", s.authorized(s.handleUpdateDatabase))
+	mux.HandleFunc("POST /v1/databases/{id}/query", s.authorized(s.handleQueryDatabase))
+	mux.HandleFunc("POST /v1/pages", s.authorized(s.handleCreatePage))
+	mux.HandleFunc("GET /v1/pages/{id}", s.authorized(s.handleRetrievePage))
+	mux.HandleFunc("PATCH /v1/pages/{id}", s.authorized(s.handleUpdatePage))
+	mux.HandleFunc("GET /v1/pages/{id}/properties/{property}", s.authorized(s.handlePropertyItem))
+	mux.HandleFunc("POST /v1/search", s.authorized(s.handleSearch))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := readBody(r)
+		_, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: body, Token: token})
+		limited := s.limited > 0
+		if limited {
+			s.limited--
+		}
+		retryAfter := s.retryAfter
+		s.mu.Unlock()
+
+		if limited {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			writeError(w, http.StatusTooManyRequests, "rate_limited", "You have been rate limited. Please try again in a few minutes.")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authorized rejects requests without a known bearer token
+func (s *Server) authorized(next func(w http.ResponseWriter, r *http.Request, user notion.User)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+
+		s.mu.Lock()
+		user, ok := s.tokens[token]
+		s.mu.Unlock()
+
+		if scheme != "Bearer" || !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "API token is invalid.")
+			return
+		}
+		next(w, r, user)
+	}
+}
+
+func (s *Server) handleOAuthToken(w http.ResponseWriter, r *http.Request) {
+	var req notion.OAuthTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeOAuthError(w, "invalid_request", "Body failed validation.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.clientID != "" {
+		scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		decoded, _ := base64.StdEncoding.DecodeString(credentials)
+		if scheme != "Basic" || string(decoded) != s.clientID+":"+s.secret {
+			writeOAuthError(w, "invalid_client", "Client authentication failed.")
+			return
+		}
+	}
+
+	resp, ok := s.oauthCodes[req.Code]
+	if !ok || req.GrantType != "authorization_code" {
+		writeOAuthError(w, "invalid_grant", "Invalid code.")
+		return
+	}
+	delete(s.oauthCodes, req.Code)
+
+	if resp.AccessToken == "" {
+		resp.AccessToken = "secret_" + shortID()
+	}
+	s.tokens[resp.AccessToken] = resp.Owner
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request, user notion.User) {
+	writeJSON(w, user)
+}
+
+func (s *Server) handleListUsers(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	s.mu.Lock()
+	users := slices.Clone(s.users)
+	s.mu.Unlock()
+
+	writeJSON(w, paginate(users, r.URL.Query().Get("start_cursor"), r.URL.Query().Get("page_size"), s.pageSize()))
+}
+
+func (s *Server) handleRetrieveUser(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, user := range s.users {
+		if user.ID == r.PathValue("id") {
+			writeJSON(w, user)
+			return
+		}
+	}
+	writeNotFound(w, r.PathValue("id"))
+}
+
+func (s *Server) handleRetrieveDatabase(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	database, ok := s.databases[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, r.PathValue("id"))
+		return
+	}
+	writeJSON(w, database)
+}
+
+func (s *Server) handleUpdateDatabase(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	var req struct {
+		Title      []notion.RichText           `json:"title"`
+		Properties map[string]*json.RawMessage `json:"properties"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	database, ok := s.databases[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, r.PathValue("id"))
+		return
+	}
+
+	if req.Title != nil {
+		database.Title = req.Title
+	}
+	for name, raw := range req.Properties {
+		existing, exists := database.Properties[name]
+		if raw == nil {
+			delete(database.Properties, name)
+			continue
+		}
+
+		var property notion.Property
+		if err := json.Unmarshal(*raw, &property); err != nil {
+			writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		var typed map[string]json.RawMessage
+		json.Unmarshal(*raw, &typed)
+		for key := range typed {
+			if key != "name" {
+				property.Type = key
+			}
+		}
+
+		if exists {
+			if property.Type == "" {
+				property = existing
+			}
+			property.ID = existing.ID
+		} else {
+			property.ID = shortID()
+		}
+
+		newName := name
+		if property.Name != "" {
+			newName = property.Name
+		}
+		property.Name = newName
+		delete(database.Properties, name)
+		database.Properties[newName] = property
+	}
+	database.LastEditedTime = s.now()
+
+	writeJSON(w, database)
+}
+
+func (s *Server) handleQueryDatabase(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	var req notion.DatabaseQueryRequest
+	json.NewDecoder(r.Body).Decode(&req)
+
+	s.mu.Lock()
+	if _, ok := s.databases[r.PathValue("id")]; !ok {
+		s.mu.Unlock()
+		writeNotFound(w, r.PathValue("id"))
+		return
+	}
+	var results []notion.Page
+	for _, id := range s.pageOrder {
+		page := s.pages[id]
+		if page.Parent.DatabaseID == r.PathValue("id") && !page.Archived && !page.InTrash {
+			results = append(results, *page)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, paginate(results, req.StartCursor, strconv.Itoa(req.PageSize), s.pageSize()))
+}
+
+func (s *Server) handleCreatePage(w http.ResponseWriter, r *http.Request, user notion.User) {
+	var req notion.CreatePageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	if req.Parent.DatabaseID != "" {
+		if _, ok := s.databases[req.Parent.DatabaseID]; !ok {
+			s.mu.Unlock()
+			writeNotFound(w, req.Parent.DatabaseID)
+			return
+		}
+	}
+	page := s.storePage(notion.Page{
+		Parent:       req.Parent,
+		Properties:   req.Properties,
+		Icon:         req.Icon,
+		Cover:        req.Cover,
+		CreatedBy:    user,
+		LastEditedBy: user,
+	})
+	s.mu.Unlock()
+
+	s.emitPageEvent("page.created", page, nil)
+	writeJSON(w, page)
+}
+
+func (s *Server) handleRetrievePage(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	page, ok := s.pages[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, r.PathValue("id"))
+		return
+	}
+	writeJSON(w, page)
+}
+
+func (s *Server) handleUpdatePage(w http.ResponseWriter, r *http.Request, user notion.User) {
+	var req notion.UpdatePageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	current, ok := s.pages[r.PathValue("id")]
+	if !ok {
+		s.mu.Unlock()
+		writeNotFound(w, r.PathValue("id"))
+		return
+	}
+
+	page := *current
+	page.Properties = make(map[string]notion.PropertyValue, len(current.Properties))
+	for name, value := range current.Properties {
+		page.Properties[name] = value
+	}
+	var updated []string
+	for name, value := range s.normalizeProperties(page.Parent.DatabaseID, req.Properties) {
+		page.Properties[name] = value
+		updated = append(updated, value.ID)
+	}
+	if req.Archived != nil {
+		page.Archived = *req.Archived
+	}
+	if req.Icon != nil {
+		page.Icon = req.Icon
+	}
+	if req.Cover != nil {
+		page.Cover = req.Cover
+	}
+	page.LastEditedBy = user
+	page = s.storePage(page)
+	s.mu.Unlock()
+
+	switch {
+	case req.Archived != nil && *req.Archived && !current.Archived:
+		s.emitPageEvent("page.deleted", page, nil)
+	case len(updated) > 0:
+		slices.Sort(updated)
+		s.emitPageEvent("page.properties_updated", page, updated)
+	}
+	writeJSON(w, page)
+}
+
+// handlePropertyItem serves title, rich_text, people and relation values as paginated
+// lists of property_item objects, and other values as a single property_item
+func (s *Server) handlePropertyItem(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	s.mu.Lock()
+	page, ok := s.pages[r.PathValue("id")]
+	var value notion.PropertyValue
+	found := false
+	if ok {
+		for _, candidate := range page.Properties {
+			if candidate.ID == r.PathValue("property") {
+				value, found = candidate, true
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	if !found {
+		writeNotFound(w, r.PathValue("property"))
+		return
+	}
+
+	var items []map[string]any
+	item := func(v any) map[string]any {
+		return map[string]any{"object": "property_item", "id": value.ID, "type": value.Type, value.Type: v}
+	}
+	switch value.Type {
+	case "title":
+		for _, v := range value.Title {
+			items = append(items, item(v))
+		}
+	case "rich_text":
+		for _, v := range value.RichText {
+			items = append(items, item(v))
+		}
+	case "people":
+		for _, v := range value.People {
+			items = append(items, item(v))
+		}
+	case "relation":
+		for _, v := range value.Relation {
+			items = append(items, item(v))
+		}
+	default:
+		single := map[string]any{}
+		encoded, _ := json.Marshal(value)
+		json.Unmarshal(encoded, &single)
+		single["object"] = "property_item"
+		writeJSON(w, single)
+		return
+	}
+
+	list := paginate(items, r.URL.Query().Get("start_cursor"), r.URL.Query().Get("page_size"), s.pageSize())
+	list["property_item"] = map[string]any{"id": value.ID, "type": value.Type, value.Type: map[string]any{}}
+	writeJSON(w, list)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request, _ notion.User) {
+	var req notion.SearchRequest
+	json.NewDecoder(r.Body).Decode(&req)
+	query := strings.ToLower(req.Query)
+
+	s.mu.Lock()
+	var results []searchResult
+	if req.Filter == nil || req.Filter.Value == notion.SearchFilterDatabase {
+		for _, database := range s.databases {
+			if strings.Contains(strings.ToLower(notion.PlainText(database.Title)), query) {
+				results = append(results, searchResult{value: database, edited: database.LastEditedTime})
+			}
+		}
+	}
+	if req.Filter == nil || req.Filter.Value == notion.SearchFilterPage {
+		for _, id := range s.pageOrder {
+			page := s.pages[id]
+			if !page.Archived && strings.Contains(strings.ToLower(pageTitle(page)), query) {
+				results = append(results, searchResult{value: page, edited: page.LastEditedTime})
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	ascending := req.Sort != nil && req.Sort.Direction == notion.SortAscending
+	slices.SortStableFunc(results, func(a, b searchResult) int {
+		if ascending {
+			return a.edited.Compare(b.edited)
+		}
+		return b.edited.Compare(a.edited)
+	})
+
+	values := make([]any, len(results))
+	for i, result := range results {
+		values[i] = result.value
+	}
+	writeJSON(w, paginate(values, req.StartCursor, strconv.Itoa(req.PageSize), s.pageSize()))
+}
+
+type searchResult struct {
+	value  any
+	edited time.Time
+}
+
+// paginate returns one page of items as a list response. Cursors are item offsets.
+func paginate[T any](items []T, cursor string, pageSize string, defaultSize int) map[string]any {
+	start, _ := strconv.Atoi(cursor)
+	size, _ := strconv.Atoi(pageSize)
+	if size <= 0 || size > defaultSize {
+		size = defaultSize
+	}
+	start = min(start, len(items))
+	end := min(start+size, len(items))
+
+	results := items[start:end]
+	if results == nil {
+		results = []T{}
+	}
+	list := map[string]any{
+		"object":      "list",
+		"results":     results,
+		"has_more":    end < len(items),
+		"next_cursor": nil,
+	}
+	if end < len(items) {
+		list["next_cursor"] = strconv.Itoa(end)
+	}
+	return list
+}
+
+func (s *Server) pageSize() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.PageSize
+}
+
+func pageTitle(page *notion.Page) string {
+	for _, value := range page.Properties {
+		if value.Type == "title" {
+			return notion.PlainText(value.Title)
+		}
+	}
+	return ""
+}
+
+func shortID() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")[:8]
+}
+
+// readBody reads the request body and leaves it readable for the handler
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
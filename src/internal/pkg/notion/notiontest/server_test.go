@@ -0,0 +1,286 @@
+package notiontest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/modules/webhooks/domain"
+	webhookInfra "src/internal/modules/webhooks/infrastructure/http"
+	"src/internal/pkg/notion"
+	"src/internal/pkg/notion/notiontest"
+)
+
+func title(text string) notion.PropertyValue {
+	return notion.PropertyValue{Title: []notion.RichText{{Type: "text", PlainText: text}}}
+}
+
+var _ = Describe("Server", func() {
+	var (
+		server   *notiontest.Server
+		service  *notion.Service
+		ctx      context.Context
+		database notion.Database
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = notiontest.NewServer()
+		DeferCleanup(server.Close)
+
+		server.SetOAuthClient("client-id", "client-secret")
+		service = notion.NewService(notion.ServiceConfig{
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+			RedirectURI:  "http://localhost/callback",
+		}, server.ClientOptions()...)
+
+		database = server.AddDatabase(notion.Database{
+			Title: []notion.RichText{{PlainText: "Tasks"}},
+			Properties: map[string]notion.Property{
+				"Name":   {Type: "title", Title: &struct{}{}},
+				"Blocks": {Type: "relation"},
+			},
+		})
+	})
+
+	It("exchanges OAuth codes for tokens the API accepts", func() {
+		server.AddOAuthCode("code-1", notion.OAuthTokenResponse{
+			AccessToken:   "secret_user",
+			WorkspaceName: "Acme",
+			Owner:         notion.User{ID: "user-1", Type: "person", Name: "Ada"},
+		})
+
+		_, err := service.GetCurrentUser(ctx, "secret_user")
+		var apiErr *notion.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Status).To(Equal(http.StatusUnauthorized))
+
+		token, err := service.ExchangeCodeForToken(ctx, "code-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.AccessToken).To(Equal("secret_user"))
+		Expect(token.WorkspaceName).To(Equal("Acme"))
+
+		user, err := service.GetCurrentUser(ctx, token.AccessToken)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user.Name).To(Equal("Ada"))
+
+		// Codes can only be used once
+		_, err = service.ExchangeCodeForToken(ctx, "code-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects token exchanges with wrong client credentials", func() {
+		server.AddOAuthCode("code-1", notion.OAuthTokenResponse{AccessToken: "secret_user"})
+		other := notion.NewService(notion.ServiceConfig{ClientID: "client-id", ClientSecret: "wrong"}, server.ClientOptions()...)
+
+		_, err := other.ExchangeCodeForToken(ctx, "code-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("pages through query results with cursors", func() {
+		server.PageSize = 2
+		for _, name := range []string{"One", "Two", "Three", "Four", "Five"} {
+			server.AddPage(notion.Page{
+				Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+				Properties: map[string]notion.PropertyValue{"Name": title(name)},
+			})
+		}
+
+		first, err := service.QueryDatabase(ctx, notiontest.DefaultToken, database.ID, &notion.DatabaseQueryRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first.Results).To(HaveLen(2))
+		Expect(first.HasMore).To(BeTrue())
+		Expect(first.Results[0].Properties["Name"].Type).To(Equal("title"))
+
+		pages, err := notion.CollectAll(service.QueryDatabaseAll(ctx, notiontest.DefaultToken, database.ID, nil), 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pages).To(HaveLen(5))
+		Expect(pages[4].Properties["Name"].Title[0].PlainText).To(Equal("Five"))
+	})
+
+	It("pages through relation property items", func() {
+		server.PageSize = 1
+		page := server.AddPage(notion.Page{
+			Parent: notion.Parent{Type: "database_id", DatabaseID: database.ID},
+			Properties: map[string]notion.PropertyValue{
+				"Name":   title("Parent"),
+				"Blocks": {Relation: []notion.Relation{{ID: "a"}, {ID: "b"}, {ID: "c"}}},
+			},
+		})
+
+		var ids []string
+		for item, err := range service.Pages.PropertyItems(ctx, notiontest.DefaultToken, page.ID, page.Properties["Blocks"].ID) {
+			Expect(err).ToNot(HaveOccurred())
+			for _, relation := range item.Relation {
+				ids = append(ids, relation.ID)
+			}
+		}
+		Expect(ids).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("creates, updates and archives pages", func() {
+		page, err := service.CreatePage(ctx, notiontest.DefaultToken, &notion.CreatePageRequest{
+			Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+			Properties: map[string]notion.PropertyValue{"Name": title("Draft")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		archived := true
+		_, err = service.UpdatePage(ctx, notiontest.DefaultToken, page.ID, &notion.UpdatePageRequest{
+			Properties: map[string]notion.PropertyValue{"Name": title("Final")},
+			Archived:   &archived,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		stored, ok := server.Page(page.ID)
+		Expect(ok).To(BeTrue())
+		Expect(stored.Archived).To(BeTrue())
+		Expect(stored.Properties["Name"].Title[0].PlainText).To(Equal("Final"))
+
+		result, err := service.QueryDatabase(ctx, notiontest.DefaultToken, database.ID, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Results).To(BeEmpty())
+	})
+
+	It("returns Notion errors for unknown objects", func() {
+		_, err := service.RetrievePage(ctx, notiontest.DefaultToken, "missing")
+		var apiErr *notion.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Status).To(Equal(http.StatusNotFound))
+		Expect(apiErr.Code).To(Equal("object_not_found"))
+	})
+
+	It("simulates rate limiting with Retry-After", func() {
+		server.RateLimit(2, 0)
+
+		db, err := service.RetrieveDatabase(ctx, notiontest.DefaultToken, database.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(db.ID).To(Equal(database.ID))
+		Expect(server.Requests()).To(HaveLen(3))
+
+		server.RateLimit(10, 0)
+		_, err = service.RetrieveDatabase(ctx, notiontest.DefaultToken, database.ID)
+		var retryErr *notion.RetryError
+		Expect(errors.As(err, &retryErr)).To(BeTrue())
+		Expect(retryErr.StatusCode).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("applies schema changes", func() {
+		changes, err := service.EnsureDatabaseSchema(ctx, notiontest.DefaultToken, database.ID, map[string]*notion.PropertySchema{
+			"Name":     notion.TitleProperty(),
+			"Priority": notion.SelectProperty(notion.SelectOption{Name: "High"}, notion.SelectOption{Name: "Low"}),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(HaveLen(1))
+
+		stored, _ := server.Database(database.ID)
+		Expect(stored.Properties).To(HaveKey("Priority"))
+		Expect(stored.Properties["Priority"].Type).To(Equal("select"))
+		Expect(stored.Properties["Priority"].ID).ToNot(BeEmpty())
+	})
+
+	It("searches pages and databases by title", func() {
+		server.AddPage(notion.Page{
+			Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+			Properties: map[string]notion.PropertyValue{"Name": title("Write tasks")},
+		})
+
+		var objects []string
+		for result, err := range service.SearchAll(ctx, notiontest.DefaultToken, &notion.SearchRequest{Query: "task"}) {
+			Expect(err).ToNot(HaveOccurred())
+			objects = append(objects, result.Object)
+		}
+		Expect(objects).To(ConsistOf("page", "database"))
+	})
+
+	Describe("webhooks", func() {
+		var (
+			mu         sync.Mutex
+			deliveries [][]byte
+			receiver   *httptest.Server
+		)
+
+		BeforeEach(func() {
+			deliveries = nil
+			validator := webhookInfra.NewHMACSHA256Validator()
+			receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				signature := domain.NewWebhookSignature(r.Header.Get("X-Notion-Signature"), "whsec")
+				if validator.ValidateSignature(signature, body) != nil {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				mu.Lock()
+				deliveries = append(deliveries, body)
+				mu.Unlock()
+			}))
+			DeferCleanup(receiver.Close)
+		})
+
+		It("delivers signed events for page writes", func() {
+			server.Subscribe(receiver.URL, "whsec")
+
+			page, err := service.CreatePage(ctx, notiontest.DefaultToken, &notion.CreatePageRequest{
+				Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+				Properties: map[string]notion.PropertyValue{"Name": title("Draft")},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = service.UpdatePage(ctx, notiontest.DefaultToken, page.ID, &notion.UpdatePageRequest{
+				Properties: map[string]notion.PropertyValue{"Name": title("Final")},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(server.WebhookErrors()).To(BeEmpty())
+			Expect(deliveries).To(HaveLen(2))
+
+			var event struct {
+				Type   string `json:"type"`
+				Entity struct {
+					ID string `json:"id"`
+				} `json:"entity"`
+				Data struct {
+					Parent struct {
+						ID string `json:"id"`
+					} `json:"parent"`
+					UpdatedProperties []string `json:"updated_properties"`
+				} `json:"data"`
+			}
+			Expect(json.Unmarshal(deliveries[1], &event)).To(Succeed())
+			Expect(event.Type).To(Equal("page.properties_updated"))
+			Expect(event.Entity.ID).To(Equal(page.ID))
+			Expect(event.Data.Parent.ID).To(Equal(database.ID))
+			Expect(event.Data.UpdatedProperties).To(Equal([]string{database.Properties["Name"].ID}))
+		})
+
+		It("reports deliveries the receiver rejects", func() {
+			server.Subscribe(receiver.URL, "other-secret")
+
+			_, err := service.CreatePage(ctx, notiontest.DefaultToken, &notion.CreatePageRequest{
+				Parent:     notion.Parent{Type: "database_id", DatabaseID: database.ID},
+				Properties: map[string]notion.PropertyValue{"Name": title("Draft")},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.WebhookErrors()).To(HaveLen(1))
+			Expect(deliveries).To(BeEmpty())
+		})
+
+		It("emits explicit events", func() {
+			resp, err := notiontest.EmitWebhook(ctx, receiver.URL, "whsec", notiontest.WebhookEvent{
+				Type:   "database.schema_updated",
+				Entity: notiontest.WebhookEntity{ID: database.ID, Type: "database"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(deliveries).To(HaveLen(1))
+		})
+	})
+})
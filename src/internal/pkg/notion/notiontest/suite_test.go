@@ -0,0 +1,13 @@
+package notiontest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNotiontest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fake Notion Server Suite")
+}
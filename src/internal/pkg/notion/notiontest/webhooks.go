@@ -0,0 +1,164 @@
+package notiontest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"src/internal/pkg/notion"
+)
+
+// WebhookEvent is the body of a webhook delivery, shaped like Notion's
+type WebhookEvent struct {
+	ID             string          `json:"id"`
+	Timestamp      time.Time       `json:"timestamp"`
+	WorkspaceID    string          `json:"workspace_id"`
+	SubscriptionID string          `json:"subscription_id"`
+	IntegrationID  string          `json:"integration_id"`
+	Type           string          `json:"type"`
+	Entity         WebhookEntity   `json:"entity"`
+	Data           json.RawMessage `json:"data,omitempty"`
+	AttemptNumber  int             `json:"attempt_number"`
+}
+
+// WebhookEntity is the page or database an event is about
+type WebhookEntity struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type webhookSubscription struct {
+	url    string
+	secret string
+	id     string
+	client *http.Client
+	errs   []error
+}
+
+// Subscribe delivers page.created, page.properties_updated and page.deleted events
+// for writes made through the API to url, signed with secret. Deliveries happen
+// synchronously before the write's response is sent; failures are kept in WebhookErrors.
+func (s *Server) Subscribe(url, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhook = &webhookSubscription{url: url, secret: secret, id: uuid.NewString(), client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// WebhookErrors returns the errors of failed webhook deliveries
+func (s *Server) WebhookErrors() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.webhook == nil {
+		return nil
+	}
+	return append([]error(nil), s.webhook.errs...)
+}
+
+// SignWebhook returns the X-Notion-Signature header value for payload
+func SignWebhook(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// EmitWebhook posts a signed event to url. Missing ID and timestamp are filled in.
+func EmitWebhook(ctx context.Context, url, secret string, event WebhookEvent) (*http.Response, error) {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	if event.AttemptNumber == 0 {
+		event.AttemptNumber = 1
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "notion-api")
+	req.Header.Set("X-Notion-Signature", SignWebhook(payload, secret))
+
+	return http.DefaultClient.Do(req)
+}
+
+// emitPageEvent delivers an event about page to the subscribed endpoint, if any.
+// It must be called without holding the lock.
+func (s *Server) emitPageEvent(eventType string, page notion.Page, updatedProperties []string) {
+	s.mu.Lock()
+	subscription := s.webhook
+	s.mu.Unlock()
+	if subscription == nil {
+		return
+	}
+
+	data := map[string]any{"parent": map[string]string{"id": page.Parent.DatabaseID, "type": "database"}}
+	if page.Parent.DatabaseID == "" {
+		data["parent"] = map[string]string{"id": page.Parent.PageID, "type": "page"}
+	}
+	if updatedProperties != nil {
+		data["updated_properties"] = updatedProperties
+	}
+	encoded, _ := json.Marshal(data)
+
+	event := WebhookEvent{
+		SubscriptionID: subscription.id,
+		Type:           eventType,
+		Entity:         WebhookEntity{ID: page.ID, Type: "page"},
+		Data:           encoded,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := EmitWebhook(ctx, subscription.url, subscription.secret, event)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			err = fmt.Errorf("webhook %s returned status %d", eventType, resp.StatusCode)
+		}
+	}
+	if err != nil {
+		s.mu.Lock()
+		subscription.errs = append(subscription.errs, err)
+		s.mu.Unlock()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in Notion's format
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(notion.APIError{Object: "error", Status: status, Code: code, Message: message})
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "object_not_found",
+		fmt.Sprintf("Could not find object with ID: %s. Make sure the relevant pages and databases are shared with your integration.", id))
+}
+
+// writeOAuthError writes a token endpoint error in the OAuth format Notion uses
+func writeOAuthError(w http.ResponseWriter, code, description string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{"error": code, "error_description": description})
+}
@@ -1,6 +1,7 @@
 package notion
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"log"
@@ -27,7 +28,7 @@ func NewOAuth(clientID, clientSecret, redirectURI string, opts ...ClientOption)
 
 // GetAuthorizationURL generates the OAuth authorization URL
 func (o *OAuth) GetAuthorizationURL(state string) string {
-	baseURL := "https://api.notion.com/v1/oauth/authorize"
+	baseURL := o.client.baseURL + "/oauth/authorize"
 
 	params := url.Values{}
 	params.Add("client_id", o.clientID)
@@ -41,7 +42,7 @@ func (o *OAuth) GetAuthorizationURL(state string) string {
 }
 
 // ExchangeCodeForToken exchanges an authorization code for an access token
-func (o *OAuth) ExchangeCodeForToken(code string) (*OAuthTokenResponse, error) {
+func (o *OAuth) ExchangeCodeForToken(ctx context.Context, code string) (*OAuthTokenResponse, error) {
 	tokenReq := OAuthTokenRequest{
 		GrantType:   "authorization_code",
 		Code:        code,
@@ -49,7 +50,7 @@ func (o *OAuth) ExchangeCodeForToken(code string) (*OAuthTokenResponse, error) {
 	}
 	accessToken := base64.StdEncoding.EncodeToString(fmt.Appendf(nil, "%s:%s", o.clientID, o.clientSecret))
 
-	resp, err := o.client.makeRequest("POST", "/oauth/token", tokenReq, accessToken, authTypeBasic)
+	resp, err := o.client.makeRequest(ctx, "POST", "/oauth/token", tokenReq, accessToken, authTypeBasic)
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
 	}
@@ -65,8 +66,8 @@ func (o *OAuth) ExchangeCodeForToken(code string) (*OAuthTokenResponse, error) {
 }
 
 // GetCurrentUser retrieves the current user information
-func (o *OAuth) GetCurrentUser(accessToken string) (*User, error) {
-	resp, err := o.client.makeRequest("GET", "/users/me", nil, accessToken, authTypeBearer)
+func (o *OAuth) GetCurrentUser(ctx context.Context, accessToken string) (*User, error) {
+	resp, err := o.client.makeRequest(ctx, "GET", "/users/me", nil, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
@@ -1,7 +1,10 @@
 package notion
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 // Pages provides methods for working with Notion pages
@@ -17,8 +20,8 @@ func NewPages(opts ...ClientOption) *Pages {
 }
 
 // Create creates a new page
-func (p *Pages) Create(accessToken string, request *CreatePageRequest) (*Page, error) {
-	resp, err := p.client.makeRequest("POST", "/pages", request, accessToken, authTypeBearer)
+func (p *Pages) Create(ctx context.Context, accessToken string, request *CreatePageRequest) (*Page, error) {
+	resp, err := p.client.makeRequest(ctx, "POST", "/pages", request, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create page: %w", err)
 	}
@@ -32,10 +35,10 @@ func (p *Pages) Create(accessToken string, request *CreatePageRequest) (*Page, e
 }
 
 // Retrieve retrieves a page by ID
-func (p *Pages) Retrieve(accessToken, pageID string) (*Page, error) {
+func (p *Pages) Retrieve(ctx context.Context, accessToken, pageID string) (*Page, error) {
 	endpoint := fmt.Sprintf("/pages/%s", pageID)
 
-	resp, err := p.client.makeRequest("GET", endpoint, nil, accessToken, authTypeBearer)
+	resp, err := p.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve page: %w", err)
 	}
@@ -49,10 +52,10 @@ func (p *Pages) Retrieve(accessToken, pageID string) (*Page, error) {
 }
 
 // Update updates an existing page
-func (p *Pages) Update(accessToken, pageID string, request *UpdatePageRequest) (*Page, error) {
+func (p *Pages) Update(ctx context.Context, accessToken, pageID string, request *UpdatePageRequest) (*Page, error) {
 	endpoint := fmt.Sprintf("/pages/%s", pageID)
 
-	resp, err := p.client.makeRequest("PATCH", endpoint, request, accessToken, authTypeBearer)
+	resp, err := p.client.makeRequest(ctx, "PATCH", endpoint, request, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update page: %w", err)
 	}
@@ -65,11 +68,12 @@ func (p *Pages) Update(accessToken, pageID string, request *UpdatePageRequest) (
 	return &page, nil
 }
 
-// GetPropertyItem retrieves a specific property item from a page
-func (p *Pages) GetPropertyItem(accessToken, pageID, propertyID string) (*PropertyValue, error) {
+// GetPropertyItem retrieves a specific property item from a page.
+// Title, rich_text, people and relation properties are paginated; use PropertyItems for those.
+func (p *Pages) GetPropertyItem(ctx context.Context, accessToken, pageID, propertyID string) (*PropertyValue, error) {
 	endpoint := fmt.Sprintf("/pages/%s/properties/%s", pageID, propertyID)
 
-	resp, err := p.client.makeRequest("GET", endpoint, nil, accessToken, authTypeBearer)
+	resp, err := p.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get property item: %w", err)
 	}
@@ -81,3 +85,93 @@ func (p *Pages) GetPropertyItem(accessToken, pageID, propertyID string) (*Proper
 
 	return &property, nil
 }
+
+// PropertyItems iterates over the values of a page property, following cursors.
+// Paginated properties (title, rich_text, people, relation and rollups of them) yield
+// one PropertyValue per entry, each holding a single element; other properties yield
+// exactly one PropertyValue.
+func (p *Pages) PropertyItems(ctx context.Context, accessToken, pageID, propertyID string) iter.Seq2[PropertyValue, error] {
+	return paginate(ctx, "", func(ctx context.Context, cursor string) ([]PropertyValue, string, bool, error) {
+		endpoint := withQuery(fmt.Sprintf("/pages/%s/properties/%s", pageID, propertyID), paginationParams(cursor, 0))
+
+		resp, err := p.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("failed to get property items: %w", err)
+		}
+
+		var raw json.RawMessage
+		if err := p.client.handleResponse(resp, &raw); err != nil {
+			return nil, "", false, fmt.Errorf("failed to parse property items response: %w", err)
+		}
+
+		var list propertyItemList
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, "", false, fmt.Errorf("failed to parse property items response: %w", err)
+		}
+
+		if list.Object != "list" {
+			var property PropertyValue
+			if err := json.Unmarshal(raw, &property); err != nil {
+				return nil, "", false, fmt.Errorf("failed to parse property response: %w", err)
+			}
+			return []PropertyValue{property}, "", false, nil
+		}
+
+		values := make([]PropertyValue, 0, len(list.Results))
+		for _, result := range list.Results {
+			value, err := decodePropertyItem(result)
+			if err != nil {
+				return nil, "", false, fmt.Errorf("failed to parse property item: %w", err)
+			}
+			values = append(values, value)
+		}
+
+		return values, list.NextCursor, list.HasMore, nil
+	})
+}
+
+// propertyItemList is one page of a paginated property item response
+type propertyItemList struct {
+	Object     string            `json:"object"`
+	Results    []json.RawMessage `json:"results"`
+	NextCursor string            `json:"next_cursor"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// propertyItem is an entry of a paginated property. Unlike PropertyValue it holds a
+// single title, rich_text, people or relation element instead of an array.
+type propertyItem struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Title    *RichText `json:"title"`
+	RichText *RichText `json:"rich_text"`
+	People   *User     `json:"people"`
+	Relation *Relation `json:"relation"`
+}
+
+// decodePropertyItem converts a paginated property entry into a PropertyValue
+func decodePropertyItem(data json.RawMessage) (PropertyValue, error) {
+	var item propertyItem
+	if err := json.Unmarshal(data, &item); err != nil {
+		return PropertyValue{}, err
+	}
+
+	value := PropertyValue{ID: item.ID, Type: item.Type}
+	switch {
+	case item.Type == "title" && item.Title != nil:
+		value.Title = []RichText{*item.Title}
+	case item.Type == "rich_text" && item.RichText != nil:
+		value.RichText = []RichText{*item.RichText}
+	case item.Type == "people" && item.People != nil:
+		value.People = []User{*item.People}
+	case item.Type == "relation" && item.Relation != nil:
+		value.Relation = []Relation{*item.Relation}
+	default:
+		// Other entries, e.g. numbers inside a rollup, use the regular value shape
+		if err := json.Unmarshal(data, &value); err != nil {
+			return PropertyValue{}, err
+		}
+	}
+
+	return value, nil
+}
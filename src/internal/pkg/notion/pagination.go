@@ -0,0 +1,93 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// DefaultMaxItems is the cap CollectAll applies when none is given
+const DefaultMaxItems = 10000
+
+// ErrTooManyItems is returned by CollectAll when an iterator yields more than the cap
+var ErrTooManyItems = errors.New("notion: iterator yielded more items than allowed")
+
+// pageFetcher retrieves one page of a paginated endpoint starting at cursor
+type pageFetcher[T any] func(ctx context.Context, cursor string) (results []T, nextCursor string, hasMore bool, err error)
+
+// paginate walks all cursors of a paginated endpoint, yielding one result at a time.
+// Every request goes through the client, so the rate limiter and retries apply; the
+// iterator stops with ctx's error when ctx is done between requests.
+func paginate[T any](ctx context.Context, cursor string, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			results, nextCursor, hasMore, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, result := range results {
+				if !yield(result, nil) {
+					return
+				}
+			}
+
+			if !hasMore || nextCursor == "" {
+				return
+			}
+			cursor = nextCursor
+		}
+	}
+}
+
+// CollectAll drains seq into a slice. It stops at the first error, and returns
+// ErrTooManyItems along with the first maxItems items when seq yields more than that.
+// maxItems <= 0 uses DefaultMaxItems.
+func CollectAll[T any](seq iter.Seq2[T, error], maxItems int) ([]T, error) {
+	if maxItems <= 0 {
+		maxItems = DefaultMaxItems
+	}
+
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		if len(items) == maxItems {
+			return items, fmt.Errorf("%w: limit is %d", ErrTooManyItems, maxItems)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// paginationParams holds the query parameters of a paginated GET endpoint
+func paginationParams(startCursor string, pageSize int) url.Values {
+	params := url.Values{}
+	if startCursor != "" {
+		params.Set("start_cursor", startCursor)
+	}
+	if pageSize > 0 {
+		params.Set("page_size", strconv.Itoa(pageSize))
+	}
+	return params
+}
+
+// withQuery appends params to an endpoint path
+func withQuery(endpoint string, params url.Values) string {
+	if len(params) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + params.Encode()
+}
@@ -0,0 +1,197 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+var _ = Describe("Pagination", func() {
+	var (
+		server    *httptest.Server
+		requests  atomic.Int32
+		databases *notion.Databases
+		pages     *notion.Pages
+		ctx       context.Context
+	)
+
+	// respond serves results in pages of two, using the result index as cursor
+	respond := func(w http.ResponseWriter, cursor string, results []string) {
+		start := 0
+		if cursor != "" {
+			fmt.Sscanf(cursor, "cursor-%d", &start)
+		}
+		end := min(start+2, len(results))
+
+		resp := map[string]any{
+			"object":   "list",
+			"results":  json.RawMessage("[" + strings.Join(results[start:end], ",") + "]"),
+			"has_more": end < len(results),
+		}
+		if end < len(results) {
+			resp["next_cursor"] = fmt.Sprintf("cursor-%d", end)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		requests.Store(0)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /v1/databases/db-1/query", func(w http.ResponseWriter, r *http.Request) {
+			var query notion.DatabaseQueryRequest
+			json.NewDecoder(r.Body).Decode(&query)
+			respond(w, query.StartCursor, []string{
+				`{"object": "page", "id": "page-1"}`,
+				`{"object": "page", "id": "page-2"}`,
+				`{"object": "page", "id": "page-3"}`,
+				`{"object": "page", "id": "page-4"}`,
+				`{"object": "page", "id": "page-5"}`,
+			})
+		})
+		mux.HandleFunc("POST /v1/search", func(w http.ResponseWriter, r *http.Request) {
+			var query struct {
+				StartCursor string `json:"start_cursor"`
+			}
+			json.NewDecoder(r.Body).Decode(&query)
+			respond(w, query.StartCursor, []string{
+				`{"object": "database", "id": "db-1", "title": [{"plain_text": "Tasks"}]}`,
+				`{"object": "database", "id": "db-2", "title": [{"plain_text": "Projects"}]}`,
+				`{"object": "database", "id": "db-3", "title": [{"plain_text": "People"}]}`,
+			})
+		})
+		mux.HandleFunc("GET /v1/pages/page-1/properties/blocked-by", func(w http.ResponseWriter, r *http.Request) {
+			respond(w, r.URL.Query().Get("start_cursor"), []string{
+				`{"object": "property_item", "id": "blocked-by", "type": "relation", "relation": {"id": "page-2"}}`,
+				`{"object": "property_item", "id": "blocked-by", "type": "relation", "relation": {"id": "page-3"}}`,
+				`{"object": "property_item", "id": "blocked-by", "type": "relation", "relation": {"id": "page-4"}}`,
+			})
+		})
+		mux.HandleFunc("GET /v1/pages/page-1/properties/estimate", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"object": "property_item", "id": "estimate", "type": "number", "number": 3}`))
+		})
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			mux.ServeHTTP(w, r)
+		}))
+
+		opts := []notion.ClientOption{
+			notion.WithBaseURL(server.URL + "/v1"),
+			notion.WithRateLimiter(nil),
+		}
+		databases = notion.NewDatabases(opts...)
+		pages = notion.NewPages(opts...)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Databases.QueryAll", func() {
+		It("should walk all cursors", func() {
+			var ids []string
+			for page, err := range databases.QueryAll(ctx, "token", "db-1", nil) {
+				Expect(err).ToNot(HaveOccurred())
+				ids = append(ids, page.ID)
+			}
+
+			Expect(ids).To(Equal([]string{"page-1", "page-2", "page-3", "page-4", "page-5"}))
+			Expect(requests.Load()).To(Equal(int32(3)))
+		})
+
+		It("should stop requesting when the caller stops iterating", func() {
+			for page := range databases.QueryAll(ctx, "token", "db-1", nil) {
+				if page.ID == "page-2" {
+					break
+				}
+			}
+
+			Expect(requests.Load()).To(Equal(int32(1)))
+		})
+
+		It("should stop with the context error once the context is done", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			var err error
+			for page, iterErr := range databases.QueryAll(ctx, "token", "db-1", nil) {
+				if iterErr != nil {
+					err = iterErr
+					break
+				}
+				if page.ID == "page-2" {
+					cancel()
+				}
+			}
+
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(requests.Load()).To(Equal(int32(1)))
+		})
+
+		It("should yield request errors", func() {
+			items, err := notion.CollectAll(databases.QueryAll(ctx, "token", "db-unknown", nil), 0)
+
+			Expect(err).To(HaveOccurred())
+			Expect(items).To(BeEmpty())
+		})
+	})
+
+	Describe("CollectAll", func() {
+		It("should collect every item", func() {
+			items, err := notion.CollectAll(databases.QueryAll(ctx, "token", "db-1", nil), 10)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(items).To(HaveLen(5))
+		})
+
+		It("should stop at the cap", func() {
+			items, err := notion.CollectAll(databases.QueryAll(ctx, "token", "db-1", nil), 3)
+
+			Expect(errors.Is(err, notion.ErrTooManyItems)).To(BeTrue())
+			Expect(items).To(HaveLen(3))
+			Expect(requests.Load()).To(Equal(int32(2)))
+		})
+	})
+
+	Describe("Databases.ListAll", func() {
+		It("should iterate over all databases", func() {
+			databaseList, err := notion.CollectAll(databases.ListAll(ctx, "token", 0), 0)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(databaseList).To(HaveLen(3))
+			Expect(databaseList[1].ID).To(Equal("db-2"))
+			Expect(databaseList[1].Title[0].PlainText).To(Equal("Projects"))
+		})
+	})
+
+	Describe("Pages.PropertyItems", func() {
+		It("should walk paginated relation items", func() {
+			items, err := notion.CollectAll(pages.PropertyItems(ctx, "token", "page-1", "blocked-by"), 0)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(items).To(HaveLen(3))
+			Expect(items[0].Type).To(Equal("relation"))
+			Expect(items[2].Relation).To(Equal([]notion.Relation{{ID: "page-4"}}))
+		})
+
+		It("should yield a single value for properties that are not paginated", func() {
+			items, err := notion.CollectAll(pages.PropertyItems(ctx, "token", "page-1", "estimate"), 0)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(items).To(HaveLen(1))
+			Expect(*items[0].Number).To(Equal(3.0))
+		})
+	})
+})
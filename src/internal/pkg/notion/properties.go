@@ -0,0 +1,330 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrPropertyNotFound is returned when a page has no property with the given name
+var ErrPropertyNotFound = errors.New("property not found")
+
+// PropertyTypeError is returned when a property is not of a type an accessor can read
+type PropertyTypeError struct {
+	Property string
+	Type     string
+	Expected []string
+}
+
+func (e *PropertyTypeError) Error() string {
+	return fmt.Sprintf("property %q is of type %s, expected %s", e.Property, e.Type, strings.Join(e.Expected, " or "))
+}
+
+// DateRange is a date property value. End is nil for single dates, and HasTime is
+// false when Notion stores a date without a time, in which case Start is midnight UTC.
+type DateRange struct {
+	Start   time.Time
+	End     *time.Time
+	HasTime bool
+}
+
+// NewText returns plain rich text with the given content
+func NewText(content string) RichText {
+	return RichText{Type: "text", Text: &TextContent{Content: content}, PlainText: content}
+}
+
+// PlainText concatenates the plain text of rich text objects
+func PlainText(richText []RichText) string {
+	var b strings.Builder
+	for _, rt := range richText {
+		switch {
+		case rt.PlainText != "":
+			b.WriteString(rt.PlainText)
+		case rt.Text != nil:
+			b.WriteString(rt.Text.Content)
+		}
+	}
+	return b.String()
+}
+
+// ParseDate parses a date value. Notion sends dates as "2006-01-02" or as ISO 8601
+// timestamps, which carry an offset unless TimeZone is set.
+func ParseDate(value *DateValue) (*DateRange, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	location := time.UTC
+	if value.TimeZone != nil && *value.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(*value.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", *value.TimeZone, err)
+		}
+	}
+
+	start, hasTime, err := parseDateString(value.Start, location)
+	if err != nil {
+		return nil, err
+	}
+	date := &DateRange{Start: start, HasTime: hasTime}
+	if value.End != nil {
+		end, _, err := parseDateString(*value.End, location)
+		if err != nil {
+			return nil, err
+		}
+		date.End = &end
+	}
+	return date, nil
+}
+
+var timestampLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02T15:04"}
+
+func parseDateString(value string, location *time.Location) (time.Time, bool, error) {
+	if date, err := time.ParseInLocation(time.DateOnly, value, time.UTC); err == nil {
+		return date, false, nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+}
+
+// Property returns the named property value
+func (p *Page) Property(name string) (PropertyValue, error) {
+	value, ok := p.Properties[name]
+	if !ok {
+		return PropertyValue{}, fmt.Errorf("%w: %q", ErrPropertyNotFound, name)
+	}
+	return value, nil
+}
+
+// typedProperty returns the named property value if it has one of the expected types
+func (p *Page) typedProperty(name string, expected ...string) (PropertyValue, error) {
+	value, err := p.Property(name)
+	if err != nil {
+		return value, err
+	}
+	if !slices.Contains(expected, value.Type) {
+		return value, &PropertyTypeError{Property: name, Type: value.Type, Expected: expected}
+	}
+	return value, nil
+}
+
+// Title returns the plain text of the page's title property
+func (p *Page) Title() string {
+	for _, value := range p.Properties {
+		if value.Type == "title" {
+			return PlainText(value.Title)
+		}
+	}
+	return ""
+}
+
+// Text returns the plain text of a title, rich_text, url, email, phone_number or
+// string formula property; empty values are returned as ""
+func (p *Page) Text(name string) (string, error) {
+	value, err := p.typedProperty(name, "title", "rich_text", "url", "email", "phone_number", "formula")
+	if err != nil {
+		return "", err
+	}
+
+	switch value.Type {
+	case "title":
+		return PlainText(value.Title), nil
+	case "rich_text":
+		return PlainText(value.RichText), nil
+	case "url":
+		return deref(value.URL), nil
+	case "email":
+		return deref(value.Email), nil
+	case "phone_number":
+		return deref(value.PhoneNumber), nil
+	default:
+		if value.Formula == nil || value.Formula.Type != "string" {
+			return "", &PropertyTypeError{Property: name, Type: formulaType(value), Expected: []string{"formula (string)"}}
+		}
+		return deref(value.Formula.String), nil
+	}
+}
+
+// Number returns a number, number formula or number rollup property; nil when empty
+func (p *Page) Number(name string) (*float64, error) {
+	value, err := p.typedProperty(name, "number", "formula", "rollup")
+	if err != nil {
+		return nil, err
+	}
+
+	switch value.Type {
+	case "number":
+		return value.Number, nil
+	case "formula":
+		if value.Formula == nil || value.Formula.Type != "number" {
+			return nil, &PropertyTypeError{Property: name, Type: formulaType(value), Expected: []string{"formula (number)"}}
+		}
+		return value.Formula.Number, nil
+	default:
+		if value.Rollup == nil || value.Rollup.Type != "number" {
+			return nil, &PropertyTypeError{Property: name, Type: "rollup", Expected: []string{"rollup (number)"}}
+		}
+		return value.Rollup.Number, nil
+	}
+}
+
+// Checkbox returns a checkbox or boolean formula property
+func (p *Page) Checkbox(name string) (bool, error) {
+	value, err := p.typedProperty(name, "checkbox", "formula")
+	if err != nil {
+		return false, err
+	}
+
+	if value.Type == "formula" {
+		if value.Formula == nil || value.Formula.Type != "boolean" {
+			return false, &PropertyTypeError{Property: name, Type: formulaType(value), Expected: []string{"formula (boolean)"}}
+		}
+		return deref(value.Formula.Boolean), nil
+	}
+	return deref(value.Checkbox), nil
+}
+
+// Select returns the option name of a select property; "" when empty
+func (p *Page) Select(name string) (string, error) {
+	value, err := p.typedProperty(name, "select")
+	if err != nil || value.Select == nil {
+		return "", err
+	}
+	return value.Select.Name, nil
+}
+
+// Status returns the option name of a status property; "" when empty
+func (p *Page) Status(name string) (string, error) {
+	value, err := p.typedProperty(name, "status")
+	if err != nil || value.Status == nil {
+		return "", err
+	}
+	return value.Status.Name, nil
+}
+
+// MultiSelect returns the option names of a multi_select property
+func (p *Page) MultiSelect(name string) ([]string, error) {
+	value, err := p.typedProperty(name, "multi_select")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(value.MultiSelect))
+	for i, option := range value.MultiSelect {
+		names[i] = option.Name
+	}
+	return names, nil
+}
+
+// Date returns a date, created_time or last_edited_time property, or a formula or
+// rollup with a date result; nil when empty
+func (p *Page) Date(name string) (*DateRange, error) {
+	value, err := p.typedProperty(name, "date", "created_time", "last_edited_time", "formula", "rollup")
+	if err != nil {
+		return nil, err
+	}
+
+	switch value.Type {
+	case "date":
+		return ParseDate(value.Date)
+	case "created_time":
+		return timestampRange(value.CreatedTime), nil
+	case "last_edited_time":
+		return timestampRange(value.LastEditedTime), nil
+	case "formula":
+		if value.Formula == nil || value.Formula.Type != "date" {
+			return nil, &PropertyTypeError{Property: name, Type: formulaType(value), Expected: []string{"formula (date)"}}
+		}
+		return ParseDate(value.Formula.Date)
+	default:
+		if value.Rollup == nil || value.Rollup.Type != "date" {
+			return nil, &PropertyTypeError{Property: name, Type: "rollup", Expected: []string{"rollup (date)"}}
+		}
+		return ParseDate(value.Rollup.Date)
+	}
+}
+
+// People returns the users of a people, created_by or last_edited_by property
+func (p *Page) People(name string) ([]User, error) {
+	value, err := p.typedProperty(name, "people", "created_by", "last_edited_by")
+	if err != nil {
+		return nil, err
+	}
+
+	switch value.Type {
+	case "created_by":
+		return optionalSlice(value.CreatedBy), nil
+	case "last_edited_by":
+		return optionalSlice(value.LastEditedBy), nil
+	default:
+		return value.People, nil
+	}
+}
+
+// Relations returns the IDs of the pages in a relation property. Page objects carry at
+// most 25 relations; when the value has more, read them with Pages.PropertyItems.
+func (p *Page) Relations(name string) ([]string, error) {
+	value, err := p.typedProperty(name, "relation")
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(value.Relation))
+	for i, relation := range value.Relation {
+		ids[i] = relation.ID
+	}
+	return ids, nil
+}
+
+// UniqueID returns a unique_id property; nil when not assigned yet
+func (p *Page) UniqueID(name string) (*UniqueIDValue, error) {
+	value, err := p.typedProperty(name, "unique_id")
+	if err != nil {
+		return nil, err
+	}
+	return value.UniqueID, nil
+}
+
+// Verification returns a verification property; nil when empty
+func (p *Page) Verification(name string) (*Verification, error) {
+	value, err := p.typedProperty(name, "verification")
+	if err != nil {
+		return nil, err
+	}
+	return value.Verification, nil
+}
+
+func formulaType(value PropertyValue) string {
+	if value.Formula == nil {
+		return "formula"
+	}
+	return "formula (" + value.Formula.Type + ")"
+}
+
+func timestampRange(t *time.Time) *DateRange {
+	if t == nil {
+		return nil
+	}
+	return &DateRange{Start: *t, HasTime: true}
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func optionalSlice[T any](p *T) []T {
+	if p == nil {
+		return nil
+	}
+	return []T{*p}
+}
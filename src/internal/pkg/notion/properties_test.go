@@ -0,0 +1,268 @@
+package notion_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+const taskPageJSON = `{
+	"object": "page",
+	"id": "page-1",
+	"parent": {"type": "database_id", "database_id": "db-1"},
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Launch "}, {"type": "text", "plain_text": "beta"}]},
+		"Notes": {"id": "n", "type": "rich_text", "rich_text": []},
+		"Status": {"id": "s", "type": "status", "status": {"id": "in-progress", "name": "In progress", "color": "blue"}},
+		"Priority": {"id": "p", "type": "select", "select": {"name": "High"}},
+		"Tags": {"id": "t", "type": "multi_select", "multi_select": [{"name": "Backend"}, {"name": "Release"}]},
+		"Estimate": {"id": "e", "type": "number", "number": 5},
+		"Budget": {"id": "b", "type": "number", "number": null},
+		"Due": {"id": "d", "type": "date", "date": {"start": "2025-10-20", "end": "2025-10-24", "time_zone": null}},
+		"Kickoff": {"id": "k", "type": "date", "date": {"start": "2025-10-15T09:00:00.000", "end": null, "time_zone": "Europe/Paris"}},
+		"Assignee": {"id": "a", "type": "people", "people": [{"object": "user", "id": "user-1", "name": "Ada"}]},
+		"Blocked by": {"id": "r", "type": "relation", "relation": [{"id": "page-2"}, {"id": "page-3"}], "has_more": false},
+		"Critical": {"id": "c", "type": "checkbox", "checkbox": true},
+		"Spec": {"id": "u", "type": "url", "url": "https://example.com/spec"},
+		"Days left": {"id": "f", "type": "formula", "formula": {"type": "number", "number": 4}},
+		"Next review": {"id": "nr", "type": "formula", "formula": {"type": "date", "date": {"start": "2025-11-01", "end": null, "time_zone": null}}},
+		"Blocker count": {"id": "bc", "type": "rollup", "rollup": {"type": "number", "number": 2, "function": "count"}},
+		"ID": {"id": "i", "type": "unique_id", "unique_id": {"number": 42, "prefix": "TASK"}},
+		"Verified": {"id": "v", "type": "verification", "verification": {"state": "verified", "verified_by": {"object": "user", "id": "user-1"}, "date": {"start": "2025-10-01", "end": null, "time_zone": null}}},
+		"Created": {"id": "ct", "type": "created_time", "created_time": "2025-09-30T08:12:00.000Z"},
+		"Author": {"id": "cb", "type": "created_by", "created_by": {"object": "user", "id": "user-2"}}
+	}
+}`
+
+func taskPage() *notion.Page {
+	var page notion.Page
+	Expect(json.Unmarshal([]byte(taskPageJSON), &page)).To(Succeed())
+	return &page
+}
+
+var _ = Describe("Page property accessors", func() {
+	var page *notion.Page
+
+	BeforeEach(func() {
+		page = taskPage()
+	})
+
+	It("reads text properties", func() {
+		Expect(page.Title()).To(Equal("Launch beta"))
+
+		notes, err := page.Text("Notes")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(notes).To(BeEmpty())
+
+		spec, err := page.Text("Spec")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spec).To(Equal("https://example.com/spec"))
+	})
+
+	It("reads options", func() {
+		status, err := page.Status("Status")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal("In progress"))
+
+		priority, err := page.Select("Priority")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(priority).To(Equal("High"))
+
+		tags, err := page.MultiSelect("Tags")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(Equal([]string{"Backend", "Release"}))
+	})
+
+	It("reads numbers from numbers, formulas and rollups", func() {
+		estimate, err := page.Number("Estimate")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*estimate).To(Equal(5.0))
+
+		budget, err := page.Number("Budget")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(budget).To(BeNil())
+
+		daysLeft, err := page.Number("Days left")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*daysLeft).To(Equal(4.0))
+
+		blockers, err := page.Number("Blocker count")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*blockers).To(Equal(2.0))
+	})
+
+	It("reads dates", func() {
+		due, err := page.Date("Due")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(due.HasTime).To(BeFalse())
+		Expect(due.Start).To(Equal(time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)))
+		Expect(*due.End).To(Equal(time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC)))
+
+		kickoff, err := page.Date("Kickoff")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kickoff.HasTime).To(BeTrue())
+		Expect(kickoff.Start.UTC()).To(Equal(time.Date(2025, 10, 15, 7, 0, 0, 0, time.UTC)))
+
+		review, err := page.Date("Next review")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(review.Start).To(Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)))
+
+		created, err := page.Date("Created")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created.Start).To(Equal(time.Date(2025, 9, 30, 8, 12, 0, 0, time.UTC)))
+	})
+
+	It("reads people, relations and computed values", func() {
+		people, err := page.People("Assignee")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(people[0].Name).To(Equal("Ada"))
+
+		author, err := page.People("Author")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(author[0].ID).To(Equal("user-2"))
+
+		blockedBy, err := page.Relations("Blocked by")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(blockedBy).To(Equal([]string{"page-2", "page-3"}))
+
+		critical, err := page.Checkbox("Critical")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(critical).To(BeTrue())
+
+		id, err := page.UniqueID("ID")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id.String()).To(Equal("TASK-42"))
+
+		verification, err := page.Verification("Verified")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verification.State).To(Equal("verified"))
+	})
+
+	It("reports missing properties and type mismatches", func() {
+		_, err := page.Date("Deadline")
+		Expect(errors.Is(err, notion.ErrPropertyNotFound)).To(BeTrue())
+
+		_, err = page.Date("Priority")
+		var typeErr *notion.PropertyTypeError
+		Expect(errors.As(err, &typeErr)).To(BeTrue())
+		Expect(typeErr.Property).To(Equal("Priority"))
+		Expect(typeErr.Type).To(Equal("select"))
+
+		_, err = page.Text("Days left")
+		Expect(errors.As(err, &typeErr)).To(BeTrue())
+		Expect(typeErr.Type).To(Equal("formula (number)"))
+	})
+})
+
+type task struct {
+	ID        string              `notion:",id"`
+	Name      string              `notion:"Name,title"`
+	Notes     *string             `notion:"Notes,rich_text"`
+	Status    string              `notion:"Status,status"`
+	Priority  string              `notion:"Priority,select"`
+	Tags      []string            `notion:"Tags,multi_select"`
+	Estimate  int                 `notion:"Estimate,number"`
+	Budget    *float64            `notion:"Budget,number"`
+	Due       notion.DateRange    `notion:"Due,date"`
+	Kickoff   *time.Time          `notion:"Kickoff,date"`
+	Assignees []string            `notion:"Assignee,people"`
+	BlockedBy []string            `notion:"Blocked by,relation"`
+	Critical  bool                `notion:"Critical,checkbox"`
+	Key       string              `notion:"ID,unique_id"`
+	DaysLeft  float64             `notion:"Days left,formula"`
+	Verified  notion.Verification `notion:"Verified,verification"`
+	Ignored   string
+}
+
+var _ = Describe("Struct codec", func() {
+	It("decodes a page into a struct", func() {
+		var t task
+		Expect(notion.DecodePage(taskPage(), &t)).To(Succeed())
+
+		Expect(t.ID).To(Equal("page-1"))
+		Expect(t.Name).To(Equal("Launch beta"))
+		Expect(t.Notes).To(BeNil())
+		Expect(t.Status).To(Equal("In progress"))
+		Expect(t.Priority).To(Equal("High"))
+		Expect(t.Tags).To(Equal([]string{"Backend", "Release"}))
+		Expect(t.Estimate).To(Equal(5))
+		Expect(t.Budget).To(BeNil())
+		Expect(t.Due.End).ToNot(BeNil())
+		Expect(t.Kickoff).ToNot(BeNil())
+		Expect(t.Assignees).To(Equal([]string{"user-1"}))
+		Expect(t.BlockedBy).To(Equal([]string{"page-2", "page-3"}))
+		Expect(t.Critical).To(BeTrue())
+		Expect(t.Key).To(Equal("TASK-42"))
+		Expect(t.DaysLeft).To(Equal(4.0))
+		Expect(t.Verified.State).To(Equal("verified"))
+	})
+
+	It("rejects tags whose type does not match the property", func() {
+		var wrong struct {
+			Due string `notion:"Due,rich_text"`
+		}
+		err := notion.DecodePage(taskPage(), &wrong)
+		var typeErr *notion.PropertyTypeError
+		Expect(errors.As(err, &typeErr)).To(BeTrue())
+		Expect(typeErr.Type).To(Equal("date"))
+
+		var missing struct {
+			Deadline time.Time `notion:"Deadline,date"`
+		}
+		Expect(errors.Is(notion.DecodePage(taskPage(), &missing), notion.ErrPropertyNotFound)).To(BeTrue())
+	})
+
+	It("encodes a struct into an update request that round-trips", func() {
+		var t task
+		Expect(notion.DecodePage(taskPage(), &t)).To(Succeed())
+		t.Status = "Done"
+		t.Kickoff = nil
+
+		request, err := notion.EncodePage(t)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(request.Properties).ToNot(HaveKey("ID"))
+		Expect(request.Properties).ToNot(HaveKey("Days left"))
+		Expect(request.Properties).ToNot(HaveKey("Verified"))
+
+		encoded, err := json.Marshal(request)
+		Expect(err).ToNot(HaveOccurred())
+		var body struct {
+			Properties map[string]map[string]any `json:"properties"`
+		}
+		Expect(json.Unmarshal(encoded, &body)).To(Succeed())
+		Expect(body.Properties["Status"]["status"]).To(Equal(map[string]any{"name": "Done"}))
+		Expect(body.Properties["Due"]["date"]).To(HaveKeyWithValue("start", "2025-10-20"))
+		Expect(body.Properties["Due"]["date"]).To(HaveKeyWithValue("end", "2025-10-24"))
+		Expect(body.Properties["Notes"]).To(HaveKeyWithValue("rich_text", []any{}))
+		// Cleared values are sent as null so Notion empties the property
+		Expect(body.Properties["Kickoff"]).To(HaveKeyWithValue("date", BeNil()))
+		Expect(body.Properties["Budget"]).To(HaveKeyWithValue("number", BeNil()))
+
+		// Decoding the updated properties gives back the struct
+		updated := taskPage()
+		for name, value := range request.Properties {
+			updated.Properties[name] = value
+		}
+		var roundTripped task
+		Expect(notion.DecodePage(updated, &roundTripped)).To(Succeed())
+		Expect(roundTripped.Status).To(Equal("Done"))
+		Expect(roundTripped.Kickoff).To(BeNil())
+		Expect(roundTripped.Name).To(Equal(t.Name))
+		Expect(roundTripped.Assignees).To(Equal(t.Assignees))
+	})
+
+	It("skips empty values tagged omitempty", func() {
+		request, err := notion.EncodePage(struct {
+			Name     string   `notion:"Name,title"`
+			Estimate *float64 `notion:"Estimate,number,omitempty"`
+		}{Name: "Draft"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(request.Properties).To(HaveKey("Name"))
+		Expect(request.Properties).ToNot(HaveKey("Estimate"))
+	})
+})
@@ -0,0 +1,138 @@
+package notion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRequestsPerSecond is Notion's average request limit per integration
+	DefaultRequestsPerSecond = 3
+	// DefaultBurst is how many requests may be sent at once before throttling kicks in
+	DefaultBurst = 3
+)
+
+// defaultRateLimiter is shared by every client in the process that does not set its own
+var defaultRateLimiter RateLimiter = NewTokenBucketLimiter(DefaultRequestsPerSecond, DefaultBurst)
+
+// RateLimiter throttles requests to the Notion API.
+// The key is the token the request is made with, since Notion limits per integration.
+type RateLimiter interface {
+	// Wait blocks until a request for key may be sent or ctx is done
+	Wait(ctx context.Context, key string) error
+}
+
+// TokenBucketLimiter is an in-process token bucket per token
+type TokenBucketLimiter struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+// NewTokenBucketLimiter creates a limiter allowing requestsPerSecond per token
+func NewTokenBucketLimiter(requestsPerSecond float64, burst int) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		limit:    rate.Limit(requestsPerSecond),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Wait blocks until the bucket for key has a token
+func (l *TokenBucketLimiter) Wait(ctx context.Context, key string) error {
+	l.mu.Lock()
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[key] = limiter
+	}
+	l.mu.Unlock()
+
+	return limiter.Wait(ctx)
+}
+
+// redisTokenBucket takes one token from the bucket in KEYS[1] and returns 0, or returns
+// how many milliseconds to wait when the bucket is empty. It uses the Redis clock so
+// processes with skewed clocks still share one bucket.
+var redisTokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + (now - ts) * rate / 1000)
+
+local wait = 0
+if tokens < 1 then
+  wait = math.ceil((1 - tokens) * 1000 / rate)
+else
+  tokens = tokens - 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`)
+
+// RedisRateLimiter is a token bucket per token shared by all processes using the same Redis
+type RedisRateLimiter struct {
+	client            *redis.Client
+	requestsPerSecond float64
+	burst             int
+}
+
+// NewRedisRateLimiter creates a limiter allowing requestsPerSecond per token across processes
+func NewRedisRateLimiter(client *redis.Client, requestsPerSecond float64, burst int) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client:            client,
+		requestsPerSecond: requestsPerSecond,
+		burst:             burst,
+	}
+}
+
+// Wait blocks until the shared bucket for key has a token
+func (l *RedisRateLimiter) Wait(ctx context.Context, key string) error {
+	redisKey := rateLimitKey(key)
+	for {
+		wait, err := redisTokenBucket.Run(ctx, l.client, []string{redisKey}, l.requestsPerSecond, l.burst).Int64()
+		if err != nil {
+			return fmt.Errorf("failed to acquire rate limit token: %w", err)
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, time.Duration(wait)*time.Millisecond); err != nil {
+			return err
+		}
+	}
+}
+
+// rateLimitKey hashes the token so access tokens are never written to Redis
+func rateLimitKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "notion:ratelimit:" + hex.EncodeToString(sum[:16])
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package notion
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests answered with 429, or with 502, 503 or 504
+// when repeating them is safe, are retried
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt; 0 disables retrying
+	BaseDelay  time.Duration // backoff before the first retry, doubled on every retry
+	MaxDelay   time.Duration // upper bound of the backoff; Retry-After may exceed it
+}
+
+// DefaultRetryPolicy is used by clients that do not set their own
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 5,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// RetryError is returned when a request still fails after all retries.
+// Err holds the APIError of the last response when Notion sent one.
+type RetryError struct {
+	Attempts   int
+	StatusCode int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("notion request failed after %d attempts (status %d): %v", e.Attempts, e.StatusCode, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// isRetryable reports whether Notion may answer differently when asked again.
+// Rate limited requests were not processed, so any of them may be repeated. A
+// gateway error may arrive after a POST took effect, and repeating it would
+// create a second page or comment, so only idempotent methods are retried then.
+func isRetryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return method == http.MethodGet || method == http.MethodPatch || method == http.MethodDelete
+	default:
+		return false
+	}
+}
+
+// delay returns how long to wait before retry number attempt (starting at 1).
+// It uses full-jitter exponential backoff, but never waits less than Retry-After.
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	backoff := p.MaxDelay
+	if shift := attempt - 1; shift < 32 && p.BaseDelay<<shift < p.MaxDelay {
+		backoff = p.BaseDelay << shift
+	}
+
+	var jittered time.Duration
+	if backoff > 0 {
+		jittered = rand.N(backoff) + 1
+	}
+
+	return max(jittered, retryAfter)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
@@ -0,0 +1,348 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaConflict is returned by EnsureSchema when an existing property cannot be
+// made to match the desired one without a destructive change
+var ErrSchemaConflict = errors.New("notion: database schema conflict")
+
+// PropertySchema defines a database property for Databases.Create and Databases.Update.
+// Set exactly one type field; set Name alone to rename a property. A nil
+// *PropertySchema in an update deletes the property.
+type PropertySchema struct {
+	Name string `json:"name,omitempty"`
+
+	Title          *struct{}       `json:"title,omitempty"`
+	RichText       *struct{}       `json:"rich_text,omitempty"`
+	Number         *NumberSchema   `json:"number,omitempty"`
+	Select         *SelectSchema   `json:"select,omitempty"`
+	MultiSelect    *SelectSchema   `json:"multi_select,omitempty"`
+	Status         *SelectSchema   `json:"status,omitempty"`
+	Date           *struct{}       `json:"date,omitempty"`
+	People         *struct{}       `json:"people,omitempty"`
+	Files          *struct{}       `json:"files,omitempty"`
+	Checkbox       *struct{}       `json:"checkbox,omitempty"`
+	URL            *struct{}       `json:"url,omitempty"`
+	Email          *struct{}       `json:"email,omitempty"`
+	PhoneNumber    *struct{}       `json:"phone_number,omitempty"`
+	Formula        *FormulaSchema  `json:"formula,omitempty"`
+	Relation       *RelationSchema `json:"relation,omitempty"`
+	Rollup         *RollupSchema   `json:"rollup,omitempty"`
+	CreatedTime    *struct{}       `json:"created_time,omitempty"`
+	CreatedBy      *struct{}       `json:"created_by,omitempty"`
+	LastEditedTime *struct{}       `json:"last_edited_time,omitempty"`
+	LastEditedBy   *struct{}       `json:"last_edited_by,omitempty"`
+}
+
+// NumberSchema configures a number property
+type NumberSchema struct {
+	Format string `json:"format,omitempty"`
+}
+
+// SelectSchema lists the options of a select, multi_select or status property.
+// Existing options must be included in updates or Notion removes them.
+type SelectSchema struct {
+	Options []SelectOption `json:"options"`
+}
+
+// FormulaSchema configures a formula property
+type FormulaSchema struct {
+	Expression string `json:"expression"`
+}
+
+// RelationSchema configures a relation property. Type is single_property or
+// dual_property; dual relations also get a property in the related database.
+type RelationSchema struct {
+	DatabaseID     string              `json:"database_id"`
+	Type           string              `json:"type"`
+	SingleProperty *struct{}           `json:"single_property,omitempty"`
+	DualProperty   *DualPropertySchema `json:"dual_property,omitempty"`
+}
+
+// DualPropertySchema names the synced property of a dual relation
+type DualPropertySchema struct {
+	SyncedPropertyName string `json:"synced_property_name,omitempty"`
+}
+
+// RollupSchema configures a rollup property
+type RollupSchema struct {
+	RelationPropertyName string `json:"relation_property_name"`
+	RollupPropertyName   string `json:"rollup_property_name"`
+	Function             string `json:"function"`
+}
+
+// CreateDatabaseRequest represents a request to create a database
+type CreateDatabaseRequest struct {
+	Parent      Parent                     `json:"parent"`
+	Title       []RichText                 `json:"title"`
+	Description []RichText                 `json:"description,omitempty"`
+	Icon        *Icon                      `json:"icon,omitempty"`
+	Cover       *Cover                     `json:"cover,omitempty"`
+	IsInline    bool                       `json:"is_inline,omitempty"`
+	Properties  map[string]*PropertySchema `json:"properties"`
+}
+
+// UpdateDatabaseRequest represents a request to update a database.
+// Properties are keyed by their current name or ID.
+type UpdateDatabaseRequest struct {
+	Title       []RichText                 `json:"title,omitempty"`
+	Description []RichText                 `json:"description,omitempty"`
+	Icon        *Icon                      `json:"icon,omitempty"`
+	Cover       *Cover                     `json:"cover,omitempty"`
+	Properties  map[string]*PropertySchema `json:"properties,omitempty"`
+	Archived    *bool                      `json:"archived,omitempty"`
+}
+
+// TitleProperty defines a title property
+func TitleProperty() *PropertySchema { return &PropertySchema{Title: &struct{}{}} }
+
+// RichTextProperty defines a rich_text property
+func RichTextProperty() *PropertySchema { return &PropertySchema{RichText: &struct{}{}} }
+
+// NumberProperty defines a number property, e.g. with format "number" or "percent"
+func NumberProperty(format string) *PropertySchema {
+	return &PropertySchema{Number: &NumberSchema{Format: format}}
+}
+
+// SelectProperty defines a select property with the given options
+func SelectProperty(options ...SelectOption) *PropertySchema {
+	return &PropertySchema{Select: &SelectSchema{Options: nonNilOptions(options)}}
+}
+
+// MultiSelectProperty defines a multi_select property with the given options
+func MultiSelectProperty(options ...SelectOption) *PropertySchema {
+	return &PropertySchema{MultiSelect: &SelectSchema{Options: nonNilOptions(options)}}
+}
+
+// StatusProperty defines a status property with the given options
+func StatusProperty(options ...SelectOption) *PropertySchema {
+	return &PropertySchema{Status: &SelectSchema{Options: nonNilOptions(options)}}
+}
+
+// DateProperty defines a date property
+func DateProperty() *PropertySchema { return &PropertySchema{Date: &struct{}{}} }
+
+// PeopleProperty defines a people property
+func PeopleProperty() *PropertySchema { return &PropertySchema{People: &struct{}{}} }
+
+// CheckboxProperty defines a checkbox property
+func CheckboxProperty() *PropertySchema { return &PropertySchema{Checkbox: &struct{}{}} }
+
+// URLProperty defines a url property
+func URLProperty() *PropertySchema { return &PropertySchema{URL: &struct{}{}} }
+
+// FormulaProperty defines a formula property
+func FormulaProperty(expression string) *PropertySchema {
+	return &PropertySchema{Formula: &FormulaSchema{Expression: expression}}
+}
+
+// RelationProperty defines a one-way relation to pages of databaseID
+func RelationProperty(databaseID string) *PropertySchema {
+	return &PropertySchema{Relation: &RelationSchema{
+		DatabaseID:     databaseID,
+		Type:           "single_property",
+		SingleProperty: &struct{}{},
+	}}
+}
+
+// DualRelationProperty defines a relation that also adds syncedPropertyName to databaseID
+func DualRelationProperty(databaseID, syncedPropertyName string) *PropertySchema {
+	return &PropertySchema{Relation: &RelationSchema{
+		DatabaseID:   databaseID,
+		Type:         "dual_property",
+		DualProperty: &DualPropertySchema{SyncedPropertyName: syncedPropertyName},
+	}}
+}
+
+// RollupProperty defines a rollup over a relation property
+func RollupProperty(relationProperty, rollupProperty, function string) *PropertySchema {
+	return &PropertySchema{Rollup: &RollupSchema{
+		RelationPropertyName: relationProperty,
+		RollupPropertyName:   rollupProperty,
+		Function:             function,
+	}}
+}
+
+// RenameProperty renames an existing property in an update
+func RenameProperty(newName string) *PropertySchema {
+	return &PropertySchema{Name: newName}
+}
+
+// Type returns the property type the schema defines, or "" for a rename
+func (s *PropertySchema) Type() string {
+	switch {
+	case s.Title != nil:
+		return "title"
+	case s.RichText != nil:
+		return "rich_text"
+	case s.Number != nil:
+		return "number"
+	case s.Select != nil:
+		return "select"
+	case s.MultiSelect != nil:
+		return "multi_select"
+	case s.Status != nil:
+		return "status"
+	case s.Date != nil:
+		return "date"
+	case s.People != nil:
+		return "people"
+	case s.Files != nil:
+		return "files"
+	case s.Checkbox != nil:
+		return "checkbox"
+	case s.URL != nil:
+		return "url"
+	case s.Email != nil:
+		return "email"
+	case s.PhoneNumber != nil:
+		return "phone_number"
+	case s.Formula != nil:
+		return "formula"
+	case s.Relation != nil:
+		return "relation"
+	case s.Rollup != nil:
+		return "rollup"
+	case s.CreatedTime != nil:
+		return "created_time"
+	case s.CreatedBy != nil:
+		return "created_by"
+	case s.LastEditedTime != nil:
+		return "last_edited_time"
+	case s.LastEditedBy != nil:
+		return "last_edited_by"
+	default:
+		return ""
+	}
+}
+
+// SchemaChange describes one change EnsureSchema makes
+type SchemaChange struct {
+	Property string
+	Action   string   // add_property or add_options
+	Options  []string // names of added options, for add_options
+}
+
+// PlanSchema compares the desired properties with a database's current schema. It
+// returns the changes to make and the update that makes them. Only missing properties
+// and missing select, multi_select and status options are added; nothing is renamed
+// or deleted. A property whose type or relation target differs is a conflict.
+func PlanSchema(database *Database, desired map[string]*PropertySchema) ([]SchemaChange, *UpdateDatabaseRequest, error) {
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []SchemaChange
+	update := &UpdateDatabaseRequest{Properties: map[string]*PropertySchema{}}
+
+	for _, name := range names {
+		want := desired[name]
+		if want == nil || want.Type() == "" {
+			return nil, nil, fmt.Errorf("%w: %q has no property type", ErrSchemaConflict, name)
+		}
+
+		existing, ok := database.Properties[name]
+		if !ok {
+			changes = append(changes, SchemaChange{Property: name, Action: "add_property"})
+			update.Properties[name] = want
+			continue
+		}
+
+		if existing.Type != want.Type() {
+			return nil, nil, fmt.Errorf("%w: %q is %s, want %s", ErrSchemaConflict, name, existing.Type, want.Type())
+		}
+
+		switch want.Type() {
+		case "relation":
+			if existing.Relation != nil && !sameNotionID(existing.Relation.DatabaseID, want.Relation.DatabaseID) {
+				return nil, nil, fmt.Errorf("%w: %q relates to %s, want %s", ErrSchemaConflict, name, existing.Relation.DatabaseID, want.Relation.DatabaseID)
+			}
+		case "select", "multi_select", "status":
+			current, wanted := selectOptions(existing), selectSchema(want)
+			options, added := mergeOptions(current, wanted.Options)
+			if len(added) == 0 {
+				continue
+			}
+			changes = append(changes, SchemaChange{Property: name, Action: "add_options", Options: added})
+			schema := &PropertySchema{}
+			setSelectSchema(schema, want.Type(), &SelectSchema{Options: options})
+			update.Properties[name] = schema
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil, nil
+	}
+	return changes, update, nil
+}
+
+// selectOptions returns the current options of a select-like property
+func selectOptions(property Property) []SelectOption {
+	switch {
+	case property.Select != nil:
+		return property.Select.Options
+	case property.MultiSelect != nil:
+		return property.MultiSelect.Options
+	case property.Status != nil:
+		return property.Status.Options
+	default:
+		return nil
+	}
+}
+
+func selectSchema(schema *PropertySchema) *SelectSchema {
+	switch {
+	case schema.Select != nil:
+		return schema.Select
+	case schema.MultiSelect != nil:
+		return schema.MultiSelect
+	default:
+		return schema.Status
+	}
+}
+
+func setSelectSchema(schema *PropertySchema, propertyType string, options *SelectSchema) {
+	switch propertyType {
+	case "select":
+		schema.Select = options
+	case "multi_select":
+		schema.MultiSelect = options
+	case "status":
+		schema.Status = options
+	}
+}
+
+// mergeOptions appends the wanted options missing from current, matched by name.
+// Existing options keep their IDs so Notion does not recreate them.
+func mergeOptions(current, wanted []SelectOption) ([]SelectOption, []string) {
+	merged := slices.Clone(current)
+	var added []string
+	for _, option := range wanted {
+		exists := slices.ContainsFunc(current, func(o SelectOption) bool { return o.Name == option.Name })
+		if !exists {
+			merged = append(merged, SelectOption{Name: option.Name, Color: option.Color})
+			added = append(added, option.Name)
+		}
+	}
+	return merged, added
+}
+
+// sameNotionID compares IDs with or without dashes
+func sameNotionID(a, b string) bool {
+	return strings.ReplaceAll(a, "-", "") == strings.ReplaceAll(b, "-", "")
+}
+
+func nonNilOptions(options []SelectOption) []SelectOption {
+	if options == nil {
+		return []SelectOption{}
+	}
+	return options
+}
@@ -0,0 +1,163 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+const tasksDatabase = `{
+	"object": "database",
+	"id": "db-tasks",
+	"properties": {
+		"Name": {"id": "title", "name": "Name", "type": "title", "title": {}},
+		"Priority": {"id": "prio", "name": "Priority", "type": "select", "select": {"options": [
+			{"id": "opt-high", "name": "High", "color": "red"}
+		]}},
+		"Due": {"id": "due", "name": "Due", "type": "date", "date": {}},
+		"Blocked by": {"id": "blk", "name": "Blocked by", "type": "relation", "relation": {"database_id": "db-tasks", "type": "single_property", "single_property": {}}}
+	}
+}`
+
+var _ = Describe("Database schema", func() {
+	var database notion.Database
+
+	BeforeEach(func() {
+		Expect(json.Unmarshal([]byte(tasksDatabase), &database)).To(Succeed())
+	})
+
+	Describe("PlanSchema", func() {
+		It("should add missing properties and options only", func() {
+			changes, update, err := notion.PlanSchema(&database, map[string]*notion.PropertySchema{
+				"Name":           notion.TitleProperty(),
+				"Due":            notion.DateProperty(),
+				"Critical path":  notion.CheckboxProperty(),
+				"Baseline start": notion.DateProperty(),
+				"Priority":       notion.SelectProperty(notion.SelectOption{Name: "High"}, notion.SelectOption{Name: "Low", Color: "gray"}),
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(Equal([]notion.SchemaChange{
+				{Property: "Baseline start", Action: "add_property"},
+				{Property: "Critical path", Action: "add_property"},
+				{Property: "Priority", Action: "add_options", Options: []string{"Low"}},
+			}))
+
+			body, err := json.Marshal(update)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"properties": {
+				"Baseline start": {"date": {}},
+				"Critical path": {"checkbox": {}},
+				"Priority": {"select": {"options": [
+					{"id": "opt-high", "name": "High", "color": "red"},
+					{"name": "Low", "color": "gray"}
+				]}}
+			}}`))
+		})
+
+		It("should plan nothing when the schema matches", func() {
+			changes, update, err := notion.PlanSchema(&database, map[string]*notion.PropertySchema{
+				"Due":        notion.DateProperty(),
+				"Blocked by": notion.RelationProperty("db-tasks"),
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+			Expect(update).To(BeNil())
+		})
+
+		It("should refuse to change a property's type", func() {
+			_, _, err := notion.PlanSchema(&database, map[string]*notion.PropertySchema{
+				"Due": notion.RichTextProperty(),
+			})
+
+			Expect(errors.Is(err, notion.ErrSchemaConflict)).To(BeTrue())
+		})
+
+		It("should refuse to retarget a relation", func() {
+			_, _, err := notion.PlanSchema(&database, map[string]*notion.PropertySchema{
+				"Blocked by": notion.RelationProperty("db-other"),
+			})
+
+			Expect(errors.Is(err, notion.ErrSchemaConflict)).To(BeTrue())
+		})
+	})
+
+	Describe("PropertySchema", func() {
+		It("should serialize renames, deletions and relations", func() {
+			body, err := json.Marshal(notion.UpdateDatabaseRequest{Properties: map[string]*notion.PropertySchema{
+				"Old":          notion.RenameProperty("New"),
+				"Obsolete":     nil,
+				"Dependencies": notion.DualRelationProperty("db-tasks", "Dependents"),
+			}})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(body).To(MatchJSON(`{"properties": {
+				"Old": {"name": "New"},
+				"Obsolete": null,
+				"Dependencies": {"relation": {"database_id": "db-tasks", "type": "dual_property", "dual_property": {"synced_property_name": "Dependents"}}}
+			}}`))
+		})
+	})
+
+	Describe("Databases.EnsureSchema", func() {
+		var (
+			server    *httptest.Server
+			databases *notion.Databases
+			updates   []string
+		)
+
+		BeforeEach(func() {
+			updates = nil
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /v1/databases/db-tasks", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tasksDatabase))
+			})
+			mux.HandleFunc("PATCH /v1/databases/db-tasks", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				updates = append(updates, string(body))
+				w.Write([]byte(tasksDatabase))
+			})
+			server = httptest.NewServer(mux)
+
+			databases = notion.NewDatabases(
+				notion.WithBaseURL(server.URL+"/v1"),
+				notion.WithRateLimiter(nil),
+			)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should apply missing properties in one update", func() {
+			changes, err := databases.EnsureSchema(context.Background(), "token", "db-tasks", map[string]*notion.PropertySchema{
+				"Critical path":  notion.CheckboxProperty(),
+				"Baseline start": notion.DateProperty(),
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(2))
+			Expect(updates).To(HaveLen(1))
+			Expect(updates[0]).To(MatchJSON(`{"properties": {"Critical path": {"checkbox": {}}, "Baseline start": {"date": {}}}}`))
+		})
+
+		It("should not update a database that already matches", func() {
+			changes, err := databases.EnsureSchema(context.Background(), "token", "db-tasks", map[string]*notion.PropertySchema{
+				"Due": notion.DateProperty(),
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+			Expect(updates).To(BeEmpty())
+		})
+	})
+})
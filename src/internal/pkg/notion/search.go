@@ -0,0 +1,73 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// Search provides methods for searching pages and databases
+type Search struct {
+	client *Client
+}
+
+// NewSearch creates a new Search client
+func NewSearch(opts ...ClientOption) *Search {
+	return &Search{
+		client: NewClient(opts...),
+	}
+}
+
+// PagesOnly limits a search to pages
+func (r *SearchRequest) PagesOnly() *SearchRequest {
+	r.Filter = &SearchFilter{Property: "object", Value: SearchFilterPage}
+	return r
+}
+
+// DatabasesOnly limits a search to databases
+func (r *SearchRequest) DatabasesOnly() *SearchRequest {
+	r.Filter = &SearchFilter{Property: "object", Value: SearchFilterDatabase}
+	return r
+}
+
+// SortByLastEdited orders results by last edit time in the given direction
+func (r *SearchRequest) SortByLastEdited(direction string) *SearchRequest {
+	r.Sort = &SearchSort{Direction: direction, Timestamp: "last_edited_time"}
+	return r
+}
+
+// Query runs a search and returns one page of results
+func (s *Search) Query(ctx context.Context, accessToken string, request *SearchRequest) (*SearchResponse, error) {
+	if request == nil {
+		request = &SearchRequest{}
+	}
+
+	resp, err := s.client.makeRequest(ctx, "POST", "/search", request, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	var searchResp SearchResponse
+	if err := s.client.handleResponse(resp, &searchResp); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	}
+
+	return &searchResp, nil
+}
+
+// All iterates over every search result, following cursors
+func (s *Search) All(ctx context.Context, accessToken string, request *SearchRequest) iter.Seq2[SearchResult, error] {
+	var query SearchRequest
+	if request != nil {
+		query = *request
+	}
+
+	return paginate(ctx, query.StartCursor, func(ctx context.Context, cursor string) ([]SearchResult, string, bool, error) {
+		query.StartCursor = cursor
+		resp, err := s.Query(ctx, accessToken, &query)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.Results, resp.NextCursor, resp.HasMore, nil
+	})
+}
@@ -1,10 +1,19 @@
 package notion
 
+import (
+	"context"
+	"iter"
+)
+
 // Service provides a unified interface to the Notion API
 type Service struct {
 	OAuth     *OAuth
 	Databases *Databases
 	Pages     *Pages
+	Blocks    *Blocks
+	Users     *Users
+	Comments  *Comments
+	Search    *Search
 	client    *Client
 }
 
@@ -27,6 +36,10 @@ func NewService(config ServiceConfig, opts ...ClientOption) *Service {
 		OAuth:     NewOAuth(config.ClientID, config.ClientSecret, config.RedirectURI, opts...),
 		Databases: NewDatabases(opts...),
 		Pages:     NewPages(opts...),
+		Blocks:    NewBlocks(opts...),
+		Users:     NewUsers(opts...),
+		Comments:  NewComments(opts...),
+		Search:    NewSearch(opts...),
 		client:    NewClient(opts...),
 	}
 }
@@ -37,41 +50,86 @@ func (s *Service) GetAuthorizationURL(state string) string {
 }
 
 // ExchangeCodeForToken is a convenience method for OAuth token exchange
-func (s *Service) ExchangeCodeForToken(code string) (*OAuthTokenResponse, error) {
-	return s.OAuth.ExchangeCodeForToken(code)
+func (s *Service) ExchangeCodeForToken(ctx context.Context, code string) (*OAuthTokenResponse, error) {
+	return s.OAuth.ExchangeCodeForToken(ctx, code)
 }
 
 // GetCurrentUser is a convenience method for getting current user
-func (s *Service) GetCurrentUser(accessToken string) (*User, error) {
-	return s.OAuth.GetCurrentUser(accessToken)
+func (s *Service) GetCurrentUser(ctx context.Context, accessToken string) (*User, error) {
+	return s.OAuth.GetCurrentUser(ctx, accessToken)
 }
 
 // QueryDatabase is a convenience method for querying databases
-func (s *Service) QueryDatabase(accessToken, databaseID string, request *DatabaseQueryRequest) (*DatabaseQueryResponse, error) {
-	return s.Databases.Query(accessToken, databaseID, request)
+func (s *Service) QueryDatabase(ctx context.Context, accessToken, databaseID string, request *DatabaseQueryRequest) (*DatabaseQueryResponse, error) {
+	return s.Databases.Query(ctx, accessToken, databaseID, request)
+}
+
+// QueryDatabaseAll is a convenience method for iterating over all pages of a database query
+func (s *Service) QueryDatabaseAll(ctx context.Context, accessToken, databaseID string, request *DatabaseQueryRequest) iter.Seq2[Page, error] {
+	return s.Databases.QueryAll(ctx, accessToken, databaseID, request)
 }
 
 // CreatePage is a convenience method for creating pages
-func (s *Service) CreatePage(accessToken string, request *CreatePageRequest) (*Page, error) {
-	return s.Pages.Create(accessToken, request)
+func (s *Service) CreatePage(ctx context.Context, accessToken string, request *CreatePageRequest) (*Page, error) {
+	return s.Pages.Create(ctx, accessToken, request)
 }
 
 // UpdatePage is a convenience method for updating pages
-func (s *Service) UpdatePage(accessToken, pageID string, request *UpdatePageRequest) (*Page, error) {
-	return s.Pages.Update(accessToken, pageID, request)
+func (s *Service) UpdatePage(ctx context.Context, accessToken, pageID string, request *UpdatePageRequest) (*Page, error) {
+	return s.Pages.Update(ctx, accessToken, pageID, request)
 }
 
 // RetrievePage is a convenience method for retrieving pages
-func (s *Service) RetrievePage(accessToken, pageID string) (*Page, error) {
-	return s.Pages.Retrieve(accessToken, pageID)
+func (s *Service) RetrievePage(ctx context.Context, accessToken, pageID string) (*Page, error) {
+	return s.Pages.Retrieve(ctx, accessToken, pageID)
+}
+
+// EnsureDatabaseSchema is a convenience method for adding missing database properties
+func (s *Service) EnsureDatabaseSchema(ctx context.Context, accessToken, databaseID string, desired map[string]*PropertySchema) ([]SchemaChange, error) {
+	return s.Databases.EnsureSchema(ctx, accessToken, databaseID, desired)
 }
 
 // RetrieveDatabase is a convenience method for retrieving databases
-func (s *Service) RetrieveDatabase(accessToken, databaseID string) (*Database, error) {
-	return s.Databases.Retrieve(accessToken, databaseID)
+func (s *Service) RetrieveDatabase(ctx context.Context, accessToken, databaseID string) (*Database, error) {
+	return s.Databases.Retrieve(ctx, accessToken, databaseID)
 }
 
 // ListDatabases is a convenience method for listing databases
-func (s *Service) ListDatabases(accessToken string, startCursor string, pageSize int) (*DatabaseQueryResponse, error) {
-	return s.Databases.List(accessToken, startCursor, pageSize)
+func (s *Service) ListDatabases(ctx context.Context, accessToken string, startCursor string, pageSize int) (*DatabaseListResponse, error) {
+	return s.Databases.List(ctx, accessToken, startCursor, pageSize)
+}
+
+// ListAllDatabases is a convenience method for iterating over all accessible databases
+func (s *Service) ListAllDatabases(ctx context.Context, accessToken string) iter.Seq2[Database, error] {
+	return s.Databases.ListAll(ctx, accessToken, 0)
+}
+
+// RetrievePageContent is a convenience method for retrieving a page's blocks with their children
+func (s *Service) RetrievePageContent(ctx context.Context, accessToken, pageID string) ([]Block, error) {
+	return s.Blocks.RetrieveTree(ctx, accessToken, pageID, 0)
+}
+
+// AppendPageContent is a convenience method for appending blocks to a page
+func (s *Service) AppendPageContent(ctx context.Context, accessToken, pageID string, blocks []Block) ([]Block, error) {
+	return s.Blocks.Append(ctx, accessToken, pageID, blocks)
+}
+
+// ListAllUsers is a convenience method for iterating over all workspace users
+func (s *Service) ListAllUsers(ctx context.Context, accessToken string) iter.Seq2[User, error] {
+	return s.Users.ListAll(ctx, accessToken)
+}
+
+// RetrieveUser is a convenience method for retrieving a user
+func (s *Service) RetrieveUser(ctx context.Context, accessToken, userID string) (*User, error) {
+	return s.Users.Retrieve(ctx, accessToken, userID)
+}
+
+// CreateComment is a convenience method for commenting on a page or replying to a discussion
+func (s *Service) CreateComment(ctx context.Context, accessToken string, request *CreateCommentRequest) (*Comment, error) {
+	return s.Comments.Create(ctx, accessToken, request)
+}
+
+// SearchAll is a convenience method for iterating over all search results
+func (s *Service) SearchAll(ctx context.Context, accessToken string, request *SearchRequest) iter.Seq2[SearchResult, error] {
+	return s.Search.All(ctx, accessToken, request)
 }
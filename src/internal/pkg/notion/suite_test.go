@@ -0,0 +1,13 @@
+package notion_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestNotion(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Notion Client Suite")
+}
@@ -1,7 +1,9 @@
 package notion
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 )
 
@@ -70,6 +72,111 @@ type BotOwner struct {
 	User User   `json:"user"`
 }
 
+// UserListResponse represents a page of workspace users
+type UserListResponse struct {
+	Object     string `json:"object"`
+	Results    []User `json:"results"`
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Comment Types
+
+// Comment represents a comment on a page or in a discussion thread
+type Comment struct {
+	Object         string     `json:"object"`
+	ID             string     `json:"id"`
+	Parent         Parent     `json:"parent"`
+	DiscussionID   string     `json:"discussion_id"`
+	CreatedTime    time.Time  `json:"created_time"`
+	LastEditedTime time.Time  `json:"last_edited_time"`
+	CreatedBy      User       `json:"created_by"`
+	RichText       []RichText `json:"rich_text"`
+}
+
+// CreateCommentRequest represents a request to create a comment. Set Parent to start
+// a new discussion on a page, or DiscussionID to reply to an existing one.
+type CreateCommentRequest struct {
+	Parent       *Parent    `json:"parent,omitempty"`
+	DiscussionID string     `json:"discussion_id,omitempty"`
+	RichText     []RichText `json:"rich_text"`
+}
+
+// CommentListResponse represents a page of comments
+type CommentListResponse struct {
+	Object     string    `json:"object"`
+	Results    []Comment `json:"results"`
+	NextCursor string    `json:"next_cursor"`
+	HasMore    bool      `json:"has_more"`
+}
+
+// Search Types
+
+// Search filter values and sort directions
+const (
+	SearchFilterPage     = "page"
+	SearchFilterDatabase = "database"
+
+	SortAscending  = "ascending"
+	SortDescending = "descending"
+)
+
+// SearchRequest represents a search across pages and databases shared with the integration
+type SearchRequest struct {
+	Query       string        `json:"query,omitempty"`
+	Filter      *SearchFilter `json:"filter,omitempty"`
+	Sort        *SearchSort   `json:"sort,omitempty"`
+	StartCursor string        `json:"start_cursor,omitempty"`
+	PageSize    int           `json:"page_size,omitempty"`
+}
+
+// SearchFilter limits search results to pages or databases
+type SearchFilter struct {
+	Property string `json:"property"`
+	Value    string `json:"value"`
+}
+
+// SearchSort orders search results; Notion only sorts by last_edited_time
+type SearchSort struct {
+	Direction string `json:"direction"`
+	Timestamp string `json:"timestamp"`
+}
+
+// SearchResult is a page or a database, depending on Object
+type SearchResult struct {
+	Object   string
+	Page     *Page
+	Database *Database
+}
+
+// UnmarshalJSON decodes a search result into a page or database
+func (r *SearchResult) UnmarshalJSON(data []byte) error {
+	var header struct {
+		Object string `json:"object"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	r.Object = header.Object
+	switch header.Object {
+	case "database":
+		r.Database = &Database{}
+		return json.Unmarshal(data, r.Database)
+	default:
+		r.Page = &Page{}
+		return json.Unmarshal(data, r.Page)
+	}
+}
+
+// SearchResponse represents a page of search results
+type SearchResponse struct {
+	Object     string         `json:"object"`
+	Results    []SearchResult `json:"results"`
+	NextCursor string         `json:"next_cursor"`
+	HasMore    bool           `json:"has_more"`
+}
+
 // Database Types
 
 // Database represents a Notion database
@@ -106,6 +213,15 @@ type DatabaseQueryResponse struct {
 	Type       string `json:"type"`
 }
 
+// DatabaseListResponse represents the databases returned by a search
+type DatabaseListResponse struct {
+	Object     string     `json:"object"`
+	Results    []Database `json:"results"`
+	NextCursor string     `json:"next_cursor"`
+	HasMore    bool       `json:"has_more"`
+	Type       string     `json:"type"`
+}
+
 // Page Types
 
 // Page represents a Notion page
@@ -120,6 +236,7 @@ type Page struct {
 	Icon           *Icon                    `json:"icon"`
 	Parent         Parent                   `json:"parent"`
 	Archived       bool                     `json:"archived"`
+	InTrash        bool                     `json:"in_trash"`
 	Properties     map[string]PropertyValue `json:"properties"`
 	URL            string                   `json:"url"`
 }
@@ -148,6 +265,7 @@ type Parent struct {
 	Type       string `json:"type"`
 	PageID     string `json:"page_id,omitempty"`
 	DatabaseID string `json:"database_id,omitempty"`
+	BlockID    string `json:"block_id,omitempty"`
 	Workspace  bool   `json:"workspace,omitempty"`
 }
 
@@ -167,6 +285,9 @@ type Property struct {
 	MultiSelect *struct {
 		Options []SelectOption `json:"options"`
 	} `json:"multi_select,omitempty"`
+	Status *struct {
+		Options []SelectOption `json:"options"`
+	} `json:"status,omitempty"`
 	Date        *struct{} `json:"date,omitempty"`
 	People      *struct{} `json:"people,omitempty"`
 	Files       *struct{} `json:"files,omitempty"`
@@ -193,6 +314,10 @@ type Property struct {
 	CreatedBy      *struct{} `json:"created_by,omitempty"`
 	LastEditedTime *struct{} `json:"last_edited_time,omitempty"`
 	LastEditedBy   *struct{} `json:"last_edited_by,omitempty"`
+	UniqueID       *struct {
+		Prefix *string `json:"prefix"`
+	} `json:"unique_id,omitempty"`
+	Verification *struct{} `json:"verification,omitempty"`
 }
 
 // PropertyValue represents a property value on a page
@@ -206,6 +331,7 @@ type PropertyValue struct {
 	Number         *float64       `json:"number,omitempty"`
 	Select         *SelectOption  `json:"select,omitempty"`
 	MultiSelect    []SelectOption `json:"multi_select,omitempty"`
+	Status         *SelectOption  `json:"status,omitempty"`
 	Date           *DateValue     `json:"date,omitempty"`
 	People         []User         `json:"people,omitempty"`
 	Files          []File         `json:"files,omitempty"`
@@ -220,20 +346,82 @@ type PropertyValue struct {
 	CreatedBy      *User          `json:"created_by,omitempty"`
 	LastEditedTime *time.Time     `json:"last_edited_time,omitempty"`
 	LastEditedBy   *User          `json:"last_edited_by,omitempty"`
+	UniqueID       *UniqueIDValue `json:"unique_id,omitempty"`
+	Verification   *Verification  `json:"verification,omitempty"`
+
+	// HasMore is set on relation values with more than 25 entries; the rest can be
+	// read with Pages.PropertyItems
+	HasMore bool `json:"has_more,omitempty"`
+}
+
+// listPropertyTypes are cleared with an empty array rather than null
+var listPropertyTypes = map[string]bool{
+	"title":        true,
+	"rich_text":    true,
+	"multi_select": true,
+	"people":       true,
+	"files":        true,
+	"relation":     true,
+}
+
+// MarshalJSON always includes the value for Type, so an empty value clears the property
+func (v PropertyValue) MarshalJSON() ([]byte, error) {
+	type plain PropertyValue
+	data, err := json.Marshal(plain(v))
+	if err != nil || v.Type == "" {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields[v.Type]; ok {
+		return data, nil
+	}
+	fields[v.Type] = json.RawMessage("null")
+	if listPropertyTypes[v.Type] {
+		fields[v.Type] = json.RawMessage("[]")
+	}
+	return json.Marshal(fields)
+}
+
+// UniqueIDValue is the value of a unique_id property, e.g. TASK-42
+type UniqueIDValue struct {
+	Number int     `json:"number"`
+	Prefix *string `json:"prefix"`
+}
+
+// String formats the ID the way Notion displays it
+func (u UniqueIDValue) String() string {
+	if u.Prefix == nil || *u.Prefix == "" {
+		return strconv.Itoa(u.Number)
+	}
+	return *u.Prefix + "-" + strconv.Itoa(u.Number)
+}
+
+// Verification is the value of a verification property on wiki pages
+type Verification struct {
+	State      string     `json:"state"` // "verified", "unverified" or "expired"
+	VerifiedBy *User      `json:"verified_by"`
+	Date       *DateValue `json:"date"`
 }
 
 // RichText represents rich text content
 type RichText struct {
-	Type string `json:"type"`
-	Text *struct {
-		Content string `json:"content"`
-		Link    *Link  `json:"link"`
-	} `json:"text,omitempty"`
-	Mention     *Mention    `json:"mention,omitempty"`
-	Equation    *Equation   `json:"equation,omitempty"`
-	Annotations Annotations `json:"annotations"`
-	PlainText   string      `json:"plain_text"`
-	Href        string      `json:"href,omitempty"`
+	Type        string       `json:"type"`
+	Text        *TextContent `json:"text,omitempty"`
+	Mention     *Mention     `json:"mention,omitempty"`
+	Equation    *Equation    `json:"equation,omitempty"`
+	Annotations Annotations  `json:"annotations"`
+	PlainText   string       `json:"plain_text"`
+	Href        string       `json:"href,omitempty"`
+}
+
+// TextContent is the content of a text rich text object
+type TextContent struct {
+	Content string `json:"content"`
+	Link    *Link  `json:"link"`
 }
 
 // Annotations represents text formatting
@@ -337,19 +525,136 @@ type Cover struct {
 	} `json:"external,omitempty"`
 }
 
-// Block represents a content block
+// Block Types
+
+// Block represents a content block. Exactly one of the type-specific fields is set,
+// matching Type. Children holds nested blocks: it is filled by Blocks.RetrieveTree
+// and appended level by level by Blocks.Append, never sent inline.
 type Block struct {
-	Object string `json:"object"`
-	Type   string `json:"type"`
-	// Block-specific content would be added here
+	Object         string     `json:"object,omitempty"`
+	ID             string     `json:"id,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Parent         *Parent    `json:"parent,omitempty"`
+	CreatedTime    *time.Time `json:"created_time,omitempty"`
+	LastEditedTime *time.Time `json:"last_edited_time,omitempty"`
+	HasChildren    bool       `json:"has_children,omitempty"`
+	Archived       bool       `json:"archived,omitempty"`
+
+	Paragraph        *TextBlock      `json:"paragraph,omitempty"`
+	Heading1         *HeadingBlock   `json:"heading_1,omitempty"`
+	Heading2         *HeadingBlock   `json:"heading_2,omitempty"`
+	Heading3         *HeadingBlock   `json:"heading_3,omitempty"`
+	ToDo             *ToDoBlock      `json:"to_do,omitempty"`
+	BulletedListItem *TextBlock      `json:"bulleted_list_item,omitempty"`
+	NumberedListItem *TextBlock      `json:"numbered_list_item,omitempty"`
+	Toggle           *TextBlock      `json:"toggle,omitempty"`
+	Quote            *TextBlock      `json:"quote,omitempty"`
+	Code             *CodeBlock      `json:"code,omitempty"`
+	Callout          *CalloutBlock   `json:"callout,omitempty"`
+	Divider          *struct{}       `json:"divider,omitempty"`
+	ChildPage        *ChildPageBlock `json:"child_page,omitempty"`
+	ChildDatabase    *ChildPageBlock `json:"child_database,omitempty"`
+	Table            *TableBlock     `json:"table,omitempty"`
+	TableRow         *TableRowBlock  `json:"table_row,omitempty"`
+	SyncedBlock      *SyncedBlock    `json:"synced_block,omitempty"`
+	Equation         *Equation       `json:"equation,omitempty"`
+
+	Children []Block `json:"-"`
+}
+
+// Block type names
+const (
+	BlockTypeParagraph        = "paragraph"
+	BlockTypeHeading1         = "heading_1"
+	BlockTypeHeading2         = "heading_2"
+	BlockTypeHeading3         = "heading_3"
+	BlockTypeToDo             = "to_do"
+	BlockTypeBulletedListItem = "bulleted_list_item"
+	BlockTypeNumberedListItem = "numbered_list_item"
+	BlockTypeToggle           = "toggle"
+	BlockTypeQuote            = "quote"
+	BlockTypeCode             = "code"
+	BlockTypeCallout          = "callout"
+	BlockTypeDivider          = "divider"
+	BlockTypeChildPage        = "child_page"
+	BlockTypeChildDatabase    = "child_database"
+	BlockTypeTable            = "table"
+	BlockTypeTableRow         = "table_row"
+	BlockTypeSyncedBlock      = "synced_block"
+	BlockTypeEquation         = "equation"
+)
+
+// TextBlock is the content of paragraph, list item, toggle and quote blocks
+type TextBlock struct {
+	RichText []RichText `json:"rich_text"`
+	Color    string     `json:"color,omitempty"`
+}
+
+// HeadingBlock is the content of heading blocks
+type HeadingBlock struct {
+	RichText     []RichText `json:"rich_text"`
+	Color        string     `json:"color,omitempty"`
+	IsToggleable bool       `json:"is_toggleable,omitempty"`
+}
+
+// ToDoBlock is the content of to_do blocks
+type ToDoBlock struct {
+	RichText []RichText `json:"rich_text"`
+	Checked  bool       `json:"checked"`
+	Color    string     `json:"color,omitempty"`
+}
+
+// CodeBlock is the content of code blocks
+type CodeBlock struct {
+	RichText []RichText `json:"rich_text"`
+	Caption  []RichText `json:"caption,omitempty"`
+	Language string     `json:"language"`
+}
+
+// CalloutBlock is the content of callout blocks
+type CalloutBlock struct {
+	RichText []RichText `json:"rich_text"`
+	Icon     *Icon      `json:"icon,omitempty"`
+	Color    string     `json:"color,omitempty"`
+}
+
+// ChildPageBlock is the content of child_page and child_database blocks
+type ChildPageBlock struct {
+	Title string `json:"title"`
+}
+
+// TableBlock is the content of table blocks. Rows are the table's table_row
+// children; Notion requires them inline when the table is created.
+type TableBlock struct {
+	TableWidth      int     `json:"table_width"`
+	HasColumnHeader bool    `json:"has_column_header"`
+	HasRowHeader    bool    `json:"has_row_header"`
+	Children        []Block `json:"children,omitempty"`
+}
+
+// TableRowBlock is the content of table_row blocks, one rich text per cell
+type TableRowBlock struct {
+	Cells [][]RichText `json:"cells"`
+}
+
+// SyncedBlock is the content of synced_block blocks. SyncedFrom is nil for the
+// original block and points at the original for duplicates.
+type SyncedBlock struct {
+	SyncedFrom *SyncedFrom `json:"synced_from"`
+}
+
+// SyncedFrom references the original of a synced block
+type SyncedFrom struct {
+	Type    string `json:"type"`
+	BlockID string `json:"block_id"`
 }
 
-// Filter represents query filters
-type Filter struct {
-	Property string `json:"property,omitempty"`
-	// Filter conditions would be added here based on property type
-	And []Filter `json:"and,omitempty"`
-	Or  []Filter `json:"or,omitempty"`
+// BlockListResponse represents a page of block children
+type BlockListResponse struct {
+	Object     string  `json:"object"`
+	Results    []Block `json:"results"`
+	NextCursor string  `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
 }
 
 // Sort represents query sorting
@@ -0,0 +1,64 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// Users provides methods for working with workspace users
+type Users struct {
+	client *Client
+}
+
+// NewUsers creates a new Users client
+func NewUsers(opts ...ClientOption) *Users {
+	return &Users{
+		client: NewClient(opts...),
+	}
+}
+
+// Retrieve retrieves a user by ID
+func (u *Users) Retrieve(ctx context.Context, accessToken, userID string) (*User, error) {
+	endpoint := fmt.Sprintf("/users/%s", userID)
+
+	resp, err := u.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	var user User
+	if err := u.client.handleResponse(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	return &user, nil
+}
+
+// List lists one page of the workspace's users
+func (u *Users) List(ctx context.Context, accessToken string, startCursor string, pageSize int) (*UserListResponse, error) {
+	endpoint := withQuery("/users", paginationParams(startCursor, pageSize))
+
+	resp, err := u.client.makeRequest(ctx, "GET", endpoint, nil, accessToken, authTypeBearer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var listResp UserListResponse
+	if err := u.client.handleResponse(resp, &listResp); err != nil {
+		return nil, fmt.Errorf("failed to parse user list response: %w", err)
+	}
+
+	return &listResp, nil
+}
+
+// ListAll iterates over all users of the workspace, following cursors
+func (u *Users) ListAll(ctx context.Context, accessToken string) iter.Seq2[User, error] {
+	return paginate(ctx, "", func(ctx context.Context, cursor string) ([]User, string, bool, error) {
+		resp, err := u.List(ctx, accessToken, cursor, 100)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return resp.Results, resp.NextCursor, resp.HasMore, nil
+	})
+}
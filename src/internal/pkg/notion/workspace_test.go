@@ -0,0 +1,124 @@
+package notion_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"src/internal/pkg/notion"
+)
+
+var _ = Describe("Users, comments and search", func() {
+	var (
+		server   *httptest.Server
+		service  *notion.Service
+		ctx      context.Context
+		received map[string]string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		received = map[string]string{}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /v1/users", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("start_cursor") == "" {
+				w.Write([]byte(`{"object": "list", "results": [{"object": "user", "id": "user-1", "type": "person", "name": "Ada"}], "next_cursor": "c2", "has_more": true}`))
+				return
+			}
+			w.Write([]byte(`{"object": "list", "results": [{"object": "user", "id": "bot-1", "type": "bot", "name": "Overlay"}], "has_more": false}`))
+		})
+		mux.HandleFunc("GET /v1/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"object": "user", "id": "` + r.PathValue("id") + `", "type": "person", "name": "Ada", "person": {"email": "ada@example.com"}}`))
+		})
+		mux.HandleFunc("GET /v1/comments", func(w http.ResponseWriter, r *http.Request) {
+			received["comments.block_id"] = r.URL.Query().Get("block_id")
+			w.Write([]byte(`{"object": "list", "results": [
+				{"object": "comment", "id": "comment-1", "discussion_id": "disc-1", "parent": {"type": "page_id", "page_id": "page-1"}, "rich_text": [{"plain_text": "Blocked"}]}
+			], "has_more": false}`))
+		})
+		mux.HandleFunc("POST /v1/comments", func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			encoded, _ := json.Marshal(body)
+			received["comments.create"] = string(encoded)
+			w.Write([]byte(`{"object": "comment", "id": "comment-2", "discussion_id": "disc-1", "rich_text": []}`))
+		})
+		mux.HandleFunc("POST /v1/search", func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			encoded, _ := json.Marshal(body)
+			received["search"] = string(encoded)
+			w.Write([]byte(`{"object": "list", "results": [
+				{"object": "page", "id": "page-1", "url": "https://notion.so/page-1"},
+				{"object": "database", "id": "db-1", "title": [{"plain_text": "Tasks"}]}
+			], "has_more": false}`))
+		})
+
+		server = httptest.NewServer(mux)
+		service = notion.NewService(notion.ServiceConfig{}, testClientOptions(server)...)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should list all users across pages", func() {
+		users, err := notion.CollectAll(service.ListAllUsers(ctx, "token"), 0)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(HaveLen(2))
+		Expect(users[1].Type).To(Equal("bot"))
+	})
+
+	It("should retrieve a user", func() {
+		user, err := service.RetrieveUser(ctx, "token", "user-1")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(user.Person.Email).To(Equal("ada@example.com"))
+	})
+
+	It("should list comments of a page", func() {
+		comments, err := notion.CollectAll(service.Comments.ListAll(ctx, "token", "page-1"), 0)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(received["comments.block_id"]).To(Equal("page-1"))
+		Expect(comments).To(HaveLen(1))
+		Expect(comments[0].DiscussionID).To(Equal("disc-1"))
+		Expect(comments[0].Parent.PageID).To(Equal("page-1"))
+	})
+
+	It("should comment on pages and reply to discussions", func() {
+		text := notion.RichText{Type: "text", PlainText: "Dependency conflict"}
+
+		_, err := service.CreateComment(ctx, "token", notion.NewPageComment("page-1", text))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(received["comments.create"]).To(ContainSubstring(`"parent":{"page_id":"page-1","type":"page_id"}`))
+
+		comment, err := service.CreateComment(ctx, "token", notion.NewDiscussionReply("disc-1", text))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(comment.ID).To(Equal("comment-2"))
+		Expect(received["comments.create"]).To(ContainSubstring(`"discussion_id":"disc-1"`))
+		Expect(received["comments.create"]).ToNot(ContainSubstring(`"parent"`))
+	})
+
+	It("should search with a filter and sort, decoding pages and databases", func() {
+		request := (&notion.SearchRequest{Query: "roadmap"}).PagesOnly().SortByLastEdited(notion.SortDescending)
+
+		results, err := notion.CollectAll(service.SearchAll(ctx, "token", request), 0)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(received["search"]).To(MatchJSON(`{
+			"query": "roadmap",
+			"filter": {"property": "object", "value": "page"},
+			"sort": {"direction": "descending", "timestamp": "last_edited_time"}
+		}`))
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Page.URL).To(Equal("https://notion.so/page-1"))
+		Expect(results[1].Database.Title[0].PlainText).To(Equal("Tasks"))
+	})
+})
@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"src/internal/database"
+	shared "src/internal/modules/shared/domain"
+)
+
+// Outbox implements shared.EventRecorder by writing events to the outbox table.
+// When called inside shared.TransactionManager.WithinTransaction the rows are
+// written in the same transaction as the repository changes, so an event is
+// stored if and only if the changes are committed.
+type Outbox struct {
+	db *gorm.DB
+}
+
+// New creates a new transactional outbox
+func New(db *gorm.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+// Record stores events in the outbox table for the relay to publish
+func (o *Outbox) Record(ctx context.Context, events ...shared.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	records := make([]Record, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s event: %w", event.EventTopic(), err)
+		}
+
+		records = append(records, Record{
+			ID:      uuid.New(),
+			Topic:   event.EventTopic(),
+			Payload: payload,
+		})
+	}
+
+	return database.Conn(ctx, o.db).Create(&records).Error
+}
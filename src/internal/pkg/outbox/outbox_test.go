@@ -0,0 +1,162 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/gorm"
+
+	"src/internal/config"
+	"src/internal/database"
+	shared "src/internal/modules/shared/domain"
+	"src/internal/pkg/outbox"
+)
+
+var db *gorm.DB
+
+var errRollback = errors.New("rollback requested")
+
+type testEvent struct {
+	Name string `json:"name"`
+}
+
+func (testEvent) EventTopic() string {
+	return "test.event"
+}
+
+func mustStartPostgresContainer() (func(context.Context, ...testcontainers.TerminateOption) error, error) {
+	var (
+		dbName = "test_database"
+		dbPwd  = "test_password"
+		dbUser = "test_user"
+	)
+
+	dbContainer, err := postgres.Run(
+		context.Background(),
+		"postgres:latest",
+		postgres.WithDatabase(dbName),
+		postgres.WithUsername(dbUser),
+		postgres.WithPassword(dbPwd),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbHost, err := dbContainer.Host(context.Background())
+	if err != nil {
+		return dbContainer.Terminate, err
+	}
+
+	dbPort, err := dbContainer.MappedPort(context.Background(), "5432/tcp")
+	if err != nil {
+		return dbContainer.Terminate, err
+	}
+
+	testConfig := &config.Config{
+		Port: 8080, // Default for tests
+	}
+	testConfig.Database.Host = dbHost
+	testConfig.Database.Port = dbPort.Port()
+	testConfig.Database.Username = dbUser
+	testConfig.Database.Password = dbPwd
+	testConfig.Database.Database = dbName
+	testConfig.Database.Schema = "public"
+
+	config.SetForTests(testConfig)
+
+	return dbContainer.Terminate, err
+}
+
+var teardown func(context.Context, ...testcontainers.TerminateOption) error
+
+var _ = BeforeSuite(func() {
+	var err error
+	teardown, err = mustStartPostgresContainer()
+	Expect(err).ToNot(HaveOccurred())
+
+	db = database.GormDB()
+	if err := database.Migrator().AutoMigrate(&outbox.Record{}); err != nil {
+		Fail("Failed to run AutoMigrate: " + err.Error())
+	}
+})
+
+var _ = AfterSuite(func() {
+	if teardown != nil {
+		if err := teardown(context.Background()); err != nil {
+			log.Fatalf("could not teardown postgres container: %v", err)
+		}
+	}
+})
+
+// records returns all outbox rows, oldest first
+func records() []outbox.Record {
+	var records []outbox.Record
+	Expect(db.Order("created_at ASC").Find(&records).Error).To(Succeed())
+	return records
+}
+
+var _ = Describe("Outbox", func() {
+	var (
+		ctx    context.Context
+		txMgr  shared.TransactionManager
+		events *outbox.Outbox
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		txMgr = database.NewTransactionManager(db)
+		events = outbox.New(db)
+		db.Exec("TRUNCATE TABLE outbox_messages")
+	})
+
+	It("stores events recorded in a committed transaction", func() {
+		err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+			return events.Record(ctx, testEvent{Name: "first"}, testEvent{Name: "second"})
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		stored := records()
+		Expect(stored).To(HaveLen(2))
+		Expect(stored[0].Topic).To(Equal("test.event"))
+		Expect(stored[0].PublishedAt).To(BeNil())
+		var event testEvent
+		Expect(json.Unmarshal(stored[0].Payload, &event)).To(Succeed())
+		Expect(event.Name).To(BeElementOf("first", "second"))
+	})
+
+	It("drops events recorded in a rolled back transaction", func() {
+		err := txMgr.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := events.Record(ctx, testEvent{Name: "rolled back"}); err != nil {
+				return err
+			}
+
+			// The row is visible inside the transaction
+			var count int64
+			Expect(database.Conn(ctx, db).Model(&outbox.Record{}).Count(&count).Error).To(Succeed())
+			Expect(count).To(Equal(int64(1)))
+
+			return errRollback
+		})
+		Expect(err).To(MatchError(errRollback))
+
+		Expect(records()).To(BeEmpty())
+	})
+
+	It("does nothing without events", func() {
+		Expect(events.Record(ctx)).To(Succeed())
+
+		Expect(records()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Record represents the outbox_messages table structure in PostgreSQL
+type Record struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Topic       string     `gorm:"not null;type:varchar(255)"`
+	Payload     []byte     `gorm:"not null;type:jsonb"`
+	CreatedAt   time.Time  `gorm:"not null;index:idx_outbox_unpublished,where:published_at IS NULL"`
+	PublishedAt *time.Time `gorm:""`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string     `gorm:"type:text"`
+	// NextAttemptAt hides a row from the relay until then, while it is claimed or
+	// waiting to be retried
+	NextAttemptAt *time.Time `gorm:""`
+}
+
+// TableName specifies the table name for GORM
+func (Record) TableName() string {
+	return "outbox_messages"
+}
@@ -0,0 +1,176 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+	defaultMaxAttempts  = 10
+
+	// claimTimeout is how long a claimed row is hidden from other relays; a relay
+	// that stops mid-batch leaves its rows to be published again after it
+	claimTimeout = time.Minute
+
+	firstRetryDelay = time.Second
+	maxRetryDelay   = time.Hour
+)
+
+// Relay forwards committed outbox rows to the Watermill publisher.
+// Rows are claimed in a short transaction with SELECT ... FOR UPDATE SKIP LOCKED,
+// published outside of it, then marked in a second short transaction, so no
+// connection or row lock is held while the publisher is called. Several relays
+// can run at once. Rows are marked as published only after a successful Publish,
+// so delivery is at-least-once: consumers should deduplicate on the message UUID,
+// which is the outbox row ID. A failed row is retried with exponential backoff
+// until it has been tried maxAttempts times; it then stays in the table, with its
+// last error, for an operator to inspect.
+type Relay struct {
+	db           *gorm.DB
+	publisher    message.Publisher
+	logger       *log.Logger
+	batchSize    int
+	pollInterval time.Duration
+	maxAttempts  int
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(db *gorm.DB, publisher message.Publisher, logger *log.Logger, batchSize int, pollInterval time.Duration, maxAttempts int) *Relay {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	return &Relay{
+		db:           db,
+		publisher:    publisher,
+		logger:       logger,
+		batchSize:    batchSize,
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+	}
+}
+
+// Run relays outbox rows until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the backlog before waiting for the next tick
+		for {
+			relayed, err := r.RelayBatch(ctx)
+			if err != nil {
+				r.logger.Printf("Outbox relay batch failed: %v", err)
+				break
+			}
+			if relayed < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayBatch publishes one batch of due rows and returns how many were published
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	records, err := r.claim(ctx)
+	if err != nil || len(records) == 0 {
+		return 0, err
+	}
+
+	failures := make(map[uuid.UUID]error)
+	for _, record := range records {
+		msg := message.NewMessage(record.ID.String(), record.Payload)
+		if err := r.publisher.Publish(record.Topic, msg); err != nil {
+			r.logger.Printf("Failed to relay outbox message %s to topic %s: %v", record.ID, record.Topic, err)
+			failures[record.ID] = err
+		}
+	}
+
+	now := time.Now()
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, record := range records {
+			updates := map[string]any{
+				"published_at":    now,
+				"attempts":        gorm.Expr("attempts + 1"),
+				"last_error":      "",
+				"next_attempt_at": nil,
+			}
+			if err, failed := failures[record.ID]; failed {
+				attempts := record.Attempts + 1
+				if attempts >= r.maxAttempts {
+					r.logger.Printf("Giving up on outbox message %s after %d attempts", record.ID, attempts)
+				}
+				updates = map[string]any{
+					"attempts":        gorm.Expr("attempts + 1"),
+					"last_error":      err.Error(),
+					"next_attempt_at": now.Add(retryDelay(attempts)),
+				}
+			}
+
+			if err := tx.Model(&Record{}).Where("id = ?", record.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(records) - len(failures), nil
+}
+
+// claim locks the oldest due rows, hides them from other relays for claimTimeout
+// and returns them
+func (r *Relay) claim(ctx context.Context) ([]Record, error) {
+	var records []Record
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND attempts < ?", r.maxAttempts).
+			Where("(next_attempt_at IS NULL OR next_attempt_at <= ?)", now).
+			Order("created_at ASC").
+			Limit(r.batchSize).
+			Find(&records).Error
+		if err != nil || len(records) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(records))
+		for i, record := range records {
+			ids[i] = record.ID
+		}
+		return tx.Model(&Record{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(claimTimeout)).Error
+	})
+	return records, err
+}
+
+// retryDelay is how long to wait before trying a row again after its nth failed
+// attempt: one second, doubling with every attempt, at most an hour
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
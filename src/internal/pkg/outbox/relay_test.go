@@ -0,0 +1,152 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/gorm/clause"
+
+	"src/internal/database"
+	"src/internal/pkg/outbox"
+)
+
+var errPublish = errors.New("broker unavailable")
+
+// mockPublisher keeps published messages and fails while err is set
+type mockPublisher struct {
+	messages  []*message.Message
+	err       error
+	onPublish func()
+}
+
+func (m *mockPublisher) Publish(topic string, messages ...*message.Message) error {
+	if m.onPublish != nil {
+		m.onPublish()
+	}
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, messages...)
+	return nil
+}
+
+func (m *mockPublisher) Close() error {
+	return nil
+}
+
+var _ = Describe("Relay", func() {
+	var (
+		ctx       context.Context
+		publisher *mockPublisher
+		relay     *outbox.Relay
+	)
+
+	// makeDue lets the relay retry failed rows without waiting for their backoff
+	makeDue := func() {
+		Expect(db.Model(&outbox.Record{}).Where("next_attempt_at IS NOT NULL").Update("next_attempt_at", time.Now().Add(-time.Second)).Error).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		publisher = &mockPublisher{}
+		relay = outbox.NewRelay(db, publisher, log.New(io.Discard, "", 0), 10, time.Second, 3)
+		db.Exec("TRUNCATE TABLE outbox_messages")
+
+		Expect(outbox.New(db).Record(ctx, testEvent{Name: "created"})).To(Succeed())
+	})
+
+	It("publishes pending rows once and marks them published", func() {
+		relayed, err := relay.RelayBatch(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relayed).To(Equal(1))
+
+		stored := records()[0]
+		Expect(publisher.messages).To(HaveLen(1))
+		Expect(publisher.messages[0].UUID).To(Equal(stored.ID.String()))
+		Expect(publisher.messages[0].Payload).To(MatchJSON(`{"name":"created"}`))
+		Expect(stored.PublishedAt).ToNot(BeNil())
+		Expect(stored.Attempts).To(Equal(1))
+		Expect(stored.NextAttemptAt).To(BeNil())
+
+		relayed, err = relay.RelayBatch(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relayed).To(BeZero())
+		Expect(publisher.messages).To(HaveLen(1))
+	})
+
+	It("records a failed publish and retries the row after a delay", func() {
+		publisher.err = errPublish
+
+		relayed, err := relay.RelayBatch(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relayed).To(BeZero())
+
+		stored := records()[0]
+		Expect(stored.PublishedAt).To(BeNil())
+		Expect(stored.Attempts).To(Equal(1))
+		Expect(stored.LastError).To(Equal(errPublish.Error()))
+		Expect(*stored.NextAttemptAt).To(BeTemporally(">", time.Now()))
+
+		// The row is not retried before its delay has passed
+		publisher.err = nil
+		relayed, err = relay.RelayBatch(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relayed).To(BeZero())
+
+		makeDue()
+		relayed, err = relay.RelayBatch(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relayed).To(Equal(1))
+		stored = records()[0]
+		Expect(stored.PublishedAt).ToNot(BeNil())
+		Expect(stored.Attempts).To(Equal(2))
+		Expect(stored.LastError).To(BeEmpty())
+	})
+
+	It("stops retrying a row after the maximum number of attempts", func() {
+		publisher.err = errPublish
+		for range 3 {
+			_, err := relay.RelayBatch(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			makeDue()
+		}
+
+		publisher.err = nil
+		relayed, err := relay.RelayBatch(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relayed).To(BeZero())
+
+		stored := records()[0]
+		Expect(publisher.messages).To(BeEmpty())
+		Expect(stored.PublishedAt).To(BeNil())
+		Expect(stored.Attempts).To(Equal(3))
+		Expect(stored.LastError).To(Equal(errPublish.Error()))
+	})
+
+	It("publishes without holding a lock on the claimed rows", func() {
+		other := outbox.NewRelay(db, &mockPublisher{}, log.New(io.Discard, "", 0), 10, time.Second, 3)
+		publisher.onPublish = func() {
+			var locked []outbox.Record
+			err := database.GormDB().
+				Clauses(clause.Locking{Strength: "UPDATE", Options: "NOWAIT"}).
+				Find(&locked).Error
+			Expect(err).ToNot(HaveOccurred())
+
+			// Other relays skip the claimed row
+			relayed, err := other.RelayBatch(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(relayed).To(BeZero())
+		}
+
+		relayed, err := relay.RelayBatch(ctx)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(relayed).To(Equal(1))
+	})
+})
@@ -1,9 +1,17 @@
 package taskqueue
 
 import (
+	"context"
+
 	"github.com/hibiken/asynq"
 )
 
+// Enqueuer is the subset of asynq.Client used to schedule tasks, so that code
+// scheduling jobs can be tested without Redis
+type Enqueuer interface {
+	EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
 // NewClient creates a new Asynq client
 func NewClient(redisOpt asynq.RedisConnOpt) *asynq.Client {
 	return asynq.NewClient(redisOpt)
@@ -13,8 +13,16 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/go-chi/render"
 
+	"src/internal/config"
+	"src/internal/database"
+	projectsPostgres "src/internal/modules/projects/infrastructure/postgres"
 	projectsHTTP "src/internal/modules/projects/interfaces/http"
+	shared "src/internal/modules/shared/domain"
+	tasksHTTP "src/internal/modules/tasks/interfaces/http"
 	usersHTTP "src/internal/modules/users/interfaces/http"
+	webhookApp "src/internal/modules/webhooks/application"
+	webhookInfra "src/internal/modules/webhooks/infrastructure/http"
+	webhookPostgres "src/internal/modules/webhooks/infrastructure/postgres"
 	webhooksHTTP "src/internal/modules/webhooks/interfaces/http"
 	authmw "src/internal/pkg/middleware"
 )
@@ -39,17 +47,45 @@ func (s *Server) RegisterRoutes() http.Handler {
 	// API v1 feature routers
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Mount("/users", usersHTTP.NewRouter())
-		r.Mount("/auth", usersHTTP.NewAuthRouter())
+		r.Mount("/auth", usersHTTP.NewAuthRouter(s.notionOpts...))
 
 		// Protected routes requiring authentication
 		r.Route("/projects", func(r chi.Router) {
 			r.Use(authmw.JWTAuthMiddleware)
-			r.Mount("/", projectsHTTP.NewRouter())
+			r.Mount("/", projectsHTTP.NewRouter(s.notionOpts...))
 		})
 
+		r.Route("/tasks", func(r chi.Router) {
+			r.Use(authmw.JWTAuthMiddleware)
+			r.Mount("/", tasksHTTP.NewRouter(s.notionOpts...))
+		})
+
+		projectRepo := projectsPostgres.NewProjectRepository(database.GormDB())
+		deliveryRepo := webhookPostgres.NewDeliveryRepository(database.GormDB())
+
 		// Webhook routes with signature validation
 		r.Route("/webhooks", func(r chi.Router) {
-			r.Mount("/", webhooksHTTP.NewRouter(s.publisher))
+			resolver := webhookInfra.NewProjectSecretResolver(projectRepo)
+			deduplicator := webhookInfra.NewRedisDeduplicator(s.redisClient, config.Get().Webhooks.ReplayWindow)
+			r.Mount("/", webhooksHTTP.NewRouter(
+				s.publisher,
+				webhooksHTTP.WithSecretResolver(resolver),
+				webhooksHTTP.WithDeduplicator(deduplicator),
+				webhooksHTTP.WithDeliveryLog(deliveryRepo),
+			))
+		})
+
+		// Protected webhook delivery log
+		r.Route("/webhook-deliveries", func(r chi.Router) {
+			r.Use(authmw.JWTAuthMiddleware)
+			deliveryService := webhookApp.NewDeliveryService(
+				deliveryRepo,
+				projectRepo,
+				webhookInfra.NewWatermillEventPublisher(s.publisher, log.Default()),
+				shared.NewSystemClock(),
+				shared.NewUUIDGenerator(),
+			)
+			r.Mount("/", webhooksHTTP.NewDeliveriesRouter(deliveryService))
 		})
 	})
 
@@ -13,6 +13,7 @@ import (
 	"src/internal/config"
 	"src/internal/database"
 	"src/internal/pkg/eventbus"
+	"src/internal/pkg/notion"
 )
 
 type Server struct {
@@ -20,6 +21,8 @@ type Server struct {
 	db          database.Service
 	redisClient *redis.Client
 	publisher   message.Publisher
+	// notionOpts are shared by every Notion client, so they share one rate limiter
+	notionOpts []notion.ClientOption
 }
 
 func NewServer() *http.Server {
@@ -43,6 +46,7 @@ func NewServer() *http.Server {
 		redisClient: redisClient,
 		db:          database.New(),
 		publisher:   publisher,
+		notionOpts:  notion.ClientOptionsFromConfig(redisClient),
 	}
 
 	// Declare Server config
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+
+	"src/internal/database"
+	"src/internal/pkg/outbox"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateOutbox, downCreateOutbox)
+}
+
+func upCreateOutbox(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	return m.AutoMigrate(&outbox.Record{})
+}
+
+func downCreateOutbox(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	return m.DropTable(&outbox.Record{})
+}
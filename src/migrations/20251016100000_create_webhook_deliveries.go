@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+
+	"src/internal/database"
+	webhookpg "src/internal/modules/webhooks/infrastructure/postgres"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateWebhookDeliveries, downCreateWebhookDeliveries)
+}
+
+func upCreateWebhookDeliveries(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	return m.AutoMigrate(&webhookpg.DeliveryRecord{})
+}
+
+func downCreateWebhookDeliveries(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	return m.DropTable(&webhookpg.DeliveryRecord{})
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pressly/goose/v3"
+
+	"src/internal/database"
+	taskpg "src/internal/modules/tasks/infrastructure/postgres"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateTasks, downCreateTasks)
+}
+
+// projectSyncRecord is the project_syncs table as created by this migration; the
+// sync state has since moved to the projects table
+type projectSyncRecord struct {
+	ProjectID   uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Status      string    `gorm:"not null;type:varchar(32);index"`
+	Cursor      string    `gorm:"type:varchar(255)"`
+	PagesSynced int       `gorm:"not null;default:0"`
+	LastError   string    `gorm:"type:text"`
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	UpdatedAt   time.Time `gorm:"not null"`
+}
+
+func (projectSyncRecord) TableName() string {
+	return "project_syncs"
+}
+
+func upCreateTasks(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	return m.AutoMigrate(&taskpg.TaskRecord{}, &projectSyncRecord{})
+}
+
+func downCreateTasks(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	return m.DropTable(&projectSyncRecord{}, &taskpg.TaskRecord{})
+}
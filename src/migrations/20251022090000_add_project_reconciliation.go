@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+
+	"src/internal/database"
+	projectpg "src/internal/modules/projects/infrastructure/postgres"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddProjectReconciliation, downAddProjectReconciliation)
+}
+
+func upAddProjectReconciliation(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	// Adds projects.reconcile_interval and creates project_reconciliations
+	return m.AutoMigrate(&projectpg.ProjectRecord{}, &projectpg.ReconciliationRecord{})
+}
+
+func downAddProjectReconciliation(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	if err := m.DropTable(&projectpg.ReconciliationRecord{}); err != nil {
+		return err
+	}
+	return m.DropColumn(&projectpg.ProjectRecord{}, "ReconcileInterval")
+}
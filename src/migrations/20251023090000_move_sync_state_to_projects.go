@@ -0,0 +1,114 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upMoveSyncStateToProjects, downMoveSyncStateToProjects)
+}
+
+func upMoveSyncStateToProjects(ctx context.Context, tx *sql.Tx) error {
+	// The columns are added in the migration transaction, so a failed backfill
+	// leaves the projects table as it was
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE projects
+			ADD COLUMN IF NOT EXISTS sync_status varchar(32) NOT NULL DEFAULT 'pending',
+			ADD COLUMN IF NOT EXISTS sync_status_changed_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			ADD COLUMN IF NOT EXISTS sync_last_error text,
+			ADD COLUMN IF NOT EXISTS sync_cursor varchar(255),
+			ADD COLUMN IF NOT EXISTS sync_pages_synced bigint NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS sync_started_at timestamptz,
+			ADD COLUMN IF NOT EXISTS synced_at timestamptz;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_projects_sync_status ON projects (sync_status);`)
+	if err != nil {
+		return err
+	}
+
+	// Carry over the initial sync progress, mapping the old statuses
+	_, err = tx.ExecContext(ctx, `
+		UPDATE projects p SET
+			sync_status = CASE s.status
+				WHEN 'running' THEN 'initial_syncing'
+				WHEN 'completed' THEN 'synced'
+				ELSE s.status
+			END,
+			sync_status_changed_at = s.updated_at,
+			sync_last_error = s.last_error,
+			sync_cursor = s.cursor,
+			sync_pages_synced = s.pages_synced,
+			sync_started_at = s.started_at,
+			synced_at = s.completed_at
+		FROM project_syncs s
+		WHERE s.project_id = p.id;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DROP TABLE IF EXISTS project_syncs;`)
+	return err
+}
+
+func downMoveSyncStateToProjects(ctx context.Context, tx *sql.Tx) error {
+	// project_syncs as created by create_tasks (see projectSyncRecord)
+	_, err := tx.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS project_syncs (
+			project_id uuid PRIMARY KEY,
+			status varchar(32) NOT NULL,
+			cursor varchar(255),
+			pages_synced bigint NOT NULL DEFAULT 0,
+			last_error text,
+			started_at timestamptz,
+			completed_at timestamptz,
+			updated_at timestamptz NOT NULL
+		);
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_project_syncs_status ON project_syncs (status);`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO project_syncs (project_id, status, cursor, pages_synced, last_error, started_at, completed_at, updated_at)
+		SELECT id,
+			CASE sync_status
+				WHEN 'initial_syncing' THEN 'running'
+				WHEN 'synced' THEN 'completed'
+				WHEN 'degraded' THEN 'completed'
+				WHEN 'token_revoked' THEN 'failed'
+				ELSE sync_status
+			END,
+			sync_cursor, sync_pages_synced, sync_last_error, sync_started_at, synced_at, sync_status_changed_at
+		FROM projects
+		WHERE sync_status <> 'pending';
+	`)
+	if err != nil {
+		return err
+	}
+
+	// Dropping sync_status drops its index with it
+	_, err = tx.ExecContext(ctx, `
+		ALTER TABLE projects
+			DROP COLUMN IF EXISTS sync_status,
+			DROP COLUMN IF EXISTS sync_status_changed_at,
+			DROP COLUMN IF EXISTS sync_last_error,
+			DROP COLUMN IF EXISTS sync_cursor,
+			DROP COLUMN IF EXISTS sync_pages_synced,
+			DROP COLUMN IF EXISTS sync_started_at,
+			DROP COLUMN IF EXISTS synced_at;
+	`)
+	return err
+}
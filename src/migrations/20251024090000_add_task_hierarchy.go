@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddTaskHierarchy, downAddTaskHierarchy)
+}
+
+func upAddTaskHierarchy(ctx context.Context, tx *sql.Tx) error {
+	// Everything runs in the migration transaction, so a failure leaves no column
+	// behind. The tasks table of a new database already has the columns.
+	statements := []string{
+		`ALTER TABLE tasks
+			ADD COLUMN IF NOT EXISTS status varchar(255) NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS priority varchar(255) NOT NULL DEFAULT '',
+			ADD COLUMN IF NOT EXISTS start_date timestamptz,
+			ADD COLUMN IF NOT EXISTS due_date timestamptz,
+			ADD COLUMN IF NOT EXISTS assignees jsonb,
+			ADD COLUMN IF NOT EXISTS parent_notion_page_id varchar(255),
+			ADD COLUMN IF NOT EXISTS parent_id uuid;`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_project_status ON tasks (project_id, status);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks (due_date);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_project_parent_page ON tasks (project_id, parent_notion_page_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);`,
+		// Deleting a task detaches its sub-items rather than deleting them, as in Notion
+		`ALTER TABLE tasks
+			ADD CONSTRAINT fk_tasks_parent FOREIGN KEY (parent_id) REFERENCES tasks (id) ON DELETE SET NULL;`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func downAddTaskHierarchy(ctx context.Context, tx *sql.Tx) error {
+	// Dropping the columns drops their indexes and fk_tasks_parent with them
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE tasks
+			DROP COLUMN IF EXISTS status,
+			DROP COLUMN IF EXISTS priority,
+			DROP COLUMN IF EXISTS start_date,
+			DROP COLUMN IF EXISTS due_date,
+			DROP COLUMN IF EXISTS assignees,
+			DROP COLUMN IF EXISTS parent_notion_page_id,
+			DROP COLUMN IF EXISTS parent_id;
+	`)
+	return err
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+
+	"src/internal/database"
+	projectpg "src/internal/modules/projects/infrastructure/postgres"
+)
+
+func init() {
+	goose.AddMigrationContext(upAddProjectPropertyMapping, downAddProjectPropertyMapping)
+}
+
+func upAddProjectPropertyMapping(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	// Adds projects.property_mapping; existing projects get a suggested mapping on their next sync
+	return m.AutoMigrate(&projectpg.ProjectRecord{})
+}
+
+func downAddProjectPropertyMapping(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	return m.DropColumn(&projectpg.ProjectRecord{}, "PropertyMapping")
+}
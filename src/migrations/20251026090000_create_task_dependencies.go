@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pressly/goose/v3"
+
+	"src/internal/database"
+	taskpg "src/internal/modules/tasks/infrastructure/postgres"
+)
+
+func init() {
+	goose.AddMigrationContext(upCreateTaskDependencies, downCreateTaskDependencies)
+}
+
+func upCreateTaskDependencies(ctx context.Context, tx *sql.Tx) error {
+	m := database.Migrator()
+	// Adds tasks.predecessor_page_ids and creates task_dependencies
+	if err := m.AutoMigrate(&taskpg.TaskRecord{}, &taskpg.DependencyRecord{}); err != nil {
+		return err
+	}
+
+	// Deleting a task removes its links in both directions
+	_, err := tx.ExecContext(ctx, `
+		ALTER TABLE task_dependencies
+		ADD CONSTRAINT fk_task_dependencies_predecessor FOREIGN KEY (predecessor_id) REFERENCES tasks (id) ON DELETE CASCADE,
+		ADD CONSTRAINT fk_task_dependencies_successor FOREIGN KEY (successor_id) REFERENCES tasks (id) ON DELETE CASCADE,
+		ADD CONSTRAINT chk_task_dependencies_type CHECK (type IN ('FS', 'SS', 'FF', 'SF')),
+		ADD CONSTRAINT chk_task_dependencies_not_self CHECK (predecessor_id <> successor_id);
+	`)
+	return err
+}
+
+func downCreateTaskDependencies(ctx context.Context, _ *sql.Tx) error {
+	m := database.Migrator()
+	if err := m.DropTable(&taskpg.DependencyRecord{}); err != nil {
+		return err
+	}
+	return m.DropColumn(&taskpg.TaskRecord{}, "PredecessorPageIDs")
+}